@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	goflag "flag"
 	"fmt"
 	"os"
@@ -11,6 +13,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
@@ -43,7 +46,25 @@ func main() {
 
 	streams := genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
 	rootCmd := cmd.NewCmdSHP(&streams)
-	if err := rootCmd.Execute(); err != nil {
+	cmd.ConfigureDisplayName(rootCmd, os.Args[0])
+
+	// the context is canceled on interruption, the command is expected to return promptly
+	ctx, stop := cmd.NotifyContext(context.Background())
+	err := rootCmd.ExecuteContext(ctx)
+	interrupted := ctx.Err() != nil
+	stop()
+
+	if interrupted {
+		os.Exit(cmd.ExitCodeInterrupted)
+	}
+	var exitErr *runner.ExitError
+	if errors.As(err, &exitErr) {
+		if exitErr.Err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %v\n", exitErr.Err)
+		}
+		os.Exit(exitErr.Code)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
 		os.Exit(1)
 	}
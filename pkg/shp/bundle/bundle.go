@@ -2,14 +2,26 @@ package bundle
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
+	"github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/types"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	progressbar "github.com/schollz/progressbar/v3"
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	buildbundle "github.com/shipwright-io/build/pkg/bundle"
@@ -17,6 +29,110 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// RegistryOptions controls how the CLI itself connects to the container registry, it does not
+// affect the registry access performed by the build running in the cluster.
+type RegistryOptions struct {
+	// InsecureSkipTLSVerify disables the verification of the registry certificate, and allows
+	// plain HTTP registries. Dangerous, only meant for registries under the user's control.
+	InsecureSkipTLSVerify bool
+	// CAFile path to a PEM encoded CA bundle trusted in addition to the system certificates.
+	CAFile string
+	// DockerConfig path to a Docker config.json file, or the directory holding it, to read the
+	// registry credentials from. When empty the default keychain is used.
+	DockerConfig string
+}
+
+// nameOptions returns the image reference parsing options.
+func (r RegistryOptions) nameOptions() []name.Option {
+	if r.InsecureSkipTLSVerify {
+		return []name.Option{name.Insecure}
+	}
+	return nil
+}
+
+// transport returns the HTTP transport for the registry calls, honoring the TLS settings.
+func (r RegistryOptions) transport() (http.RoundTripper, error) {
+	t := remote.DefaultTransport.(*http.Transport).Clone()
+	if !r.InsecureSkipTLSVerify && r.CAFile == "" {
+		return t, nil
+	}
+
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	t.TLSClientConfig.InsecureSkipVerify = r.InsecureSkipTLSVerify // #nosec G402 explicitly requested by the user
+	if r.CAFile != "" {
+		pem, err := os.ReadFile(r.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid PEM certificates found in %q", r.CAFile)
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
+	return t, nil
+}
+
+// keychain returns the keychain to resolve the registry credentials.
+func (r RegistryOptions) keychain() authn.Keychain {
+	if r.DockerConfig == "" {
+		return authn.DefaultKeychain
+	}
+	return dockerConfigKeychain{path: r.DockerConfig}
+}
+
+// dockerConfigKeychain resolves the registry credentials using a specific Docker config file.
+type dockerConfigKeychain struct {
+	path string
+}
+
+// Resolve looks up the credentials for the target repository, or its registry, in the Docker config
+// file. Anonymous access is used when there are no credentials for the target.
+func (k dockerConfigKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	path := k.path
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, config.ConfigFileName)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read docker config: %w", err)
+	}
+	defer f.Close()
+
+	cf, err := config.LoadFromReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse docker config %q: %w", path, err)
+	}
+
+	var empty types.AuthConfig
+	for _, key := range []string{target.String(), target.RegistryStr()} {
+		if key == name.DefaultRegistry {
+			key = authn.DefaultAuthKey
+		}
+		cfg, err := cf.GetAuthConfig(key)
+		if err != nil {
+			return nil, err
+		}
+		// the server address is always set, clearing it to check whether credentials were found
+		cfg.ServerAddress = ""
+		if cfg != empty {
+			return authn.FromConfig(authn.AuthConfig{
+				Username:      cfg.Username,
+				Password:      cfg.Password,
+				Auth:          cfg.Auth,
+				IdentityToken: cfg.IdentityToken,
+				RegistryToken: cfg.RegistryToken,
+			}), nil
+		}
+	}
+	return authn.Anonymous, nil
+}
+
 // GetSourceBundleImage returns the source bundle image of the build that is
 // associated with the provided buildrun, an empty string if source bundle is
 // not used, or an error in case the build cannot be obtained
@@ -41,12 +157,41 @@ func GetSourceBundleImage(ctx context.Context, client buildclientset.Interface,
 	return "", nil
 }
 
+// ContentTag returns the tag identifying the source bundle image by its digest, i.e.
+// "sha256-<hex>", so identical source trees map to the same tag.
+func ContentTag(digest v1.Hash) string {
+	return fmt.Sprintf("%s-%s", digest.Algorithm, digest.Hex)
+}
+
+// Pack packs the local directory into the source bundle image, the same way the build controller
+// does. The image creation time is fixed, so the image digest only depends on the files packed.
+func Pack(localDirectory string) (v1.Image, error) {
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) { return buildbundle.Pack(localDirectory) })
+	if err != nil {
+		return nil, err
+	}
+	image, err := mutate.Time(empty.Image, time.Unix(0, 0))
+	if err != nil {
+		return nil, err
+	}
+	return mutate.AppendLayers(image, layer)
+}
+
 // Push bundles the provided local directory into a container image and pushes
 // it to the given registry. For this to work, it relies on valid and working
 // container registry access credentials and tokens to be available in the
 // local system, for example logins done by `docker login` or similar.
-func Push(ctx context.Context, io *genericclioptions.IOStreams, localDirectory string, targetImage string) (name.Digest, error) {
-	tag, err := name.NewTag(targetImage)
+//
+// The image is pushed with both the target tag and its content tag. Unless
+// forced, the upload is skipped when the target tag already points to the same
+// digest, and when only the content tag does, the target tag is moved to it.
+func Push(ctx context.Context, io *genericclioptions.IOStreams, localDirectory string, targetImage string, registryOpts RegistryOptions, force bool) (name.Digest, error) {
+	tag, err := name.NewTag(targetImage, registryOpts.nameOptions()...)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	transport, err := registryOpts.transport()
 	if err != nil {
 		return name.Digest{}, err
 	}
@@ -54,11 +199,36 @@ func Push(ctx context.Context, io *genericclioptions.IOStreams, localDirectory s
 	// The default keychain resolver takes the provided image reference and
 	// checks it against the available login credentials in the system. The
 	// needs to have done a `docker login` or similar to the respective
-	// registry before using the Shipwright CLI.
-	auth, err := authn.DefaultKeychain.Resolve(tag.Context())
+	// registry before using the Shipwright CLI, unless a Docker config file
+	// is informed.
+	auth, err := registryOpts.keychain().Resolve(tag.Context())
+	if err != nil {
+		return name.Digest{}, err
+	}
+	opts := []remote.Option{remote.WithContext(ctx), remote.WithTransport(transport), remote.WithAuth(auth)}
+
+	fmt.Fprintf(io.Out, "Bundling %q as %q ...\n", localDirectory, targetImage)
+	image, err := Pack(localDirectory)
 	if err != nil {
 		return name.Digest{}, err
 	}
+	hash, err := image.Digest()
+	if err != nil {
+		return name.Digest{}, err
+	}
+	digest := tag.Context().Digest(hash.String())
+	contentTag := tag.Context().Tag(ContentTag(hash))
+
+	if !force {
+		if desc, err := remote.Head(tag, opts...); err == nil && desc.Digest == hash {
+			fmt.Fprintf(io.Out, "Source bundle %q is up to date, skipping the upload\n", targetImage)
+			return digest, nil
+		}
+		if desc, err := remote.Head(contentTag, opts...); err == nil && desc.Digest == hash {
+			fmt.Fprintf(io.Out, "Source bundle found as %q, skipping the upload\n", contentTag.String())
+			return digest, remote.Tag(tag, image, opts...)
+		}
+	}
 
 	updates := make(chan v1.Update, 1)
 	done := make(chan struct{}, 1)
@@ -107,15 +277,10 @@ func Push(ctx context.Context, io *genericclioptions.IOStreams, localDirectory s
 		}
 	}()
 
-	fmt.Fprintf(io.Out, "Bundling %q as %q ...\n", localDirectory, targetImage)
-	digest, err := buildbundle.PackAndPush(
-		tag,
-		localDirectory,
-		remote.WithContext(ctx),
-		remote.WithAuth(auth),
-		remote.WithProgress(updates),
-	)
-
+	err = remote.Write(tag, image, append(opts, remote.WithProgress(updates))...)
 	done <- struct{}{}
-	return digest, err
+	if err != nil {
+		return name.Digest{}, err
+	}
+	return digest, remote.Tag(contentTag, image, opts...)
 }
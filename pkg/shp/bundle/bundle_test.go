@@ -0,0 +1,109 @@
+package bundle
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	o "github.com/onsi/gomega"
+)
+
+func TestRegistryOptionsTransport(t *testing.T) {
+	g := o.NewWithT(t)
+
+	transport, err := RegistryOptions{}.transport()
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify).To(o.BeFalse())
+	g.Expect(RegistryOptions{}.nameOptions()).To(o.BeEmpty())
+
+	insecure := RegistryOptions{InsecureSkipTLSVerify: true}
+	transport, err = insecure.transport()
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify).To(o.BeTrue())
+	g.Expect(insecure.nameOptions()).To(o.HaveLen(1))
+
+	_, err = RegistryOptions{CAFile: filepath.Join(t.TempDir(), "missing.pem")}.transport()
+	g.Expect(err).To(o.HaveOccurred())
+
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	g.Expect(os.WriteFile(invalid, []byte("not a certificate"), 0600)).To(o.Succeed())
+	_, err = RegistryOptions{CAFile: invalid}.transport()
+	g.Expect(err).To(o.HaveOccurred())
+}
+
+func TestRegistryOptionsKeychain(t *testing.T) {
+	g := o.NewWithT(t)
+
+	g.Expect(RegistryOptions{}.keychain()).To(o.Equal(authn.DefaultKeychain))
+
+	dir := t.TempDir()
+	dockerConfig := filepath.Join(dir, "config.json")
+	g.Expect(os.WriteFile(dockerConfig, []byte(`{
+  "auths": {
+    "registry.example.com": {"auth": "dXNlcjpwYXNzd29yZA=="},
+    "https://index.docker.io/v1/": {"username": "hub-user", "password": "hub-password"}
+  }
+}`), 0600)).To(o.Succeed())
+
+	authConfig := func(keychain authn.Keychain, image string) *authn.AuthConfig {
+		ref, err := name.NewTag(image)
+		g.Expect(err).ToNot(o.HaveOccurred())
+		auth, err := keychain.Resolve(ref.Context())
+		g.Expect(err).ToNot(o.HaveOccurred())
+		cfg, err := auth.Authorization()
+		g.Expect(err).ToNot(o.HaveOccurred())
+		return cfg
+	}
+
+	// both the config file path and its directory are accepted
+	for _, path := range []string{dockerConfig, dir} {
+		keychain := RegistryOptions{DockerConfig: path}.keychain()
+
+		cfg := authConfig(keychain, "registry.example.com/org/source:latest")
+		g.Expect(cfg.Username).To(o.Equal("user"))
+		g.Expect(cfg.Password).To(o.Equal("password"))
+
+		cfg = authConfig(keychain, "docker.io/org/source:latest")
+		g.Expect(cfg.Username).To(o.Equal("hub-user"))
+
+		g.Expect(authConfig(keychain, "other.example.com/org/source:latest")).To(o.Equal(&authn.AuthConfig{}))
+	}
+
+	ref, err := name.NewTag("registry.example.com/org/source:latest")
+	g.Expect(err).ToNot(o.HaveOccurred())
+	_, err = RegistryOptions{DockerConfig: filepath.Join(dir, "missing.json")}.keychain().Resolve(ref.Context())
+	g.Expect(err).To(o.HaveOccurred())
+}
+
+func TestPackDigest(t *testing.T) {
+	g := o.NewWithT(t)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.go")
+	g.Expect(os.WriteFile(file, []byte("package main\n"), 0600)).To(o.Succeed())
+
+	digest := func() v1.Hash {
+		image, err := Pack(dir)
+		g.Expect(err).ToNot(o.HaveOccurred())
+		hash, err := image.Digest()
+		g.Expect(err).ToNot(o.HaveOccurred())
+		return hash
+	}
+
+	// identical source trees map to the same image, and so the same content tag
+	first := digest()
+	g.Expect(digest()).To(o.Equal(first))
+	g.Expect(ContentTag(first)).To(o.Equal("sha256-" + first.Hex))
+	_, err := name.NewTag("registry.example.com/org/source:" + ContentTag(first))
+	g.Expect(err).ToNot(o.HaveOccurred())
+
+	stat, err := os.Stat(file)
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(os.WriteFile(file, []byte("package app\n"), 0600)).To(o.Succeed())
+	g.Expect(os.Chtimes(file, stat.ModTime(), stat.ModTime())).To(o.Succeed())
+	g.Expect(digest()).ToNot(o.Equal(first))
+}
@@ -0,0 +1,84 @@
+package bundle
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// Image describes a source bundle image found in a repository, all the tags pointing to the same
+// manifest are grouped together.
+type Image struct {
+	Digest  name.Digest // image reference by digest, i.e. "registry/namespace/source@sha256:..."
+	Tags    []string    // tags pointing to the image
+	Created time.Time   // image creation time, as recorded on the image configuration
+}
+
+// ParseRepository parses the repository reference, i.e. "registry/namespace/source", honoring the
+// registry options.
+func ParseRepository(repository string, registryOpts RegistryOptions) (name.Repository, error) {
+	return name.NewRepository(repository, registryOpts.nameOptions()...)
+}
+
+// remoteOptions returns the options to access the repository, honoring the registry options.
+func remoteOptions(ctx context.Context, repo name.Repository, registryOpts RegistryOptions) ([]remote.Option, error) {
+	transport, err := registryOpts.transport()
+	if err != nil {
+		return nil, err
+	}
+	auth, err := registryOpts.keychain().Resolve(repo)
+	if err != nil {
+		return nil, err
+	}
+	return []remote.Option{remote.WithContext(ctx), remote.WithTransport(transport), remote.WithAuth(auth)}, nil
+}
+
+// ListImages lists the images of the repository, grouping the tags by image digest, in the order
+// the registry lists the tags.
+func ListImages(ctx context.Context, repo name.Repository, registryOpts RegistryOptions) ([]Image, error) {
+	opts, err := remoteOptions(ctx, repo, registryOpts)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := remote.List(repo, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	images := []Image{}
+	byDigest := map[string]int{}
+	for _, tag := range tags {
+		desc, err := remote.Get(repo.Tag(tag), opts...)
+		if err != nil {
+			return nil, err
+		}
+		digest := repo.Digest(desc.Digest.String())
+		if i, ok := byDigest[digest.String()]; ok {
+			images[i].Tags = append(images[i].Tags, tag)
+			continue
+		}
+
+		img, err := desc.Image()
+		if err != nil {
+			return nil, err
+		}
+		config, err := img.ConfigFile()
+		if err != nil {
+			return nil, err
+		}
+		byDigest[digest.String()] = len(images)
+		images = append(images, Image{Digest: digest, Tags: []string{tag}, Created: config.Created.Time})
+	}
+	return images, nil
+}
+
+// DeleteImage deletes the image manifest from the registry, removing all the tags pointing to it.
+func DeleteImage(ctx context.Context, digest name.Digest, registryOpts RegistryOptions) error {
+	opts, err := remoteOptions(ctx, digest.Context(), registryOpts)
+	if err != nil {
+		return err
+	}
+	return remote.Delete(digest, opts...)
+}
@@ -14,7 +14,7 @@ import (
 func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Command {
 	command := &cobra.Command{
 		Use:     "build",
-		Aliases: []string{"bd"},
+		Aliases: []string{"bd", "b"},
 		Short:   "Manage Builds",
 		Annotations: map[string]string{
 			"commandType": "main",
@@ -24,10 +24,13 @@ func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Co
 	// TODO: add support for `update` and `get` commands
 	command.AddCommand(
 		runner.NewRunner(p, ioStreams, createCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, cloneCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, listCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, deleteCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, editCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, runCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, uploadCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, pruneBundlesCmd()).Cmd(),
 	)
 	return command
 }
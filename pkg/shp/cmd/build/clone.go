@@ -0,0 +1,154 @@
+package build
+
+import (
+	"fmt"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// cloneForceFlag command-line flag to re-create the destination Build when it already exists.
+const cloneForceFlag = "force"
+
+// CloneCommand contains data input from user for the clone sub-command
+type CloneCommand struct {
+	cmd *cobra.Command // cobra command instance
+
+	source      string // name of the Build to clone
+	destination string // name of the Build created
+
+	sourceURL   string // overrides the git repository URL on the clone
+	revision    string // overrides the git revision on the clone
+	outputImage string // overrides the output image on the clone
+	force       bool   // re-creates the destination Build when it already exists
+}
+
+const buildCloneLongDesc = `
+Creates a new Build out of the spec, labels and annotations of an existing one, informed by name.
+For example:
+
+	$ shp build clone my-app my-app-staging --git-revision=staging --output-image="..."
+
+The source URL, revision and output image of the new Build may be overridden, the Build cloned is
+left untouched. The command refuses to overwrite an existing destination Build, unless --force is
+informed, in which case the destination is deleted and created again.
+`
+
+// Cmd returns cobra.Command object of the clone sub-command.
+func (c *CloneCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete picks the source and destination Build names from arguments.
+func (c *CloneCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.source = args[0]
+	c.destination = args[1]
+	return nil
+}
+
+// Mutating the Build is cloned into the namespace.
+func (c *CloneCommand) Mutating() bool {
+	return true
+}
+
+// Validate the source and destination names must be distinct, and the output image valid.
+func (c *CloneCommand) Validate() error {
+	if c.source == "" || c.destination == "" {
+		return fmt.Errorf("source and destination names must be provided")
+	}
+	if c.source == c.destination {
+		return fmt.Errorf("the destination name must be different from the source %q", c.source)
+	}
+	return flags.ValidateOutputImage(c.outputImage)
+}
+
+// Run creates the destination Build out of the source one, applying the overrides.
+func (c *CloneCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	ctx := c.cmd.Context()
+	buildClient := clientset.ShipwrightV1alpha1().Builds(params.Namespace())
+
+	src, err := buildClient.Get(ctx, c.source, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	b := c.cloneBuild(src)
+	if warning := flags.OutputImageWarning(c.outputImage); warning != "" {
+		params.Logger(ioStreams.ErrOut).Warning(warning)
+	}
+
+	_, err = buildClient.Create(ctx, b, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		if !c.force {
+			return fmt.Errorf("build %q already exists, use --%s to re-create it", c.destination, cloneForceFlag)
+		}
+		if err = buildClient.Delete(ctx, c.destination, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		fmt.Fprintf(ioStreams.Out, "Deleted build %q\n", c.destination)
+		_, err = buildClient.Create(ctx, b, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(ioStreams.Out, "Cloned build %q into %q\n", c.source, c.destination)
+	return nil
+}
+
+// cloneBuild returns a copy of the Build named after the destination, keeping only the spec, labels
+// and annotations, with the overrides applied.
+func (c *CloneCommand) cloneBuild(src *buildv1alpha1.Build) *buildv1alpha1.Build {
+	src = src.DeepCopy()
+	b := &buildv1alpha1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        c.destination,
+			Labels:      src.Labels,
+			Annotations: src.Annotations,
+		},
+		Spec: src.Spec,
+	}
+	if c.sourceURL != "" {
+		b.Spec.Source.URL = &c.sourceURL
+	}
+	if c.revision != "" {
+		b.Spec.Source.Revision = &c.revision
+	}
+	if c.outputImage != "" {
+		b.Spec.Output.Image = c.outputImage
+	}
+	return b
+}
+
+// cloneCmd instantiate the "build clone" sub-command.
+func cloneCmd() runner.SubCommand {
+	cloneCommand := &CloneCommand{
+		cmd: &cobra.Command{
+			Use:   "clone <source> <destination>",
+			Short: "Create a Build out of an existing one",
+			Long:  buildCloneLongDesc,
+			Args:  cobra.ExactArgs(2),
+		},
+	}
+
+	cmdFlags := cloneCommand.cmd.Flags()
+	cmdFlags.StringVar(&cloneCommand.sourceURL, flags.SourceURLFlag, "", "override the git repository source URL on the new Build")
+	cmdFlags.Var(flags.NewRevisionValue(&cloneCommand.revision), flags.SourceRevisionFlag,
+		"override the git repository source revision on the new Build, either a branch, tag or commit SHA")
+	cmdFlags.Var(flags.NewRevisionValue(&cloneCommand.revision), flags.GitRevisionFlag,
+		fmt.Sprintf("alias for --%s", flags.SourceRevisionFlag))
+	cmdFlags.StringVar(&cloneCommand.outputImage, flags.OutputImageFlag, "", "override the output image on the new Build")
+	cmdFlags.BoolVar(&cloneCommand.force, cloneForceFlag, false, "delete and create again the destination Build when it already exists")
+
+	return cloneCommand
+}
@@ -0,0 +1,85 @@
+package build
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/pointer"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestCloneBuild(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	src := &buildv1alpha1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       metav1.NamespaceDefault,
+			Name:            "app",
+			UID:             types.UID("uid"),
+			ResourceVersion: "42",
+			Labels:          map[string]string{"team": "build"},
+		},
+		Spec: buildv1alpha1.BuildSpec{
+			Source:   buildv1alpha1.Source{URL: pointer.String("https://github.com/shipwright-io/sample-go")},
+			Strategy: buildv1alpha1.Strategy{Name: "buildpacks-v3"},
+			Output:   buildv1alpha1.Image{Image: "registry/app:latest"},
+		},
+		Status: buildv1alpha1.BuildStatus{Registered: buildv1alpha1.ConditionStatusPtr("True")},
+	}
+	shpclientset := shpfake.NewSimpleClientset(src)
+	param := params.NewParamsForTest(nil, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	run := func(args ...string) (string, error) {
+		cmd := cloneCmd().(*CloneCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().ParseFlags(args)).To(gomega.Succeed())
+		g.Expect(cmd.Complete(param, nil, []string{"app", "app-staging"})).To(gomega.Succeed())
+		if err := cmd.Validate(); err != nil {
+			return "", err
+		}
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), err
+	}
+
+	out, err := run("--git-revision=staging", "--output-image=registry/app:staging")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.Equal("Cloned build \"app\" into \"app-staging\"\n"))
+
+	clone, err := shpclientset.ShipwrightV1alpha1().Builds(metav1.NamespaceDefault).Get(context.Background(), "app-staging", metav1.GetOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(clone.UID).To(gomega.BeEmpty())
+	g.Expect(clone.ResourceVersion).ToNot(gomega.Equal("42"))
+	g.Expect(clone.Labels).To(gomega.Equal(map[string]string{"team": "build"}))
+	g.Expect(clone.Status).To(gomega.Equal(buildv1alpha1.BuildStatus{}))
+	g.Expect(clone.Spec.Source.URL).To(gomega.Equal(src.Spec.Source.URL))
+	g.Expect(clone.Spec.Source.Revision).To(gomega.Equal(pointer.String("staging")))
+	g.Expect(clone.Spec.Output.Image).To(gomega.Equal("registry/app:staging"))
+	g.Expect(clone.Spec.Strategy).To(gomega.Equal(src.Spec.Strategy))
+
+	// the source Build is left untouched
+	build, err := shpclientset.ShipwrightV1alpha1().Builds(metav1.NamespaceDefault).Get(context.Background(), "app", metav1.GetOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(build.Spec).To(gomega.Equal(src.Spec))
+
+	_, err = run()
+	g.Expect(err).To(gomega.MatchError("build \"app-staging\" already exists, use --force to re-create it"))
+
+	out, err = run("--force")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.Equal("Deleted build \"app-staging\"\nCloned build \"app\" into \"app-staging\"\n"))
+	clone, err = shpclientset.ShipwrightV1alpha1().Builds(metav1.NamespaceDefault).Get(context.Background(), "app-staging", metav1.GetOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(clone.Spec).To(gomega.Equal(src.Spec))
+
+	_, err = run("--output-image=registry/App")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -1,12 +1,17 @@
 package build
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
@@ -20,12 +25,63 @@ type CreateCommand struct {
 
 	name      string                   // build resource's name
 	buildSpec *buildv1alpha1.BuildSpec // stores command-line flags
+	metadata  *flags.Metadata          // labels and annotations informed on command-line
+
+	apply          bool // create or update the Build using server-side apply
+	forceConflicts bool // take ownership of fields managed by others when applying
+
+	createNamespace bool // create the target namespace when it does not exist
+
+	quiet bool // print only the Build name
+
+	local  bool                 // print the Build object instead of creating it, without a cluster
+	output *flags.OutputOptions // output format of the Build object printed with --local
+
+	sourceSecret sourceSecretOptions // git credentials of the source secret created with the Build
 }
 
+const (
+	// applyFlag command-line flag to create or update the Build using server-side apply.
+	applyFlag = "apply"
+	// forceConflictsFlag command-line flag to force the server-side apply conflicts.
+	forceConflictsFlag = "force-conflicts"
+	// createNamespaceFlag command-line flag to create the target namespace before the Build.
+	createNamespaceFlag = "create-namespace"
+	// localFlag command-line flag to print the Build object out of the flags, without a cluster.
+	localFlag = "local"
+	// fieldManager name of the field manager recorded on server-side apply.
+	fieldManager = "shp"
+)
+
 const buildCreateLongDesc = `
 Creates a new Build instance using the first argument as its name. For example:
 
 	$ shp build create my-app --source-url="..." --output-image="..."
+
+The --strategy-name refers to a ClusterBuildStrategy by default, use --strategy-kind=BuildStrategy
+to refer to the namespaced BuildStrategy instead, i.e. when the same name exists in both scopes.
+
+With --apply the Build is created or updated using server-side apply, so informing the same flags
+again converges instead of failing because the Build already exists. Fields managed by others,
+like a different client, are conflicts unless --force-conflicts is informed.
+
+With --create-namespace the target namespace is created first, when it does not exist yet.
+
+With --quiet only the Build name is printed, the warnings and errors are still written to stderr.
+
+With --local the Build object is printed instead of created, in the --output format (YAML by
+default), without contacting the cluster. For instance, to template the manifests offline:
+
+	$ shp build create my-app --source-url="..." --output-image="..." --local -o yaml > build.yaml
+
+To clone a private repository, the git credentials informed with --source-git-username and
+--source-git-password, or --source-git-ssh-key, are stored in a basic-auth or SSH secret created
+together with the Build, and referenced as the Build's source credentials. The secret is named after
+--source-credentials-secret, or otherwise after the Build with the "-git-credentials" suffix. An
+existing secret is only updated with --apply, when it was created for the same Build. The
+credentials are never printed, and with --local the secret is not created:
+
+	$ shp build create my-app --source-url="git@github.com:org/app.git" --source-git-ssh-key ~/.ssh/id_ed25519 --output-image="..."
 `
 
 // Cmd returns cobra.Command object of the create subcommand.
@@ -34,40 +90,140 @@ func (c *CreateCommand) Cmd() *cobra.Command {
 }
 
 // Complete fills internal subcommand structure for future work with user input
-func (c *CreateCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+func (c *CreateCommand) Complete(params *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.quiet = params.Quiet()
 	switch len(args) {
 	case 1:
 		c.name = args[0]
 	default:
 		return fmt.Errorf("one argument is expected")
 	}
+	if c.local && !c.output.Enabled() {
+		c.output.Format = "yaml"
+	}
 	return nil
 }
 
+// Mutating the Build is created in the namespace, unless only printed with --local, or the
+// namespace is created on demand with --create-namespace.
+func (c *CreateCommand) Mutating() bool {
+	return !c.local && !c.createNamespace
+}
+
 // Validate is used for user input validation of flags and other data.
 func (c *CreateCommand) Validate() error {
 	if c.name == "" {
 		return fmt.Errorf("name must be provided")
 	}
-	return nil
+	if c.forceConflicts && !c.apply {
+		return fmt.Errorf("--%s can only be used together with --%s", forceConflictsFlag, applyFlag)
+	}
+	if err := c.validateLocal(); err != nil {
+		return err
+	}
+	if err := c.sourceSecret.validate(); err != nil {
+		return err
+	}
+	if err := flags.ValidateSourceBundleImage(c.buildSpec.Source.BundleContainer.Image); err != nil {
+		return err
+	}
+	if err := flags.ValidateOutputMetadata(&c.buildSpec.Output); err != nil {
+		return err
+	}
+	return flags.ValidateOutputImage(c.buildSpec.Output.Image)
 }
 
-// Run executes the creation of a new Build instance using flags to fill up the details.
-func (c *CreateCommand) Run(params *params.Params, io *genericclioptions.IOStreams) error {
+// validateLocal checks the output format is only informed with --local, which can not be combined
+// with the flags acting on the cluster.
+func (c *CreateCommand) validateLocal() error {
+	if !c.local {
+		if c.output.Enabled() {
+			return fmt.Errorf("--%s can only be used together with --%s", flags.OutputFlag, localFlag)
+		}
+		return nil
+	}
+	switch {
+	case c.apply:
+		return fmt.Errorf("--%s can not be used together with --%s", applyFlag, localFlag)
+	case c.createNamespace:
+		return fmt.Errorf("--%s can not be used together with --%s", createNamespaceFlag, localFlag)
+	case c.quiet:
+		return fmt.Errorf("--quiet can not be used together with --%s", localFlag)
+	}
+	return c.output.Validate()
+}
+
+// newBuild returns the Build object out of the command-line flags, without contacting the cluster.
+func (c *CreateCommand) newBuild(namespace string) (*buildv1alpha1.Build, error) {
 	b := &buildv1alpha1.Build{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: c.name,
+			Name:      c.name,
+			Namespace: namespace,
 		},
 		Spec: *c.buildSpec,
 	}
 
 	flags.SanitizeBuildSpec(&b.Spec)
+	if c.sourceSecret.enabled() && b.Spec.Source.Credentials == nil {
+		b.Spec.Source.Credentials = &corev1.LocalObjectReference{Name: c.name + sourceSecretSuffix}
+	}
+	if err := c.metadata.ApplyTo(&b.ObjectMeta); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Run executes the creation of a new Build instance using flags to fill up the details.
+func (c *CreateCommand) Run(params *params.Params, io *genericclioptions.IOStreams) error {
+	b, err := c.newBuild(params.Namespace())
+	if err != nil {
+		return err
+	}
+
+	if warning := flags.OutputImageWarning(b.Spec.Output.Image); warning != "" {
+		params.Logger(io.ErrOut).Warning(warning)
+	}
+	if c.local {
+		if c.sourceSecret.enabled() {
+			params.Logger(io.ErrOut).Info(fmt.Sprintf("Secret %q is not created with --%s", b.Spec.Source.Credentials.Name, localFlag))
+		}
+		return c.output.PrintObject(io.Out, b)
+	}
+	params.WarnUnsupportedFlags(c.cmd.Context(), c.cmd.Flags(), io.ErrOut, flags.BuildFeatures)
 
 	// print warning with regards to source bundle image being used
-	if b.Spec.Source.BundleContainer != nil && b.Spec.Source.BundleContainer.Image != "" {
+	if b.Spec.Source.BundleContainer != nil && b.Spec.Source.BundleContainer.Image != "" && !c.quiet {
 		fmt.Fprintf(io.Out, "Build %q uses a source bundle image, which means source code will be transferred to a container registry. It is advised to use private images to ensure the security of the source code being uploaded.\n", c.name)
 	}
 
+	if c.createNamespace {
+		if err := c.ensureNamespace(params, io); err != nil {
+			return err
+		}
+	}
+
+	if !c.sourceSecret.enabled() {
+		return c.createBuild(params, io, b)
+	}
+	secretName := b.Spec.Source.Credentials.Name
+	created, err := c.createSourceSecret(params, io, secretName)
+	if err != nil {
+		return err
+	}
+	if err = c.createBuild(params, io, b); err != nil {
+		if created {
+			c.deleteSourceSecret(params, io, secretName)
+		}
+		return err
+	}
+	return nil
+}
+
+// createBuild creates the Build, or applies it with --apply.
+func (c *CreateCommand) createBuild(params *params.Params, io *genericclioptions.IOStreams, b *buildv1alpha1.Build) error {
+	if c.apply {
+		return c.applyBuild(params, io, b)
+	}
 	clientset, err := params.ShipwrightClientSet()
 	if err != nil {
 		return err
@@ -75,10 +231,146 @@ func (c *CreateCommand) Run(params *params.Params, io *genericclioptions.IOStrea
 	if _, err := clientset.ShipwrightV1alpha1().Builds(params.Namespace()).Create(c.cmd.Context(), b, metav1.CreateOptions{}); err != nil {
 		return err
 	}
-	fmt.Fprintf(io.Out, "Created build %q\n", c.name)
+	c.printResult(io, "Created build %q\n")
 	return nil
 }
 
+// createSourceSecret creates the source secret out of the git credentials, returning whether it was
+// created. An existing secret is never overwritten, except with --apply when it belongs to the same
+// Build, in which case it's updated.
+func (c *CreateCommand) createSourceSecret(params *params.Params, io *genericclioptions.IOStreams, name string) (bool, error) {
+	secret, err := c.sourceSecret.newSecret(params.Namespace(), name, c.name)
+	if err != nil {
+		return false, err
+	}
+	clientset, err := params.ClientSet()
+	if err != nil {
+		return false, err
+	}
+	secrets := clientset.CoreV1().Secrets(params.Namespace())
+	_, err = secrets.Create(c.cmd.Context(), secret, metav1.CreateOptions{})
+	switch {
+	case k8serrors.IsAlreadyExists(err) && c.apply:
+		return false, c.updateSourceSecret(params, io, secret)
+	case k8serrors.IsAlreadyExists(err):
+		return false, fmt.Errorf("secret %q already exists, reference it with --%s instead of informing the git credentials",
+			name, flags.SourceCredentialsSecretFlag)
+	case err != nil:
+		return false, err
+	}
+	if !c.quiet {
+		fmt.Fprintf(io.Out, "Created secret %q\n", name)
+	}
+	return true, nil
+}
+
+// updateSourceSecret updates the existing source secret with the git credentials informed, as long
+// as it's labeled with the same Build, a secret belonging to something else is left untouched.
+func (c *CreateCommand) updateSourceSecret(params *params.Params, io *genericclioptions.IOStreams, secret *corev1.Secret) error {
+	clientset, err := params.ClientSet()
+	if err != nil {
+		return err
+	}
+	secrets := clientset.CoreV1().Secrets(params.Namespace())
+	existing, err := secrets.Get(c.cmd.Context(), secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if existing.Labels[buildv1alpha1.LabelBuild] != c.name {
+		return fmt.Errorf("secret %q already exists and does not belong to build %q, reference it with --%s instead of informing the git credentials",
+			secret.Name, c.name, flags.SourceCredentialsSecretFlag)
+	}
+	existing.Type = secret.Type
+	existing.Data = secret.Data
+	existing.StringData = nil
+	for key, value := range secret.Annotations {
+		if existing.Annotations == nil {
+			existing.Annotations = map[string]string{}
+		}
+		existing.Annotations[key] = value
+	}
+	if _, err = secrets.Update(c.cmd.Context(), existing, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	if !c.quiet {
+		fmt.Fprintf(io.Out, "Updated secret %q\n", secret.Name)
+	}
+	return nil
+}
+
+// deleteSourceSecret removes the source secret once the Build could not be created, so running the
+// command again doesn't find it. Failing to do so is only a warning.
+func (c *CreateCommand) deleteSourceSecret(params *params.Params, io *genericclioptions.IOStreams, name string) {
+	clientset, err := params.ClientSet()
+	if err == nil {
+		// the command context may be done already
+		err = clientset.CoreV1().Secrets(params.Namespace()).Delete(context.Background(), name, metav1.DeleteOptions{})
+	}
+	if err != nil {
+		params.Logger(io.ErrOut).Warning(fmt.Sprintf("unable to delete secret %q created for the Build: %s", name, err))
+	}
+}
+
+// ensureNamespace creates the target namespace, an existing namespace is not an error.
+func (c *CreateCommand) ensureNamespace(params *params.Params, io *genericclioptions.IOStreams) error {
+	clientset, err := params.ClientSet()
+	if err != nil {
+		return err
+	}
+
+	namespace := params.Namespace()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	_, err = clientset.CoreV1().Namespaces().Create(c.cmd.Context(), ns, metav1.CreateOptions{})
+	switch {
+	case err == nil && !c.quiet:
+		fmt.Fprintf(io.Out, "Created namespace %q\n", namespace)
+	case err == nil:
+	case k8serrors.IsAlreadyExists(err):
+	case k8serrors.IsForbidden(err):
+		return fmt.Errorf("not allowed to create namespace %q, create it beforehand or omit --%s: %w",
+			namespace, createNamespaceFlag, err)
+	default:
+		return err
+	}
+	return nil
+}
+
+// applyBuild creates or updates the Build using server-side apply, the conflicts with other field
+// managers are only overwritten when forced.
+func (c *CreateCommand) applyBuild(params *params.Params, io *genericclioptions.IOStreams, b *buildv1alpha1.Build) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+
+	b.SetGroupVersionKind(buildv1alpha1.SchemeGroupVersion.WithKind("Build"))
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	opts := metav1.PatchOptions{FieldManager: fieldManager, Force: &c.forceConflicts}
+	_, err = clientset.ShipwrightV1alpha1().Builds(params.Namespace()).
+		Patch(c.cmd.Context(), c.name, types.ApplyPatchType, data, opts)
+	if err != nil {
+		if k8serrors.IsConflict(err) {
+			return fmt.Errorf("%w, use --%s to take ownership of the conflicting fields", err, forceConflictsFlag)
+		}
+		return err
+	}
+	c.printResult(io, "Applied build %q\n")
+	return nil
+}
+
+// printResult prints the message formatted with the Build name, or only the name when quiet.
+func (c *CreateCommand) printResult(io *genericclioptions.IOStreams, format string) {
+	if c.quiet {
+		fmt.Fprintln(io.Out, c.name)
+		return
+	}
+	fmt.Fprintf(io.Out, format, c.name)
+}
+
 // createCmd instantiate the "build create" subcommand.
 func createCmd() runner.SubCommand {
 	cmd := &cobra.Command{
@@ -94,8 +386,20 @@ func createCmd() runner.SubCommand {
 		panic(err)
 	}
 
-	return &CreateCommand{
+	createCommand := &CreateCommand{
 		cmd:       cmd,
 		buildSpec: buildSpecFlags,
+		metadata:  flags.MetadataFromFlags(cmd.Flags()),
 	}
+	cmd.Flags().BoolVar(&createCommand.apply, applyFlag, false, "Create or update the Build using server-side apply")
+	cmd.Flags().BoolVar(&createCommand.forceConflicts, forceConflictsFlag, false, "Together with --apply, overwrite the fields managed by others")
+	cmd.Flags().BoolVar(&createCommand.createNamespace, createNamespaceFlag, false, "Create the target namespace when it does not exist")
+	cmd.Flags().BoolVar(&createCommand.local, localFlag, false,
+		"Print the Build object instead of creating it, without contacting the cluster")
+	createCommand.output = flags.OutputFlags(cmd.Flags())
+	// the output only applies to --local, it's not taken from SHP_OUTPUT
+	delete(cmd.Flags().Lookup(flags.OutputFlag).Annotations, flags.EnvDefaultAnnotation)
+	createCommand.sourceSecret.addFlags(cmd.Flags())
+
+	return createCommand
 }
@@ -0,0 +1,280 @@
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	fakekubetesting "k8s.io/client-go/testing"
+)
+
+func TestCreateBuildApply(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	conflict := false
+	applied := []*buildv1alpha1.Build{}
+	shpclientset := shpfake.NewSimpleClientset()
+	shpclientset.PrependReactor("patch", "builds", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		patchAction := action.(fakekubetesting.PatchAction)
+		g.Expect(patchAction.GetPatchType()).To(gomega.Equal(types.ApplyPatchType))
+		if conflict {
+			return true, nil, k8serrors.NewConflict(schema.GroupResource{Resource: "builds"}, patchAction.GetName(), nil)
+		}
+		b := &buildv1alpha1.Build{}
+		g.Expect(json.Unmarshal(patchAction.GetPatch(), b)).To(gomega.Succeed())
+		applied = append(applied, b)
+		return true, b, nil
+	})
+	param := params.NewParamsForTest(nil, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := createCmd().(*CreateCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputImageFlag, "registry/app:latest")).To(gomega.Succeed())
+	g.Expect(cmd.Cmd().Flags().Set(forceConflictsFlag, "true")).To(gomega.Succeed())
+	g.Expect(cmd.Complete(param, nil, []string{"app"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError("--force-conflicts can only be used together with --apply"))
+
+	g.Expect(cmd.Cmd().Flags().Set(applyFlag, "true")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+	// applying twice converges on the same Build
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.Equal("Applied build \"app\"\nApplied build \"app\"\n"))
+	g.Expect(applied).To(gomega.HaveLen(2))
+	g.Expect(applied[0].Kind).To(gomega.Equal("Build"))
+	g.Expect(applied[0].APIVersion).To(gomega.Equal(buildv1alpha1.SchemeGroupVersion.String()))
+	g.Expect(applied[0].Spec.Output.Image).To(gomega.Equal("registry/app:latest"))
+
+	conflict = true
+	err := cmd.Run(param, &ioStreams)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring("use --force-conflicts"))
+}
+
+func TestCreateBuildOutputImage(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	param := params.NewParamsForTest(nil, shpfake.NewSimpleClientset(), nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := createCmd().(*CreateCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Complete(param, nil, []string{"app"})).To(gomega.Succeed())
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputImageFlag, "registry/App:latest")).To(gomega.Succeed())
+	err := cmd.Validate()
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring(`invalid --output-image "registry/App:latest"`))
+
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputImageFlag, "registry/app")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+	g.Expect(cmd.Cmd().Flags().Set(flags.SourceBundleImageFlag, "registry/App-source")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError(gomega.ContainSubstring(`invalid --source-bundle-image "registry/App-source"`)))
+	g.Expect(cmd.Cmd().Flags().Set(flags.SourceBundleImageFlag, "")).To(gomega.Succeed())
+
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.Equal("Created build \"app\"\n"))
+	g.Expect(errOut.String()).To(gomega.ContainSubstring(`--output-image "registry/app" has no tag or digest`))
+}
+
+func TestCreateBuildCreateNamespace(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	run := func(clientset *fake.Clientset, shpclientset *shpfake.Clientset) (string, error) {
+		param := params.NewParamsForTest(clientset, shpclientset, nil, "team-a", nil, nil)
+		cmd := createCmd().(*CreateCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().Flags().Set(flags.OutputImageFlag, "registry/app:v1")).To(gomega.Succeed())
+		g.Expect(cmd.Cmd().Flags().Set(createNamespaceFlag, "true")).To(gomega.Succeed())
+		g.Expect(cmd.Complete(param, nil, []string{"app"})).To(gomega.Succeed())
+		g.Expect(cmd.Validate()).To(gomega.Succeed())
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), err
+	}
+
+	clientset := fake.NewSimpleClientset()
+	out, err := run(clientset, shpfake.NewSimpleClientset())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.Equal("Created namespace \"team-a\"\nCreated build \"app\"\n"))
+	_, err = clientset.CoreV1().Namespaces().Get(context.Background(), "team-a", metav1.GetOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	// the namespace already exists
+	out, err = run(clientset, shpfake.NewSimpleClientset())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.Equal("Created build \"app\"\n"))
+
+	clientset = fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "namespaces", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+		return true, nil, k8serrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "team-a", nil)
+	})
+	shpclientset := shpfake.NewSimpleClientset()
+	_, err = run(clientset, shpclientset)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.HavePrefix(`not allowed to create namespace "team-a", create it beforehand or omit --create-namespace`))
+	builds, err := shpclientset.ShipwrightV1alpha1().Builds("team-a").List(context.Background(), metav1.ListOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(builds.Items).To(gomega.BeEmpty())
+}
+
+func TestCreateBuildQuiet(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// --quiet is a global flag, inherited from the root command
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpfake.NewSimpleClientset(), genericclioptions.NewConfigFlags(true), "team-a", nil, nil)
+	cmd := createCmd().(*CreateCommand)
+	cmd.Cmd().SetContext(context.Background())
+	param.AddFlags(cmd.Cmd().PersistentFlags())
+	g.Expect(cmd.Cmd().ParseFlags([]string{
+		"--quiet", "--create-namespace", "--output-image=registry/app", "--source-bundle-image=registry/app-source:latest",
+	})).To(gomega.Succeed())
+	g.Expect(cmd.Complete(param, nil, []string{"app"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.Equal("app\n"))
+	// the warnings are still printed
+	g.Expect(errOut.String()).To(gomega.ContainSubstring(`--output-image "registry/app" has no tag or digest`))
+}
+
+func TestCreateBuildLocal(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// the clients are nil, any attempt to contact the cluster fails the test
+	param := params.NewParamsForTest(nil, nil, nil, "team-a", nil, nil)
+	cmd := createCmd().(*CreateCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{
+		"--local", "--output-image=registry/app:latest", "--source-url=https://github.com/org/app", "--label=team=a",
+	})).To(gomega.Succeed())
+	g.Expect(cmd.Complete(param, nil, []string{"app"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.ContainSubstring("kind: Build\n"))
+	g.Expect(out.String()).To(gomega.ContainSubstring("namespace: team-a"))
+	g.Expect(out.String()).To(gomega.ContainSubstring("team: a"))
+	g.Expect(out.String()).To(gomega.ContainSubstring("image: registry/app:latest"))
+
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputFlag, "jsonpath={.spec.source.url}")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	out.Reset()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.Equal("https://github.com/org/app"))
+
+	g.Expect(cmd.Cmd().Flags().Set(applyFlag, "true")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError("--apply can not be used together with --local"))
+
+	cmd = createCmd().(*CreateCommand)
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--output-image=registry/app:latest", "-o", "yaml"})).To(gomega.Succeed())
+	g.Expect(cmd.Complete(param, nil, []string{"app"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError("--output can only be used together with --local"))
+}
+
+func TestCreateBuildSourceSecret(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	g.Expect(os.WriteFile(keyFile, []byte("ssh private key"), 0o600)).To(gomega.Succeed())
+
+	run := func(clientset *fake.Clientset, shpclientset *shpfake.Clientset, args ...string) (string, error) {
+		param := params.NewParamsForTest(clientset, shpclientset, nil, "team-a", nil, nil)
+		cmd := createCmd().(*CreateCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().ParseFlags(append([]string{"--output-image=registry/app:v1"}, args...))).To(gomega.Succeed())
+		g.Expect(cmd.Complete(param, nil, []string{"app"})).To(gomega.Succeed())
+		if err := cmd.Validate(); err != nil {
+			return "", err
+		}
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), err
+	}
+
+	clientset, shpclientset := fake.NewSimpleClientset(), shpfake.NewSimpleClientset()
+	out, err := run(clientset, shpclientset, "--source-git-username=user", "--source-git-password=s3cr3t")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.Equal("Created secret \"app-git-credentials\"\nCreated build \"app\"\n"))
+	secret, err := clientset.CoreV1().Secrets("team-a").Get(context.Background(), "app-git-credentials", metav1.GetOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(secret.Type).To(gomega.Equal(corev1.SecretTypeBasicAuth))
+	g.Expect(secret.Data).To(gomega.Equal(map[string][]byte{"username": []byte("user"), "password": []byte("s3cr3t")}))
+	g.Expect(secret.Annotations).To(gomega.HaveKeyWithValue(buildv1alpha1.AnnotationBuildRefSecret, "true"))
+	g.Expect(secret.Labels).To(gomega.HaveKeyWithValue(buildv1alpha1.LabelBuild, "app"))
+	b, err := shpclientset.ShipwrightV1alpha1().Builds("team-a").Get(context.Background(), "app", metav1.GetOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(b.Spec.Source.Credentials.Name).To(gomega.Equal("app-git-credentials"))
+
+	// the existing secret is never overwritten
+	_, err = run(clientset, shpfake.NewSimpleClientset(), "--source-git-username=user", "--source-git-password=other")
+	g.Expect(err).To(gomega.MatchError(
+		`secret "app-git-credentials" already exists, reference it with --source-credentials-secret instead of informing the git credentials`))
+
+	// with --apply the secret created for the same Build is updated
+	shpclientset = shpfake.NewSimpleClientset()
+	shpclientset.PrependReactor("patch", "builds", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		b := &buildv1alpha1.Build{}
+		g.Expect(json.Unmarshal(action.(fakekubetesting.PatchAction).GetPatch(), b)).To(gomega.Succeed())
+		return true, b, nil
+	})
+	out, err = run(clientset, shpclientset, "--apply", "--source-git-username=user", "--source-git-password=other")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.Equal("Updated secret \"app-git-credentials\"\nApplied build \"app\"\n"))
+	secret, err = clientset.CoreV1().Secrets("team-a").Get(context.Background(), "app-git-credentials", metav1.GetOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(secret.Data).To(gomega.Equal(map[string][]byte{"username": []byte("user"), "password": []byte("other")}))
+
+	// a secret belonging to something else is not touched, even with --apply
+	clientset = fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "shared", Labels: map[string]string{buildv1alpha1.LabelBuild: "other"}},
+		Data:       map[string][]byte{"username": []byte("someone")},
+	})
+	_, err = run(clientset, shpclientset, "--apply", "--source-git-username=user", "--source-git-password=other", "--source-credentials-secret=shared")
+	g.Expect(err).To(gomega.MatchError(`secret "shared" already exists and does not belong to build "app", ` +
+		`reference it with --source-credentials-secret instead of informing the git credentials`))
+	secret, err = clientset.CoreV1().Secrets("team-a").Get(context.Background(), "shared", metav1.GetOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(secret.Data).To(gomega.Equal(map[string][]byte{"username": []byte("someone")}))
+
+	// the SSH secret takes the name informed, and is removed when the Build can't be created
+	clientset = fake.NewSimpleClientset()
+	shpclientset = shpfake.NewSimpleClientset(&buildv1alpha1.Build{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app"}})
+	_, err = run(clientset, shpclientset, "--source-git-ssh-key="+keyFile, "--source-credentials-secret=app-ssh")
+	g.Expect(k8serrors.IsAlreadyExists(err)).To(gomega.BeTrue())
+	g.Expect(clientset.Actions()).To(gomega.HaveLen(2))
+	g.Expect(clientset.Actions()[0].(fakekubetesting.CreateAction).GetObject().(*corev1.Secret).Type).To(gomega.Equal(corev1.SecretTypeSSHAuth))
+	g.Expect(clientset.Actions()[1].GetVerb()).To(gomega.Equal("delete"))
+
+	// with --local the secret is not created, and its values are not printed
+	out, err = run(nil, nil, "--local", "--source-git-username=user", "--source-git-password=s3cr3t")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.ContainSubstring("name: app-git-credentials"))
+	g.Expect(out).ToNot(gomega.ContainSubstring("s3cr3t"))
+
+	_, err = run(nil, nil, "--source-git-username=user")
+	g.Expect(err).To(gomega.MatchError("--source-git-password must be informed together with --source-git-username"))
+	_, err = run(nil, nil, "--source-git-password=s3cr3t", "--source-git-ssh-key="+keyFile)
+	g.Expect(err).To(gomega.MatchError(
+		"--source-git-ssh-key can not be used together with --source-git-username and --source-git-password"))
+}
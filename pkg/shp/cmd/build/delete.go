@@ -7,30 +7,42 @@ import (
 	"github.com/spf13/cobra"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/util"
 )
 
 // DeleteCommand contains data provided by user to the delete subcommand
 type DeleteCommand struct {
-	name string
+	names []string
 
 	cmd        *cobra.Command
 	deleteRuns bool
+	yes        bool // skips the confirmation prompt
 }
 
+const buildDeleteLongDesc = `
+Deletes the Builds informed by name, and their BuildRuns with --delete-runs. When more than one
+Build is informed, the deletion is confirmed interactively, unless --yes is informed or stdin is not
+a terminal.
+`
+
 func deleteCmd() runner.SubCommand {
 	deleteCommand := &DeleteCommand{
 		cmd: &cobra.Command{
-			Use:   "delete <name> [flags]",
-			Short: "Delete Build",
-			Args:  cobra.ExactArgs(1),
+			Use:   "delete <name> [<name>...] [flags]",
+			Short: "Delete Builds",
+			Long:  buildDeleteLongDesc,
+			Args:  cobra.MinimumNArgs(1),
 		},
 	}
 
 	deleteCommand.cmd.Flags().BoolVarP(&deleteCommand.deleteRuns, "delete-runs", "r", false, "Also delete all of the buildruns")
+	flags.YesFlags(deleteCommand.cmd.Flags(), &deleteCommand.yes)
 
 	return deleteCommand
 }
@@ -42,42 +54,76 @@ func (c *DeleteCommand) Cmd() *cobra.Command {
 
 // Complete fills DeleteSubCommand structure with data obtained from cobra command
 func (c *DeleteCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
-	c.name = args[0]
+	c.names = args
 
 	return nil
 }
 
+// Mutating the Builds are deleted from the namespace.
+func (c *DeleteCommand) Mutating() bool {
+	return true
+}
+
 // Validate is used for validation of user input data
 func (c *DeleteCommand) Validate() error {
 	return nil
 }
 
-// Run contains main logic of delete subcommand
+// Run contains main logic of delete subcommand, every informed Build is deleted even when
+// deleting others fail, the errors are returned together at the end.
 func (c *DeleteCommand) Run(params *params.Params, io *genericclioptions.IOStreams) error {
+	// deleting a single Build keeps acting immediately, only bulk deletes are confirmed
+	if len(c.names) > 1 && !c.yes && params.Interactive(io.In) {
+		question := fmt.Sprintf("About to delete %d Builds in namespace %q, continue?", len(c.names), params.Namespace())
+		if c.deleteRuns {
+			question = fmt.Sprintf("About to delete %d Builds and their BuildRuns in namespace %q, continue?", len(c.names), params.Namespace())
+		}
+		confirmed, err := util.Confirm(io.In, io.ErrOut, question)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(io.Out, "Deletion canceled, no Builds deleted")
+			return nil
+		}
+	}
+
+	errs := []error{}
+	for _, name := range c.names {
+		if err := c.deleteBuild(params, io, name); err != nil {
+			params.Logger(io.ErrOut).Warning(fmt.Sprintf("Error deleting Build %q: %v", name, err))
+			errs = append(errs, fmt.Errorf("failed to delete Build %q: %w", name, err))
+			continue
+		}
+		fmt.Fprintf(io.Out, "Build deleted %q\n", name)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// deleteBuild deletes the Build, and its BuildRuns when requested.
+func (c *DeleteCommand) deleteBuild(params *params.Params, io *genericclioptions.IOStreams, name string) error {
 	clientset, err := params.ShipwrightClientSet()
 	if err != nil {
 		return err
 	}
-	if err := clientset.ShipwrightV1alpha1().Builds(params.Namespace()).Delete(c.Cmd().Context(), c.name, v1.DeleteOptions{}); err != nil {
+	if err := clientset.ShipwrightV1alpha1().Builds(params.Namespace()).Delete(c.Cmd().Context(), name, v1.DeleteOptions{}); err != nil {
 		return err
 	}
 
 	if c.deleteRuns {
 		var brList *buildv1alpha1.BuildRunList
 		if brList, err = clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()).List(c.cmd.Context(), v1.ListOptions{
-			LabelSelector: fmt.Sprintf("%v/name=%v", buildv1alpha1.BuildDomain, c.name),
+			LabelSelector: fmt.Sprintf("%v/name=%v", buildv1alpha1.BuildDomain, name),
 		}); err != nil {
 			return err
 		}
 
 		for _, buildrun := range brList.Items {
 			if err := clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()).Delete(c.cmd.Context(), buildrun.Name, v1.DeleteOptions{}); err != nil {
-				fmt.Fprintf(io.ErrOut, "Error deleting BuildRun %q: %v\n", buildrun.Name, err)
+				params.Logger(io.ErrOut).Warning(fmt.Sprintf("Error deleting BuildRun %q: %v", buildrun.Name, err))
 			}
 		}
 	}
 
-	fmt.Fprintf(io.Out, "Build deleted %q\n", c.name)
-
 	return nil
 }
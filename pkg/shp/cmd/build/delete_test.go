@@ -0,0 +1,43 @@
+package build
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestDeleteBuilds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	build := func(name string) *buildv1alpha1.Build {
+		return &buildv1alpha1.Build{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name}}
+	}
+	shpclientset := shpfake.NewSimpleClientset(build("a"), build("c"))
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := deleteCmd().(*DeleteCommand)
+	cmd.Cmd().SetContext(context.Background())
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"a", "b", "c"})).To(gomega.Succeed())
+
+	// the missing Build does not prevent deleting the others
+	err := cmd.Run(param, &ioStreams)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring(`"b"`))
+	g.Expect(out.String()).To(gomega.Equal("Build deleted \"a\"\nBuild deleted \"c\"\n"))
+	g.Expect(errOut.String()).To(gomega.ContainSubstring(`Error deleting Build "b"`))
+
+	builds, err := shpclientset.ShipwrightV1alpha1().Builds(metav1.NamespaceDefault).List(context.Background(), metav1.ListOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(builds.Items).To(gomega.BeEmpty())
+}
@@ -0,0 +1,247 @@
+package build
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// EditCommand contains data input from user for the edit sub-command
+type EditCommand struct {
+	cmd *cobra.Command // cobra command instance
+
+	name string // build resource's name
+}
+
+const buildEditLongDesc = `
+Edits the Build informed by name using the editor defined by KUBE_EDITOR or EDITOR, in this order,
+falling back to vi. The Build is presented as YAML, without status and managedFields, and the
+changes are applied when the editor is closed. For example:
+
+	$ shp build edit my-app
+
+Closing the editor without changes, or with an empty file, cancels the edit. When the changes are
+rejected, i.e. by the API server validation, the editor is opened again with the error on top.
+`
+
+// editHeader comment written on top of the Build presented in the editor.
+const editHeader = `# Please edit the Build below. Lines beginning with a '#' are ignored, and an
+# empty file cancels the edit.
+#
+`
+
+// Cmd returns cobra.Command object of the edit sub-command.
+func (c *EditCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete picks the build resource name from arguments.
+func (c *EditCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Mutating the Build is updated in the namespace.
+func (c *EditCommand) Mutating() bool {
+	return true
+}
+
+// Validate the user must inform the build resource name.
+func (c *EditCommand) Validate() error {
+	if c.name == "" {
+		return fmt.Errorf("name must be provided")
+	}
+	return nil
+}
+
+// Run opens the Build in the editor and applies the changes as a merge patch, opening the editor
+// again while the changes are rejected, until the user gives up by not changing the file.
+func (c *EditCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	buildClient := clientset.ShipwrightV1alpha1().Builds(params.Namespace())
+	b, err := buildClient.Get(c.cmd.Context(), c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	original, err := editableBuild(b)
+	if err != nil {
+		return err
+	}
+
+	edited := original
+	var lastErr error
+	for {
+		content, err := c.launchEditor(ioStreams, append(editErrorHeader(lastErr), edited...))
+		if err != nil {
+			return err
+		}
+		content = stripComments(content)
+		if len(bytes.TrimSpace(content)) == 0 {
+			fmt.Fprintf(ioStreams.Out, "Edit canceled, Build %q is unchanged\n", c.name)
+			return nil
+		}
+		// giving up when the editor is closed without addressing the error
+		if lastErr != nil && bytes.Equal(content, edited) {
+			return lastErr
+		}
+		edited = content
+
+		patch, err := c.mergePatch(original, edited)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if string(patch) == "{}" {
+			fmt.Fprintf(ioStreams.Out, "Edit canceled, no changes made to Build %q\n", c.name)
+			return nil
+		}
+		if _, err = buildClient.Patch(c.cmd.Context(), c.name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			lastErr = err
+			continue
+		}
+		fmt.Fprintf(ioStreams.Out, "Edited build %q\n", c.name)
+		return nil
+	}
+}
+
+// launchEditor writes the content on a temporary file, waits for the editor to close, and returns
+// the resulting file content.
+func (c *EditCommand) launchEditor(ioStreams *genericclioptions.IOStreams, content []byte) ([]byte, error) {
+	f, err := os.CreateTemp("", fmt.Sprintf("shp-edit-%s-*.yaml", c.name))
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	args := append(editorCommand(), f.Name())
+	// #nosec G204 the editor is informed by the user on purpose
+	cmd := exec.CommandContext(c.cmd.Context(), args[0], args[1:]...)
+	cmd.Stdin = ioStreams.In
+	cmd.Stdout = ioStreams.Out
+	cmd.Stderr = ioStreams.ErrOut
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %q has failed: %w", args[0], err)
+	}
+	return os.ReadFile(f.Name())
+}
+
+// mergePatch returns the JSON merge patch between the original and the edited Build YAML, the
+// Build can't be renamed.
+func (c *EditCommand) mergePatch(original, edited []byte) ([]byte, error) {
+	originalJSON, err := yaml.YAMLToJSON(original)
+	if err != nil {
+		return nil, err
+	}
+	editedJSON, err := yaml.YAMLToJSON(edited)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	b := &buildv1alpha1.Build{}
+	if err = json.Unmarshal(editedJSON, b); err != nil {
+		return nil, fmt.Errorf("invalid Build: %w", err)
+	}
+	if b.Name != c.name {
+		return nil, fmt.Errorf("the Build name can't be changed from %q to %q", c.name, b.Name)
+	}
+	return jsonpatch.CreateMergePatch(originalJSON, editedJSON)
+}
+
+// editableBuild renders the Build as YAML, without the status and managedFields, which are not
+// meant to be edited.
+func editableBuild(b *buildv1alpha1.Build) ([]byte, error) {
+	b = b.DeepCopy()
+	b.SetGroupVersionKind(buildv1alpha1.SchemeGroupVersion.WithKind("Build"))
+	b.ManagedFields = nil
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	if err = json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	delete(obj, "status")
+	return yaml.Marshal(obj)
+}
+
+// editorCommand returns the editor command-line, KUBE_EDITOR takes precedence over EDITOR, using vi
+// when neither is set.
+func editorCommand() []string {
+	for _, env := range []string{"KUBE_EDITOR", "EDITOR"} {
+		if editor := strings.Fields(os.Getenv(env)); len(editor) > 0 {
+			return editor
+		}
+	}
+	return []string{"vi"}
+}
+
+// editErrorHeader returns the comment header presented in the editor, including the error which
+// rejected the previous changes, when any.
+func editErrorHeader(err error) []byte {
+	header := editHeader
+	if err == nil {
+		return []byte(header)
+	}
+	header += "# The changes could not be applied:\n"
+	for _, line := range strings.Split(err.Error(), "\n") {
+		header += fmt.Sprintf("# %s\n", line)
+	}
+	return []byte(header + "#\n")
+}
+
+// stripComments removes the lines beginning with a '#'.
+func stripComments(content []byte) []byte {
+	var b bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.Bytes()
+}
+
+// editCmd instantiate the "build edit" sub-command.
+func editCmd() runner.SubCommand {
+	return &EditCommand{
+		cmd: &cobra.Command{
+			Use:   "edit <name>",
+			Short: "Edit a Build using the default editor",
+			Long:  buildEditLongDesc,
+			Args:  cobra.ExactArgs(1),
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package build
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakekubetesting "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
+)
+
+func TestEditBuild(t *testing.T) {
+	dir := t.TempDir()
+	seen := filepath.Join(dir, "seen.yaml")
+
+	// editor script recording the content presented, and applying the informed sed expression
+	editor := func(t *testing.T, expression string) {
+		script := filepath.Join(dir, "editor.sh")
+		content := "#!/bin/sh\ncat \"$1\" >> " + seen + "\nsed -i '" + expression + "' \"$1\"\n"
+		if err := os.WriteFile(script, []byte(content), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Remove(seen); err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+		t.Setenv("EDITOR", "/bin/false")
+		t.Setenv("KUBE_EDITOR", script)
+	}
+
+	newBuild := func() *buildv1alpha1.Build {
+		return &buildv1alpha1.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:     metav1.NamespaceDefault,
+				Name:          "app",
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "shp"}},
+			},
+			Spec: buildv1alpha1.BuildSpec{
+				Source: buildv1alpha1.Source{URL: pointer.String("https://github.com/shipwright-io/sample-go")},
+				Output: buildv1alpha1.Image{Image: "registry/app:v1"},
+			},
+			Status: buildv1alpha1.BuildStatus{Reason: (*buildv1alpha1.BuildReason)(pointer.String("Succeeded"))},
+		}
+	}
+
+	run := func(shpclientset *shpfake.Clientset) (string, error) {
+		cmd := editCmd().(*EditCommand)
+		cmd.Cmd().SetContext(context.Background())
+		param := params.NewParamsForTest(nil, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+		if err := cmd.Complete(param, nil, []string{"app"}); err != nil {
+			return "", err
+		}
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), err
+	}
+
+	t.Run("changes are applied", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		editor(t, "s|registry/app:v1|registry/app:v2|")
+
+		shpclientset := shpfake.NewSimpleClientset(newBuild())
+		out, err := run(shpclientset)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(out).To(gomega.Equal("Edited build \"app\"\n"))
+
+		b, err := shpclientset.ShipwrightV1alpha1().Builds(metav1.NamespaceDefault).Get(context.Background(), "app", metav1.GetOptions{})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(b.Spec.Output.Image).To(gomega.Equal("registry/app:v2"))
+
+		presented, err := os.ReadFile(seen)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(string(presented)).To(gomega.ContainSubstring("# Please edit the Build below."))
+		g.Expect(string(presented)).To(gomega.ContainSubstring("kind: Build\n"))
+		g.Expect(string(presented)).ToNot(gomega.ContainSubstring("managedFields"))
+		g.Expect(string(presented)).ToNot(gomega.ContainSubstring("status"))
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		editor(t, "s|not-found|not-found|")
+
+		out, err := run(shpfake.NewSimpleClientset(newBuild()))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(out).To(gomega.Equal("Edit canceled, no changes made to Build \"app\"\n"))
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		editor(t, "d")
+
+		out, err := run(shpfake.NewSimpleClientset(newBuild()))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(out).To(gomega.Equal("Edit canceled, Build \"app\" is unchanged\n"))
+	})
+
+	t.Run("rejected changes reopen the editor", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		editor(t, "s|registry/app:v1|registry/app:v2|")
+
+		shpclientset := shpfake.NewSimpleClientset(newBuild())
+		shpclientset.PrependReactor("patch", "builds", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+			return true, nil, k8serrors.NewInvalid(schema.GroupKind{Group: "shipwright.io", Kind: "Build"}, "app", nil)
+		})
+		_, err := run(shpclientset)
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(k8serrors.IsInvalid(err)).To(gomega.BeTrue())
+
+		presented, err := os.ReadFile(seen)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(string(presented)).To(gomega.ContainSubstring("# The changes could not be applied:\n# Build.shipwright.io \"app\" is invalid"))
+	})
+
+	t.Run("renaming is rejected", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		editor(t, "s|name: app|name: other|")
+
+		_, err := run(shpfake.NewSimpleClientset(newBuild()))
+		g.Expect(err).To(gomega.MatchError("the Build name can't be changed from \"app\" to \"other\""))
+	})
+}
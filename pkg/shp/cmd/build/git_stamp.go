@@ -0,0 +1,76 @@
+package build
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const (
+	// stampGitFlag command-line flag, annotates the BuildRun with the local git checkout details.
+	stampGitFlag = "stamp-git"
+
+	// gitSHAAnnotation annotation carrying the commit SHA of the local checkout the BuildRun was
+	// created from.
+	gitSHAAnnotation = "shp.shipwright.io/git-sha"
+	// gitBranchAnnotation annotation carrying the branch of the local checkout, not set on a
+	// detached HEAD.
+	gitBranchAnnotation = "shp.shipwright.io/git-branch"
+	// gitDirtyAnnotation annotation telling whether the local checkout has uncommitted changes.
+	gitDirtyAnnotation = "shp.shipwright.io/git-dirty"
+)
+
+// errNotGitRepository the directory is not part of a git checkout with commits.
+var errNotGitRepository = errors.New("not a git repository")
+
+// gitStamp returns the annotations describing the git checkout the directory belongs to: the
+// commit SHA, the branch and whether there are uncommitted changes. The git command-line is used,
+// when it's not installed or the directory is not a git checkout errNotGitRepository is returned.
+func gitStamp(ctx context.Context, dir string) (map[string]string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("%w: git command not found", errNotGitRepository)
+	}
+	sha, err := gitOutput(ctx, dir, "rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errNotGitRepository, err)
+	}
+	branch, err := gitOutput(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	status, err := gitOutput(ctx, dir, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := map[string]string{
+		gitSHAAnnotation:   sha,
+		gitDirtyAnnotation: strconv.FormatBool(status != ""),
+	}
+	// a detached HEAD is abbreviated as "HEAD"
+	if branch != "HEAD" {
+		annotations[gitBranchAnnotation] = branch
+	}
+	return annotations, nil
+}
+
+// gitOutput runs the git sub-command on the directory, returning its trimmed output, or the error
+// message written by git.
+func gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	// #nosec G204 only git sub-commands are run, on the directory informed
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,55 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestGitStamp(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git command not found")
+	}
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	_, err := gitStamp(ctx, dir)
+	g.Expect(errors.Is(err, errNotGitRepository)).To(gomega.BeTrue())
+
+	git := func(args ...string) string {
+		out, err := gitOutput(ctx, dir, args...)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		return out
+	}
+	git("init", "--quiet", "--initial-branch=main")
+	git("config", "user.email", "shp@example.com")
+	git("config", "user.name", "shp")
+	g.Expect(os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM scratch\n"), 0600)).To(gomega.Succeed())
+	git("add", "Dockerfile")
+	git("commit", "--quiet", "--message", "initial commit")
+	sha := git("rev-parse", "HEAD")
+
+	annotations, err := gitStamp(ctx, dir)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(annotations).To(gomega.Equal(map[string]string{
+		gitSHAAnnotation:    sha,
+		gitBranchAnnotation: "main",
+		gitDirtyAnnotation:  "false",
+	}))
+
+	// uncommitted changes, on a detached HEAD the branch is not known
+	g.Expect(os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM busybox\n"), 0600)).To(gomega.Succeed())
+	git("checkout", "--quiet", "--detach")
+	annotations, err = gitStamp(ctx, dir)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(annotations).To(gomega.Equal(map[string]string{
+		gitSHAAnnotation:   sha,
+		gitDirtyAnnotation: "true",
+	}))
+}
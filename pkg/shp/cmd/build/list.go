@@ -2,13 +2,17 @@ package build
 
 import (
 	"fmt"
+	"sort"
 	"text/tabwriter"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/util"
 	"github.com/spf13/cobra"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors" // Import the k8serrors package
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -18,18 +22,49 @@ import (
 type ListCommand struct {
 	cmd *cobra.Command
 
-	noHeader bool
+	noHeader      bool
+	allNamespaces bool // list Builds across all namespaces
+	fieldSelector string
+	sort          *flags.SortOptions
+	page          *flags.PageOptions
+	output        *flags.OutputOptions
 }
 
+const buildListLongDesc = `
+Lists the Builds in the current namespace, or across all namespaces with --all-namespaces.
+
+In large namespaces, use --limit to list a page of Builds at a time, the token to list the next
+page is printed on stderr and informed with --continue. The field selector is applied before the
+limit, and the sorting only applies to the Builds of the page:
+
+	$ shp build list --limit 50
+	$ shp build list --limit 50 --continue "..."
+
+Use --output custom-columns to choose the columns shown, like kubectl, i.e.:
+
+	$ shp build list -o custom-columns=NAME:.metadata.name,IMAGE:.spec.output.image
+
+For scripts and CI pipelines, --output json or yaml prints the Builds as a BuildList, to be consumed
+without parsing the table columns:
+
+	$ shp build list -o json | jq -r '.items[].spec.output.image'
+`
+
 func listCmd() runner.SubCommand {
 	listCommand := &ListCommand{
 		cmd: &cobra.Command{
 			Use:   "list [flags]",
 			Short: "List Builds",
+			Long:  buildListLongDesc,
 		},
 	}
 
 	listCommand.cmd.Flags().BoolVar(&listCommand.noHeader, "no-header", false, "Do not show columns header in list output")
+	flags.AllNamespacesFlags(listCommand.cmd.Flags(), &listCommand.allNamespaces)
+	flags.FieldSelectorFlags(listCommand.cmd.Flags(), &listCommand.fieldSelector)
+	listCommand.sort = flags.SortFlags(listCommand.cmd.Flags(), "name", "creation")
+	listCommand.page = flags.PageFlags(listCommand.cmd.Flags())
+	listCommand.output = flags.OutputFlags(listCommand.cmd.Flags())
 
 	return listCommand
 }
@@ -41,23 +76,32 @@ func (c *ListCommand) Cmd() *cobra.Command {
 
 // Complete fills object with user input data
 func (c *ListCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, _ []string) error {
+	c.output.NoHeaders = c.noHeader
 	return nil
 }
 
 // Validate checks user input data
 func (c *ListCommand) Validate() error {
-	return nil
+	if err := flags.ValidateAllNamespaces(c.cmd.Flags(), c.allNamespaces); err != nil {
+		return err
+	}
+	if err := c.sort.Validate(); err != nil {
+		return err
+	}
+	if err := c.page.Validate(); err != nil {
+		return err
+	}
+	return c.output.Validate()
 }
 
 // Run contains main logic of List subcommand of Build
 func (c *ListCommand) Run(params *params.Params, io *genericclioptions.IOStreams) error {
-	// TODO: Support multiple output formats here, not only tabwriter
-	//       find out more in kubectl libraries and use them
-
 	// Initialize tabwriter for command output
 	writer := tabwriter.NewWriter(io.Out, 0, 8, 2, '\t', 0)
 	columnNames := "NAME\tOUTPUT\tSTATUS"
-	columnTemplate := "%s\t%s\t%s\n"
+	if c.allNamespaces {
+		columnNames = "NAMESPACE\t" + columnNames
+	}
 
 	var buildList *buildv1alpha1.BuildList
 	clientset, err := params.ShipwrightClientSet()
@@ -65,24 +109,43 @@ func (c *ListCommand) Run(params *params.Params, io *genericclioptions.IOStreams
 		return err
 	}
 
-	k8sclient, err := params.ClientSet()
-	if err != nil {
-		return fmt.Errorf("failed to get k8s client: %w", err)
-	}
-	_, err = k8sclient.CoreV1().Namespaces().Get(c.cmd.Context(), params.Namespace(), metav1.GetOptions{})
-	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			fmt.Fprintf(io.Out, "Namespace '%s' not found. Please ensure that the namespace exists and try again.\n", params.Namespace())
-			return nil
+	namespace := metav1.NamespaceAll
+	if !c.allNamespaces {
+		namespace = params.Namespace()
+		k8sclient, err := params.ClientSet()
+		if err != nil {
+			return fmt.Errorf("failed to get k8s client: %w", err)
+		}
+		_, err = k8sclient.CoreV1().Namespaces().Get(c.cmd.Context(), namespace, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				fmt.Fprintf(io.Out, "Namespace '%s' not found. Please ensure that the namespace exists and try again.\n", namespace)
+				return nil
+			}
+			return err
 		}
-		return err
 	}
 
-	if buildList, err = clientset.ShipwrightV1alpha1().Builds(params.Namespace()).List(c.cmd.Context(), metav1.ListOptions{}); err != nil {
-		return err
+	listOpts := metav1.ListOptions{FieldSelector: c.fieldSelector}
+	c.page.ApplyTo(&listOpts)
+	if buildList, err = clientset.ShipwrightV1alpha1().Builds(namespace).List(c.cmd.Context(), listOpts); err != nil {
+		return c.page.Error(util.FieldSelectorError(err, c.fieldSelector))
+	}
+	c.sortBuilds(buildList.Items)
+	// telling how to list the next page after the list is printed
+	if msg := c.page.NextPage(buildList); msg != "" {
+		defer params.Logger(io.ErrOut).Info(msg)
+	}
+
+	if c.output.Enabled() {
+		return c.output.PrintObject(io.Out, buildList)
 	}
 	if len(buildList.Items) == 0 {
-		fmt.Fprintf(io.Out, "No builds found in namespace '%s'. Please create a build or verify the namespace.\n", params.Namespace())
+		if c.allNamespaces {
+			fmt.Fprintln(io.Out, "No builds found in any namespace. Please create a build.")
+			return nil
+		}
+		fmt.Fprintf(io.Out, "No builds found in namespace '%s'. Please create a build or verify the namespace.\n", namespace)
 		return nil
 	}
 
@@ -90,13 +153,36 @@ func (c *ListCommand) Run(params *params.Params, io *genericclioptions.IOStreams
 		fmt.Fprintln(writer, columnNames)
 	}
 
+	color := params.Color(io.Out)
 	for _, b := range buildList.Items {
 		message := ""
 		if b.Status.Message != nil {
 			message = *b.Status.Message
 		}
-		fmt.Fprintf(writer, columnTemplate, b.Name, b.Spec.Output.Image, message)
+		registered := corev1.ConditionUnknown
+		if b.Status.Registered != nil {
+			registered = *b.Status.Registered
+		}
+		if c.allNamespaces {
+			fmt.Fprintf(writer, "%s\t", b.Namespace)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", b.Name, b.Spec.Output.Image, util.ColorStatus(message, registered, color))
 	}
 
 	return writer.Flush()
 }
+
+// sortBuilds sorts the Builds according to the sort flags, when informed.
+func (c *ListCommand) sortBuilds(builds []buildv1alpha1.Build) {
+	less := c.sort.Less(map[string]func(i, j int) bool{
+		"name": func(i, j int) bool {
+			return builds[i].Name < builds[j].Name
+		},
+		"creation": func(i, j int) bool {
+			return builds[i].CreationTimestamp.Before(&builds[j].CreationTimestamp)
+		},
+	})
+	if less != nil {
+		sort.SliceStable(builds, less)
+	}
+}
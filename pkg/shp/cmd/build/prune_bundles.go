@@ -0,0 +1,225 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/bundle"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/util"
+)
+
+const (
+	// olderThanFlag command-line flag, minimum age of the source bundle images pruned.
+	olderThanFlag = "older-than"
+	// pruneDeleteFlag command-line flag, deletes the dangling images instead of only listing them.
+	pruneDeleteFlag = "delete"
+)
+
+// listBundleImages and deleteBundleImage access the container registry, replaced on testing.
+var (
+	listBundleImages  = bundle.ListImages
+	deleteBundleImage = bundle.DeleteImage
+)
+
+// PruneBundlesCommand represents the "build prune-bundles" sub-command, which finds the source
+// bundle images no longer referenced by the Builds and BuildRuns.
+type PruneBundlesCommand struct {
+	cmd *cobra.Command // cobra command instance
+
+	repository   string                 // source bundle repository, as informed
+	repo         name.Repository        // parsed source bundle repository
+	olderThan    time.Duration          // minimum age of the images pruned
+	delete       bool                   // deletes the dangling images, otherwise only lists them
+	yes          bool                   // skips the confirmation prompt
+	registryOpts bundle.RegistryOptions // CLI's own registry connection settings
+}
+
+const buildPruneBundlesLongDesc = `
+Lists the source bundle images of the repository, pushed i.e. by "shp build upload", which are no
+longer referenced by any Build or BuildRun in the namespace, and are older than --older-than. For
+example:
+
+	$ shp build prune-bundles ghcr.io/org/app-source --older-than 720h
+
+Nothing is deleted unless --delete is informed, which permanently removes the images from the
+container registry, using the local registry credentials. The deletion is confirmed interactively,
+unless --yes is informed or stdin is not a terminal.
+
+The age is read from the image creation time. Source bundles pushed by shp carry a reproducible
+creation time in 1970, so only the references to them keep them from being pruned.
+`
+
+// Cmd returns cobra.Command object of the prune-bundles sub-command.
+func (c *PruneBundlesCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete picks the source bundle repository from arguments.
+func (c *PruneBundlesCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.repository = args[0]
+	return nil
+}
+
+// Validate the repository must be a valid repository reference, without tag or digest.
+func (c *PruneBundlesCommand) Validate() error {
+	if c.olderThan < 0 {
+		return fmt.Errorf("--%s must be a positive duration", olderThanFlag)
+	}
+	var err error
+	if c.repo, err = bundle.ParseRepository(c.repository, c.registryOpts); err != nil {
+		return fmt.Errorf("invalid source bundle repository %q: %w", c.repository, err)
+	}
+	return nil
+}
+
+// Run lists the dangling source bundle images, and deletes them when requested.
+func (c *PruneBundlesCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	referenced, err := c.referencedImages(params)
+	if err != nil {
+		return err
+	}
+	images, err := listBundleImages(c.cmd.Context(), c.repo, c.registryOpts)
+	if err != nil {
+		return fmt.Errorf("unable to list the images of %q: %w", c.repo.Name(), err)
+	}
+
+	dangling := []bundle.Image{}
+	cutoff := time.Now().Add(-c.olderThan)
+	for _, image := range images {
+		if image.Created.Before(cutoff) && !referenced.Has(image.Digest.DigestStr()) && !referenced.HasAny(image.Tags...) {
+			dangling = append(dangling, image)
+		}
+	}
+	if len(dangling) == 0 {
+		fmt.Fprintf(ioStreams.Out, "No dangling source bundle images found in %q\n", c.repo.Name())
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(writer, "DIGEST\tTAGS\tAGE")
+	for _, image := range dangling {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", image.Digest.DigestStr(), strings.Join(image.Tags, ","), imageAge(image.Created))
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+
+	logger := params.Logger(ioStreams.ErrOut)
+	if !c.delete {
+		logger.Info(fmt.Sprintf("%d dangling source bundle images found, use --%s to remove them from the registry",
+			len(dangling), pruneDeleteFlag))
+		return nil
+	}
+
+	logger.Warning(fmt.Sprintf("--%s permanently removes the images from the container registry %q",
+		pruneDeleteFlag, c.repo.RegistryStr()))
+	if !c.yes && params.Interactive(ioStreams.In) {
+		question := fmt.Sprintf("About to delete %d images from %q, continue?", len(dangling), c.repo.Name())
+		confirmed, err := util.Confirm(ioStreams.In, ioStreams.ErrOut, question)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(ioStreams.Out, "Deletion canceled, no images deleted")
+			return nil
+		}
+	}
+
+	errs := []error{}
+	for _, image := range dangling {
+		if err = deleteBundleImage(c.cmd.Context(), image.Digest, c.registryOpts); err != nil {
+			logger.Warning(fmt.Sprintf("Error deleting image %q: %v", image.Digest.String(), err))
+			errs = append(errs, fmt.Errorf("failed to delete image %q: %w", image.Digest.String(), err))
+			continue
+		}
+		fmt.Fprintf(ioStreams.Out, "Image deleted %q\n", image.Digest.String())
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// referencedImages returns the tags and digests of the repository referenced by the Builds and
+// BuildRuns in the namespace, including the digests of the bundles the BuildRuns have pulled.
+func (c *PruneBundlesCommand) referencedImages(params *params.Params) (sets.String, error) {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return nil, err
+	}
+	ctx := c.cmd.Context()
+	builds, err := clientset.ShipwrightV1alpha1().Builds(params.Namespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	buildRuns, err := clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := sets.NewString()
+	addSpec := func(spec *buildv1alpha1.BuildSpec) {
+		if spec == nil || spec.Source.BundleContainer == nil {
+			return
+		}
+		ref, err := name.ParseReference(spec.Source.BundleContainer.Image)
+		if err != nil || ref.Context().Name() != c.repo.Name() {
+			return
+		}
+		referenced.Insert(ref.Identifier())
+	}
+	for i := range builds.Items {
+		addSpec(&builds.Items[i].Spec)
+	}
+	for i := range buildRuns.Items {
+		br := &buildRuns.Items[i]
+		addSpec(br.Spec.BuildSpec)
+		addSpec(br.Status.BuildSpec)
+		for _, source := range br.Status.Sources {
+			if source.Bundle != nil && source.Bundle.Digest != "" {
+				referenced.Insert(source.Bundle.Digest)
+			}
+		}
+	}
+	return referenced, nil
+}
+
+// imageAge returns the image age in human readable form, or unknown for the reproducible creation
+// time of the source bundles.
+func imageAge(created time.Time) string {
+	if created.Unix() <= 0 {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(created))
+}
+
+// pruneBundlesCmd instantiate the "build prune-bundles" sub-command.
+func pruneBundlesCmd() runner.SubCommand {
+	c := &PruneBundlesCommand{
+		cmd: &cobra.Command{
+			Use:   "prune-bundles <repository>",
+			Short: "Find, and optionally delete, the dangling source bundle images",
+			Long:  buildPruneBundlesLongDesc,
+			Args:  cobra.ExactArgs(1),
+		},
+	}
+
+	c.cmd.Flags().DurationVar(&c.olderThan, olderThanFlag, 7*24*time.Hour, "only prune the images older than the given duration")
+	c.cmd.Flags().BoolVar(&c.delete, pruneDeleteFlag, false,
+		"DANGEROUS: delete the dangling images from the container registry, by default they are only listed")
+	flags.YesFlags(c.cmd.Flags(), &c.yes)
+	sourceBundleRegistryFlags(c.cmd.Flags(), &c.registryOpts)
+	return c
+}
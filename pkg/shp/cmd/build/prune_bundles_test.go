@@ -0,0 +1,96 @@
+package build
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/bundle"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestPruneBundles(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	defer func() {
+		listBundleImages = bundle.ListImages
+		deleteBundleImage = bundle.DeleteImage
+	}()
+
+	const repository = "registry.example.com/org/source"
+	digest := func(hex string) name.Digest {
+		d, err := name.NewDigest(repository + "@sha256:" + hex)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		return d
+	}
+	var (
+		inUseByBuild    = "1111111111111111111111111111111111111111111111111111111111111111"
+		inUseByBuildRun = "2222222222222222222222222222222222222222222222222222222222222222"
+		dangling        = "3333333333333333333333333333333333333333333333333333333333333333"
+		recent          = "4444444444444444444444444444444444444444444444444444444444444444"
+	)
+	images := []bundle.Image{
+		{Digest: digest(inUseByBuild), Tags: []string{"latest"}, Created: time.Unix(0, 0)},
+		{Digest: digest(inUseByBuildRun), Tags: []string{"v1"}, Created: time.Unix(0, 0)},
+		{Digest: digest(dangling), Tags: []string{"v2", "v3"}, Created: time.Unix(0, 0)},
+		{Digest: digest(recent), Tags: []string{"v4"}, Created: time.Now()},
+	}
+	listBundleImages = func(_ context.Context, repo name.Repository, _ bundle.RegistryOptions) ([]bundle.Image, error) {
+		g.Expect(repo.Name()).To(gomega.Equal(repository))
+		return images, nil
+	}
+	deleted := []string{}
+	deleteBundleImage = func(_ context.Context, d name.Digest, _ bundle.RegistryOptions) error {
+		deleted = append(deleted, d.DigestStr())
+		return nil
+	}
+
+	b := &buildv1alpha1.Build{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "app"}}
+	b.Spec.Source.BundleContainer = &buildv1alpha1.BundleContainer{Image: repository + ":latest"}
+	other := &buildv1alpha1.Build{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "other"}}
+	other.Spec.Source.BundleContainer = &buildv1alpha1.BundleContainer{Image: "registry.example.com/org/other:v2"}
+	br := &buildv1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "app-1"}}
+	br.Status.Sources = []buildv1alpha1.SourceResult{{
+		Name:   "default",
+		Bundle: &buildv1alpha1.BundleSourceResult{Digest: "sha256:" + inUseByBuildRun},
+	}}
+	param := params.NewParamsForTest(nil, shpfake.NewSimpleClientset(b, other, br), nil, metav1.NamespaceDefault, nil, nil)
+
+	run := func(args ...string) (string, string, error) {
+		cmd := pruneBundlesCmd().(*PruneBundlesCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().ParseFlags(args)).To(gomega.Succeed())
+		g.Expect(cmd.Complete(param, nil, []string{repository})).To(gomega.Succeed())
+		g.Expect(cmd.Validate()).To(gomega.Succeed())
+		ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), errOut.String(), err
+	}
+
+	// by default the dangling images are only listed
+	out, errOut, err := run()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.Equal("DIGEST                                                                   TAGS   AGE\n" +
+		"sha256:" + dangling + "  v2,v3  <unknown>\n"))
+	g.Expect(errOut).To(gomega.Equal("1 dangling source bundle images found, use --delete to remove them from the registry\n"))
+	g.Expect(deleted).To(gomega.BeEmpty())
+
+	out, errOut, err = run("--delete", "--older-than=0s")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.ContainSubstring("Image deleted \"" + repository + "@sha256:" + dangling + "\"\n"))
+	g.Expect(out).To(gomega.ContainSubstring("sha256:" + recent))
+	g.Expect(errOut).To(gomega.ContainSubstring("--delete permanently removes the images"))
+	g.Expect(deleted).To(gomega.Equal([]string{"sha256:" + dangling, "sha256:" + recent}))
+
+	cmd := pruneBundlesCmd().(*PruneBundlesCommand)
+	g.Expect(cmd.Complete(param, nil, []string{repository + ":latest"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).ToNot(gomega.Succeed())
+}
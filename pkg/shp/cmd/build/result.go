@@ -0,0 +1,129 @@
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const (
+	// resultFormatFlag command-line flag, format the BuildRun result is written on.
+	resultFormatFlag = "result-format"
+	// resultFormatEnv result written as "key=value" lines, the GitHub Actions output format.
+	resultFormatEnv = "env"
+	// resultFormatJSON result written as a JSON object.
+	resultFormatJSON = "json"
+
+	// githubOutputEnv environment variable naming the file GitHub Actions reads the step outputs
+	// from, the result is appended to it instead of written on stdout.
+	githubOutputEnv = "GITHUB_OUTPUT"
+	// githubOutputJSONKey step output name of the JSON result on the GitHub Actions output file.
+	githubOutputJSONKey = "result"
+)
+
+// buildResult the outcome of a successful BuildRun, for CI systems to capture.
+type buildResult struct {
+	BuildRun string `json:"buildrun"`
+	Image    string `json:"image"`
+	Digest   string `json:"digest"`
+}
+
+// validateResultFormat checks the result format is one of the supported ones.
+func validateResultFormat(format string) error {
+	switch format {
+	case "", resultFormatEnv, resultFormatJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid --%s %q, use either %q or %q",
+		resultFormatFlag, format, resultFormatEnv, resultFormatJSON)
+}
+
+// newBuildResult returns the result of the successful BuildRun, failing when the image or digest
+// are not reported.
+func newBuildResult(br *buildv1alpha1.BuildRun) (*buildResult, error) {
+	if !br.IsSuccessful() {
+		return nil, fmt.Errorf("BuildRun %q has not succeeded, no result to write on --%s", br.Name, resultFormatFlag)
+	}
+	image, digest, err := outputImage(br)
+	if err != nil {
+		return nil, fmt.Errorf("unable to write the result on --%s: %w", resultFormatFlag, err)
+	}
+	return &buildResult{BuildRun: br.Name, Image: image, Digest: digest}, nil
+}
+
+// write writes the result on the given format.
+func (b *buildResult) write(w io.Writer, format string) error {
+	if format == resultFormatJSON {
+		return json.NewEncoder(w).Encode(b)
+	}
+	_, err := fmt.Fprintf(w, "buildrun=%s\nimage=%s\ndigest=%s\n", b.BuildRun, b.Image, b.Digest)
+	return err
+}
+
+// writeGitHubOutput writes the result as GitHub Actions step outputs, the JSON object is written as
+// a single "result=<json>" line.
+func (b *buildResult) writeGitHubOutput(w io.Writer, format string) error {
+	if format != resultFormatJSON {
+		return b.write(w, format)
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s=%s\n", githubOutputJSONKey, data)
+	return err
+}
+
+// resultOnStdout tells whether the result is written on stdout, so everything else goes to stderr
+// for the result to be captured.
+func (r *RunCommand) resultOnStdout() bool {
+	return r.resultFormat != "" && os.Getenv(githubOutputEnv) == ""
+}
+
+// logStreams returns the streams the logs and messages are written to, when the result is written
+// on stdout they are moved to stderr.
+func (r *RunCommand) logStreams(ioStreams *genericclioptions.IOStreams) *genericclioptions.IOStreams {
+	if !r.resultOnStdout() {
+		return ioStreams
+	}
+	logStreams := *ioStreams
+	logStreams.Out = ioStreams.ErrOut
+	return &logStreams
+}
+
+// writeResult writes the result of the BuildRun on the format informed with --result-format. The
+// result is appended to the file named by GITHUB_OUTPUT when it's set, otherwise it's written on
+// stdout.
+func (r *RunCommand) writeResult(out io.Writer) error {
+	br, err := r.follower.BuildRun()
+	if err != nil {
+		return err
+	}
+	if br == nil {
+		return fmt.Errorf("BuildRun not found, no result to write on --%s", resultFormatFlag)
+	}
+	result, err := newBuildResult(br)
+	if err != nil {
+		return err
+	}
+
+	githubOutput := os.Getenv(githubOutputEnv)
+	if githubOutput == "" {
+		return result.write(out, r.resultFormat)
+	}
+	// #nosec G304 the file is informed by the CI system on purpose
+	f, err := os.OpenFile(githubOutput, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err = result.writeGitHubOutput(f, r.resultFormat); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -1,33 +1,106 @@
 package build
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/follower"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
 	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/util"
 
 	"github.com/spf13/cobra"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
+const (
+	// validateFlag command-line flag, toggles the validation of the source overrides and service
+	// account.
+	validateFlag = "validate"
+	// skipPushFlag command-line flag, runs the build without pushing the output image.
+	skipPushFlag = "skip-push"
+	// skipPushParam build strategy parameter which instructs the strategy not to push the image.
+	skipPushParam = "skip-push"
+	// schedulerTimeoutFlag command-line flag, maximum time for the BuildRun pod to start running.
+	schedulerTimeoutFlag = "scheduler-timeout"
+	// generateNamePrefixFlag command-line flag, prefix of the generated BuildRun name.
+	generateNamePrefixFlag = "generate-name-prefix"
+	// timingsFlag command-line flag, prints where the time went once the BuildRun is done.
+	timingsFlag = "timings"
+	// cancelOnInterruptFlag command-line flag, cancels the BuildRun when following is interrupted.
+	cancelOnInterruptFlag = "cancel-on-interrupt"
+	// cancelTimeout maximum time to request the BuildRun cancellation, once interrupted.
+	cancelTimeout = 10 * time.Second
+	// retryOnFailureFlag command-line flag, amount of BuildRuns created again on infrastructure failures.
+	retryOnFailureFlag = "retry-on-failure"
+	// parallelismFlag command-line flag, maximum amount of builds followed at once.
+	parallelismFlag = "parallelism"
+	// digestFileFlag command-line flag, file the pushed image reference by digest is written to.
+	digestFileFlag = "digest-file"
+	// generatedNameSuffix stands for the random suffix appended by the API server to the
+	// generateName, used to validate the resulting BuildRun name.
+	generatedNameSuffix = "abcde"
+)
+
+// infraFailureReasons BuildRun failure reasons caused by the cluster rather than by the build itself,
+// the only ones retried with --retry-on-failure.
+var infraFailureReasons = sets.NewString(
+	buildv1alpha1.BuildRunStatePodEvicted,
+	"ExceededNodeResources",
+	"ExceededResourceQuota",
+	"PodCreationFailed",
+	"TaskRunImagePullFailed",
+)
+
 // RunCommand represents the `build run` sub-command, which creates a unique BuildRun instance to run
 // the build process, informed via arguments.
 type RunCommand struct {
 	cmd *cobra.Command // cobra command instance
 
-	buildName     string
-	namespace     string
-	buildRunSpec  *buildv1alpha1.BuildRunSpec // stores command-line flags
-	follow        bool                        // flag to tail pod logs
-	follower      *follower.Follower
-	followerReady chan bool
+	buildName         string
+	buildNames        []string // builds informed on arguments, each one runs on its own when more than one
+	namespace         string
+	buildRunSpec      *buildv1alpha1.BuildRunSpec // stores command-line flags
+	source            *buildv1alpha1.Source       // source overrides, only applied on the BuildRun
+	envRemoved        []string                    // Build environment variables removed on the BuildRun
+	metadata          *flags.Metadata             // labels and annotations informed on command-line
+	validate          bool                        // flag to validate the source overrides and service account
+	skipPush          bool                        // flag to build without pushing the image
+	follow            bool                        // flag to tail pod logs
+	quiet             bool                        // flag to print only the BuildRun name, or suppress the pod status while following
+	reconnectTail     int64                       // log lines repeated when reconnecting a log stream
+	schedulerTimeout  time.Duration               // maximum time for the pod to start running when following
+	namePrefix        string                      // prefix of the generated BuildRun name
+	timings           bool                        // prints the timing breakdown when following
+	cancelOnInterrupt bool                        // cancels the BuildRun when following is interrupted
+	retryOnFailure    int                         // BuildRuns created again on infrastructure failures
+	stampGit          bool                        // annotates the BuildRun with the local git checkout details
+	digestFile        string                      // file the pushed image reference by digest is written to
+	resultFormat      string                      // format the BuildRun result is written on, i.e. for CI step outputs
+	parallelism       int                         // maximum amount of builds followed at once, all by default
+	buildRunName      string                      // name of the last BuildRun created
+	paramFile         string                      // file with the parameter values, "-" for stdin
+	paramFileValues   []buildv1alpha1.ParamValue  // parameter values read from the param file
+	envFile           string                      // dotenv file with environment variables, "-" for stdin
+	envFileValues     []corev1.EnvVar             // environment variables read from the env file
+	follower          *follower.Follower
+	followerReady     chan bool
 }
 
 const buildRunLongDesc = `
@@ -35,6 +108,121 @@ Creates a unique BuildRun instance for the given Build, which starts the build
 process orchestrated by the Shipwright build controller. For example:
 
 	$ shp build run my-app
+
+The Build's source can be overridden for the BuildRun alone, leaving the Build
+untouched, i.e. to run the Build against a fork:
+
+	$ shp build run my-app --source-url="..." --git-revision="..." --source-git-clone-secret="..."
+
+Or against a source bundle image pushed beforehand, i.e. by an earlier stage of a pipeline, in place
+of the Build's git repository, without uploading any local source:
+
+	$ shp build run my-app --source-bundle-image="ghcr.io/org/app-source:abc123"
+
+Likewise, environment variables are added or overridden with --env NAME=value, and the ones declared
+by the Build are removed with --env NAME-, affecting only the BuildRun, not the Build:
+
+	$ shp build run my-app --env LOG_LEVEL=debug --env PROXY-
+
+Many environment variables are informed at once with --env-from-file, a dotenv file of "NAME=value"
+lines, or "-" to read it from stdin. The variables informed with --env take precedence:
+
+	$ shp build run my-app --env-from-file=build.env --env LOG_LEVEL=debug
+
+Labels and annotations are stamped on the image pushed with --output-label and --output-annotation,
+i.e. the git revision or the CI job URL. Since the BuildRun output replaces the Build's output, they
+require --output-image, and whether they are applied depends on the build strategy honoring them:
+
+	$ shp build run my-app --output-image="..." --output-label org.opencontainers.image.revision=abc123
+
+Many parameter values are informed at once with --param-file, a YAML or JSON file of "name: value"
+entries, where array parameters take a list of values, or "-" to read it from stdin. The values
+informed with --param-value take precedence. The values are verified against the parameters the
+build strategy declares, when it can be read, warning about the ones it does not declare:
+
+	$ shp build run my-app --param-file=params.yaml --param-value=verbose=true
+
+The BuildRun runs under the service account informed with --service-account, i.e. one holding the
+credentials for a particular registry, in place of the default one. It must exist in the
+namespace, unless --validate=false is informed, while --service-account-generate has a service
+account generated for the build instead:
+
+	$ shp build run my-app --service-account=registry-pusher
+
+To validate the Build without publishing the image, use --skip-push. It requires a build
+strategy declaring the "skip-push" parameter, which is set to "true" on the BuildRun.
+
+When following the logs, --scheduler-timeout fails fast if the BuildRun pod can't start running in
+time, i.e. for lack of cluster capacity, printing why the pod is still pending. It is independent of
+the Build timeout.
+
+Unless the source is overridden, environment variables are removed, --param-file or --skip-push is
+informed, the Build is not fetched, the BuildRun only references it by name and the controller resolves the
+rest.
+
+The BuildRun name is generated out of the Build name, use --generate-name-prefix to group the
+BuildRuns of a particular trigger under a custom prefix instead:
+
+	$ shp build run my-app --generate-name-prefix nightly-
+
+With --quiet only the BuildRun name is printed, i.e. to capture it on scripts, the warnings and errors
+are still written to stderr. Together with --follow, the name is printed on the first line, ahead of
+the logs:
+
+	$ name=$(shp build run my-app --quiet)
+	$ shp build run my-app --follow --quiet | head -n 1
+
+To tell the client and API latency apart from the actual build time, --timings prints a breakdown
+on stderr once the BuildRun is done, together with --follow. The create and total times are taken
+from the CLI's own clock, while the time to schedule the pod and the build time are taken from the
+BuildRun and pod status:
+
+	$ shp build run my-app --follow --timings
+
+With --follow the command creates the BuildRun, streams its logs, and exits with a non-zero status
+when the BuildRun fails. Interrupting it, i.e. with Ctrl-C, stops following the logs and leaves the
+BuildRun running, unless --cancel-on-interrupt is informed, in which case the BuildRun is canceled.
+Either way, the command tells on stderr what happened to the BuildRun:
+
+	$ shp build run my-app --follow --cancel-on-interrupt
+
+On flaky infrastructure, --retry-on-failure creates a new BuildRun with the same spec when the
+BuildRun fails, and follows it again, up to the given amount of retries. Only the failures caused by
+the cluster are retried, i.e. an evicted pod or an image which could not be pulled, while build errors
+are not. The name and outcome of each attempt is printed on stderr:
+
+	$ shp build run my-app --follow --retry-on-failure 2
+
+For traceability from the image back to the source, --stamp-git annotates the BuildRun with the commit
+SHA, branch and whether there are uncommitted changes of the git checkout in the current directory,
+as "shp.shipwright.io/git-sha", "shp.shipwright.io/git-branch" and "shp.shipwright.io/git-dirty". It
+requires the git command-line, outside of a git checkout the annotations are skipped with a warning,
+and the ones informed with --annotation take precedence:
+
+	$ shp build run my-app --stamp-git
+
+For signing or attestation steps further down a pipeline, --digest-file writes the reference by digest
+of the image pushed, i.e. "registry/namespace/app@sha256:...", to the given file once the BuildRun
+succeeds, together with --follow. The file is created or truncated, and the command fails when the
+BuildRun does not report the image digest:
+
+	$ shp build run my-app --follow --digest-file=image-digest
+
+To capture the outcome on CI, --result-format writes the BuildRun name, image and digest pushed once
+the BuildRun succeeds, together with --follow. With "env" they are written as "key=value" lines, the
+GitHub Actions step output format, and with "json" as a JSON object. The result is appended to the
+file named by $GITHUB_OUTPUT when it's set, the JSON object as a single "result=<json>" line.
+Otherwise the result is written on stdout, and the logs and messages are written on stderr:
+
+	$ shp build run my-app --follow --result-format=env
+	$ result=$(shp build run my-app --follow --result-format=json)
+
+Several builds are run at once by informing more than one name, i.e. the components of a monorepo,
+a BuildRun is created for each one of them. With --follow their logs are followed concurrently, each
+line prefixed by the build name, up to --parallelism builds at a time. Once all of them are done a
+summary is printed, and the command exits with non-zero status when any of them has failed:
+
+	$ shp build run frontend backend worker --follow --parallelism 2
 `
 
 // Cmd returns cobra.Command object of the create sub-command.
@@ -44,20 +232,38 @@ func (r *RunCommand) Cmd() *cobra.Command {
 
 // Complete picks the build resource name from arguments, and instantiate additional components.
 func (r *RunCommand) Complete(params *params.Params, ioStreams *genericclioptions.IOStreams, args []string) error {
+	r.quiet = params.Quiet()
 	switch len(args) {
+	case 0:
+		return errors.New("build name is not informed")
 	case 1:
 		r.buildName = args[0]
-	default:
-		return errors.New("build name is not informed")
 	}
+	r.buildNames = args
 
 	r.namespace = params.Namespace()
+	if r.paramFile != "" {
+		var err error
+		if r.paramFileValues, err = flags.ReadParamFile(r.paramFile, ioStreams.In); err != nil {
+			return err
+		}
+	}
+	if r.envFile != "" {
+		if r.paramFile == "-" && r.envFile == "-" {
+			return fmt.Errorf("--%s and --%s can't both read from stdin", flags.ParamFileFlag, flags.EnvFromFileFlag)
+		}
+		var err error
+		if r.envFileValues, err = flags.ReadEnvFile(r.envFile, ioStreams.In); err != nil {
+			return err
+		}
+	}
+	// each build gets a command of its own on Run, see forBuild
+	if len(r.buildNames) > 1 {
+		return nil
+	}
 
 	if r.follow {
-		var err error
-		// provide empty build run name; will be set in Run()
-		r.follower, err = params.NewFollower(r.cmd.Context(), types.NamespacedName{}, ioStreams)
-		if err != nil {
+		if err := r.newFollower(params, r.logStreams(ioStreams)); err != nil {
 			return err
 		}
 		r.followerReady = make(chan bool, 1)
@@ -66,18 +272,121 @@ func (r *RunCommand) Complete(params *params.Params, ioStreams *genericclioption
 	return r.Cmd().Flags().Set(flags.BuildrefNameFlag, r.buildName)
 }
 
+// newFollower instantiate the log follower, a new one is needed for each BuildRun followed.
+func (r *RunCommand) newFollower(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	var err error
+	// provide empty build run name; will be set in Run()
+	r.follower, err = params.NewFollower(r.cmd.Context(), types.NamespacedName{}, ioStreams)
+	if err != nil {
+		return err
+	}
+	r.follower.SetQuiet(r.quiet)
+	r.follower.SetReconnectTail(r.reconnectTail)
+	r.follower.SetSchedulerTimeout(r.schedulerTimeout)
+	return nil
+}
+
+// Mutating the BuildRun is created in the namespace.
+func (r *RunCommand) Mutating() bool {
+	return true
+}
+
 // Validate the user must inform the build resource name.
 func (r *RunCommand) Validate() error {
-	if r.buildName == "" {
-		return fmt.Errorf("name is not informed")
+	if err := r.validateBuildNames(); err != nil {
+		return err
+	}
+	if err := flags.ValidateOutputImage(r.buildRunSpec.Output.Image); err != nil {
+		return err
+	}
+	if err := flags.ValidateBuildRunOutput(r.buildRunSpec.Output); err != nil {
+		return err
+	}
+	if err := r.validateSourceBundle(); err != nil {
+		return err
+	}
+	if err := validateResultFormat(r.resultFormat); err != nil {
+		return err
+	}
+	switch {
+	case r.schedulerTimeout < 0:
+		return fmt.Errorf("--%s must be a positive duration", schedulerTimeoutFlag)
+	case r.schedulerTimeout > 0 && !r.follow:
+		return fmt.Errorf("--%s can only be used together with --follow", schedulerTimeoutFlag)
+	case r.timings && !r.follow:
+		return fmt.Errorf("--%s can only be used together with --follow", timingsFlag)
+	case r.cancelOnInterrupt && !r.follow:
+		return fmt.Errorf("--%s can only be used together with --follow", cancelOnInterruptFlag)
+	case r.retryOnFailure < 0:
+		return fmt.Errorf("--%s must not be negative", retryOnFailureFlag)
+	case r.retryOnFailure > 0 && !r.follow:
+		return fmt.Errorf("--%s can only be used together with --follow", retryOnFailureFlag)
+	case r.digestFile != "" && !r.follow:
+		return fmt.Errorf("--%s can only be used together with --follow", digestFileFlag)
+	case r.digestFile != "" && len(r.buildNames) > 1:
+		return fmt.Errorf("--%s can only be used when running a single build", digestFileFlag)
+	case r.resultFormat != "" && !r.follow:
+		return fmt.Errorf("--%s can only be used together with --follow", resultFormatFlag)
+	case r.resultFormat != "" && len(r.buildNames) > 1:
+		return fmt.Errorf("--%s can only be used when running a single build", resultFormatFlag)
+	case r.parallelism < 0:
+		return fmt.Errorf("--%s must not be negative", parallelismFlag)
+	case r.parallelism > 0 && !r.follow:
+		return fmt.Errorf("--%s can only be used together with --follow", parallelismFlag)
+	}
+	if r.cmd.Flags().Changed(generateNamePrefixFlag) {
+		if r.namePrefix == "" {
+			return fmt.Errorf("invalid --%s \"\", the prefix must not be empty", generateNamePrefixFlag)
+		}
+		// the BuildRun name is also recorded as label value, hence validated as DNS label
+		if errs := validation.IsDNS1123Label(r.namePrefix + generatedNameSuffix); len(errs) > 0 {
+			return fmt.Errorf("invalid --%s %q, the generated BuildRun name is not valid: %s",
+				generateNamePrefixFlag, r.namePrefix, strings.Join(errs, "; "))
+		}
+	}
+	return flags.ValidateReconnectTail(r.reconnectTail)
+}
+
+// validateBuildNames checks the build names are informed, and only once each, since the output is
+// told apart by build name.
+func (r *RunCommand) validateBuildNames() error {
+	if len(r.buildNames) <= 1 {
+		if r.buildName == "" {
+			return fmt.Errorf("name is not informed")
+		}
+		return nil
+	}
+	names := sets.NewString()
+	for _, name := range r.buildNames {
+		if name == "" {
+			return fmt.Errorf("name is not informed")
+		}
+		if names.Has(name) {
+			return fmt.Errorf("build %q is informed more than once", name)
+		}
+		names.Insert(name)
 	}
 	return nil
 }
 
+// validateSourceBundle checks the source bundle image reference, which replaces the Build's git
+// repository, hence can't be combined with the git source overrides.
+func (r *RunCommand) validateSourceBundle() error {
+	if r.source.BundleContainer == nil || r.source.BundleContainer.Image == "" {
+		return nil
+	}
+	for _, flag := range []string{flags.SourceURLFlag, flags.SourceRevisionFlag, flags.GitRevisionFlag} {
+		if r.cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--%s can't be used together with --%s", flag, flags.SourceBundleImageFlag)
+		}
+	}
+	return flags.ValidateSourceBundleImage(r.source.BundleContainer.Image)
+}
+
 // FollowerReady blocks until the any log following connections are established in the Run call.
 // Useful if you have code that calls Run on a separate thread and coordination is needed.
 func (r *RunCommand) FollowerReady() bool {
-	if !r.follow {
+	if !r.follow || len(r.buildNames) > 1 {
 		return false
 	}
 	_, closed := <-r.followerReady
@@ -86,32 +395,269 @@ func (r *RunCommand) FollowerReady() bool {
 
 // Run creates a BuildRun resource based on Build's name informed on arguments.
 func (r *RunCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	if len(r.buildNames) > 1 {
+		return r.runBuilds(params, ioStreams)
+	}
+	started := time.Now()
+	// with the result written on stdout, everything else goes to stderr
+	resultOut := ioStreams.Out
+	ioStreams = r.logStreams(ioStreams)
+
 	// resource using GenerateName, which will provide a unique instance
+	generateName := fmt.Sprintf("%s-", r.buildName)
+	if r.cmd.Flags().Changed(generateNamePrefixFlag) {
+		generateName = r.namePrefix
+	}
 	br := &buildv1alpha1.BuildRun{
 		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: fmt.Sprintf("%s-", r.buildName),
+			GenerateName: generateName,
 		},
 		Spec: *r.buildRunSpec,
 	}
 	flags.SanitizeBuildRunSpec(&br.Spec)
+	br.Spec.ParamValues = flags.MergeParamValues(br.Spec.ParamValues, r.paramFileValues)
+	br.Spec.Env = flags.MergeEnv(br.Spec.Env, r.envFileValues)
+	if err := r.metadata.ApplyTo(&br.ObjectMeta); err != nil {
+		return err
+	}
+	if r.stampGit {
+		if err := r.stampGitAnnotations(params, ioStreams, br); err != nil {
+			return err
+		}
+	}
+	if warning := flags.OutputImageWarning(r.buildRunSpec.Output.Image); warning != "" {
+		params.Logger(ioStreams.ErrOut).Warning(warning)
+	}
+	params.WarnUnsupportedFlags(r.cmd.Context(), r.cmd.Flags(), ioStreams.ErrOut, flags.BuildRunFeatures)
 
 	ctx := r.cmd.Context()
 	clientset, err := params.ShipwrightClientSet()
 	if err != nil {
 		return err
 	}
+
+	// overriding the source or removing environment variables requires an embedded BuildSpec, it's
+	// the only case, besides --skip-push, where the Build is fetched; otherwise the BuildRun just
+	// references it by name, leaving the controller to resolve the rest
+	embed := flags.SanitizeSourceOverride(r.source) || len(r.envRemoved) > 0
+	if embed {
+		if err = r.embedBuildSpec(params, ioStreams, br); err != nil {
+			return err
+		}
+	}
+
+	if r.validate {
+		if err = r.verifyServiceAccount(params, br); err != nil {
+			return err
+		}
+	}
+
+	if len(r.paramFileValues) > 0 {
+		if err = r.verifyParamFile(params, ioStreams, br); err != nil {
+			return err
+		}
+	}
+
+	if r.skipPush {
+		if err = r.setSkipPush(params, br); err != nil {
+			return err
+		}
+	}
+
+	// the BuildRun created again on retries
+	template := br.DeepCopy()
 	br, err = clientset.ShipwrightV1alpha1().BuildRuns(r.namespace).Create(ctx, br, metav1.CreateOptions{})
 	if err != nil {
 		return err
 	}
+	created := time.Now()
+	r.buildRunName = br.GetName()
 
+	if r.quiet {
+		// when following, the name goes ahead of the logs, so scripts are still able to capture it
+		fmt.Fprintln(ioStreams.Out, br.GetName())
+	}
 	if !r.follow {
-		fmt.Fprintf(ioStreams.Out, "BuildRun created %q for build %q\n", br.GetName(), r.buildName)
+		if !r.quiet {
+			fmt.Fprintf(ioStreams.Out, "BuildRun created %q for build %q\n", br.GetName(), r.buildName)
+		}
 		return nil
 	}
 
-	buildRun := types.NamespacedName{Namespace: r.namespace, Name: br.GetName()}
-	r.follower.SetBuildRunName(buildRun)
+	for retry := 1; ; retry++ {
+		pod, err := r.followBuildRun(br.GetName(), embed, retry == 1)
+		if errors.Is(ctx.Err(), context.Canceled) {
+			r.onInterrupt(params, ioStreams, br.GetName())
+		}
+		if r.timings {
+			r.printTimings(params, ioStreams, br.GetName(), pod, created.Sub(started), time.Since(started))
+		}
+		if ctx.Err() != nil || !r.retry(params, ioStreams, br.GetName(), retry) {
+			if err != nil || ctx.Err() != nil {
+				return err
+			}
+			if r.digestFile != "" {
+				if err = r.writeDigestFile(br.GetName()); err != nil {
+					return err
+				}
+			}
+			if r.resultFormat != "" {
+				return r.writeResult(resultOut)
+			}
+			return nil
+		}
+
+		if err = r.newFollower(params, ioStreams); err != nil {
+			return err
+		}
+		started = time.Now()
+		br, err = clientset.ShipwrightV1alpha1().BuildRuns(r.namespace).Create(ctx, template.DeepCopy(), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		created = time.Now()
+		r.buildRunName = br.GetName()
+		params.Logger(ioStreams.ErrOut).Info(fmt.Sprintf("BuildRun created %q for build %q, retry %d/%d",
+			br.GetName(), r.buildName, retry, r.retryOnFailure))
+	}
+}
+
+// stampGitAnnotations annotates the BuildRun with the details of the git checkout in the current
+// directory, keeping the annotations informed on command-line. Outside of a git checkout the
+// annotations are skipped with a warning.
+func (r *RunCommand) stampGitAnnotations(
+	params *params.Params,
+	ioStreams *genericclioptions.IOStreams,
+	br *buildv1alpha1.BuildRun,
+) error {
+	annotations, err := gitStamp(r.cmd.Context(), ".")
+	if errors.Is(err, errNotGitRepository) {
+		params.Logger(ioStreams.ErrOut).Warning(fmt.Sprintf("skipping --%s, %s", stampGitFlag, err))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read the git checkout for --%s: %w", stampGitFlag, err)
+	}
+	if br.Annotations == nil {
+		br.Annotations = map[string]string{}
+	}
+	for k, v := range annotations {
+		if _, ok := br.Annotations[k]; !ok {
+			br.Annotations[k] = v
+		}
+	}
+	return nil
+}
+
+// runBuilds runs each one of the builds informed on a command of its own. Without --follow the
+// BuildRuns are created one after the other, otherwise the builds are followed concurrently, up to
+// --parallelism at once, with the output lines prefixed by the build name, and a summary is printed
+// once all of them are done. An error is returned when any of the builds has failed.
+func (r *RunCommand) runBuilds(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	if !r.follow {
+		errs := []error{}
+		for _, name := range r.buildNames {
+			child, err := r.forBuild(params, ioStreams, name)
+			if err == nil {
+				err = child.Run(params, ioStreams)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("build %q: %w", name, err))
+			}
+		}
+		return utilerrors.NewAggregate(errs)
+	}
+
+	width := 0
+	for _, name := range r.buildNames {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	parallelism := r.parallelism
+	if parallelism == 0 || parallelism > len(r.buildNames) {
+		parallelism = len(r.buildNames)
+	}
+
+	children := make([]*RunCommand, len(r.buildNames))
+	errs := make([]error, len(r.buildNames))
+	outLock, errOutLock := &sync.Mutex{}, &sync.Mutex{}
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, name := range r.buildNames {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			prefix := fmt.Sprintf("%-*s | ", width, name)
+			out := util.NewPrefixWriter(ioStreams.Out, prefix, outLock)
+			errOut := util.NewPrefixWriter(ioStreams.ErrOut, prefix, errOutLock)
+			childStreams := &genericclioptions.IOStreams{In: ioStreams.In, Out: out, ErrOut: errOut}
+			children[i], errs[i] = r.forBuild(params, childStreams, name)
+			if errs[i] == nil {
+				errs[i] = children[i].Run(params, childStreams)
+			}
+			_ = out.Flush()
+			_ = errOut.Flush()
+		}(i, name)
+	}
+	wg.Wait()
+
+	failed := 0
+	writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(writer, "BUILD\tBUILDRUN\tRESULT\tMESSAGE")
+	for i, name := range r.buildNames {
+		brName, result, message := "<none>", "Succeeded", ""
+		if children[i] != nil && children[i].buildRunName != "" {
+			brName = children[i].buildRunName
+		}
+		switch {
+		case errs[i] != nil:
+			failed++
+			result, message = "Failed", errs[i].Error()
+		case r.cmd.Context().Err() != nil:
+			result = "Interrupted"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", name, brName, result, message)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d builds failed", failed, len(r.buildNames))
+	}
+	return nil
+}
+
+// forBuild returns a copy of the command running only the informed build, with its own BuildRun
+// spec, source overrides and follower writing on the informed streams.
+func (r *RunCommand) forBuild(
+	params *params.Params,
+	ioStreams *genericclioptions.IOStreams,
+	name string,
+) (*RunCommand, error) {
+	child := *r
+	child.buildName = name
+	child.buildNames = []string{name}
+	child.buildRunName = ""
+	child.buildRunSpec = r.buildRunSpec.DeepCopy()
+	child.buildRunSpec.BuildRef.Name = name
+	child.source = r.source.DeepCopy()
+	if r.follow {
+		if err := child.newFollower(params, ioStreams); err != nil {
+			return nil, err
+		}
+		child.followerReady = make(chan bool, 1)
+	}
+	return &child, nil
+}
+
+// followBuildRun streams the logs of the BuildRun until it's done, returning its pod. The first
+// BuildRun followed signals the follower is ready once connected.
+func (r *RunCommand) followBuildRun(name string, embed bool, first bool) (*corev1.Pod, error) {
+	r.follower.SetBuildRunName(types.NamespacedName{Namespace: r.namespace, Name: name})
 
 	// instantiating a pod watcher with a specific label-selector to find the indented pod where the
 	// actual build started by this subcommand is being executed, including the randomized buildrun
@@ -119,28 +665,534 @@ func (r *RunCommand) Run(params *params.Params, ioStreams *genericclioptions.IOS
 	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf(
 		"build.shipwright.io/name=%s,buildrun.shipwright.io/name=%s",
 		r.buildName,
-		br.GetName(),
+		name,
 	)}
-	err = r.follower.Connect(listOpts)
+	// a BuildRun with an embedded BuildSpec is not bound to the Build, only the BuildRun name is
+	// available to select the pod
+	if embed {
+		listOpts.LabelSelector = fmt.Sprintf("%s=%s", buildv1alpha1.LabelBuildRun, name)
+	}
+	if err := r.follower.Connect(listOpts); err != nil {
+		return nil, err
+	}
+	if first {
+		close(r.followerReady)
+	}
+	return r.follower.WaitForCompletion()
+}
+
+// retry tells whether the BuildRun is created again, with --retry-on-failure, once it has failed for
+// an infrastructure reason and retries are left. The outcome of the BuildRun is printed on stderr.
+func (r *RunCommand) retry(params *params.Params, ioStreams *genericclioptions.IOStreams, name string, retry int) bool {
+	if r.retryOnFailure == 0 {
+		return false
+	}
+	logger := params.Logger(ioStreams.ErrOut)
+	br, err := r.follower.BuildRun()
+	if err != nil {
+		logger.Warning(fmt.Sprintf("BuildRun %q is not retried: %s", name, err))
+		return false
+	}
+	reason, failed := failureReason(br)
+	switch {
+	case !failed:
+		if br != nil && br.IsSuccessful() {
+			logger.Info(fmt.Sprintf("BuildRun %q succeeded", name))
+		}
+		return false
+	case !infraFailureReasons.Has(reason):
+		logger.Info(fmt.Sprintf("BuildRun %q failed with reason %q, not retried since it's not an infrastructure failure",
+			name, reason))
+		return false
+	case retry > r.retryOnFailure:
+		logger.Info(fmt.Sprintf("BuildRun %q failed with reason %q, no retries left", name, reason))
+		return false
+	}
+	logger.Info(fmt.Sprintf("BuildRun %q failed with reason %q, retrying", name, reason))
+	return true
+}
+
+// writeDigestFile writes the reference by digest of the image pushed by the BuildRun to the file
+// informed on --digest-file, failing when the BuildRun has not succeeded or does not report the
+// digest.
+func (r *RunCommand) writeDigestFile(brName string) error {
+	br, err := r.follower.BuildRun()
+	if err != nil {
+		return err
+	}
+	if br == nil || !br.IsSuccessful() {
+		return fmt.Errorf("BuildRun %q has not succeeded, no image digest to write on --%s", brName, digestFileFlag)
+	}
+	ref, err := imageDigestReference(br)
+	if err != nil {
+		return fmt.Errorf("unable to write the image digest on --%s: %w", digestFileFlag, err)
+	}
+
+	// #nosec G304 the file is informed by the user on purpose
+	f, err := os.Create(r.digestFile)
+	if err != nil {
+		return err
+	}
+	if _, err = f.WriteString(ref); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// imageDigestReference returns the reference by digest of the image pushed by the BuildRun.
+func imageDigestReference(br *buildv1alpha1.BuildRun) (string, error) {
+	image, digest, err := outputImage(br)
+	if err != nil {
+		return "", err
+	}
+	return util.ImageDigestReference(image, digest), nil
+}
+
+// outputImage returns the image pushed by the BuildRun, out of the BuildRun's output image, or
+// otherwise the Build's, and the digest reported on the status.
+func outputImage(br *buildv1alpha1.BuildRun) (string, string, error) {
+	if br.Status.Output == nil || br.Status.Output.Digest == "" {
+		return "", "", fmt.Errorf("BuildRun %q does not report the image digest", br.Name)
+	}
+	image := util.OutputImage(br)
+	if image == "" {
+		return "", "", fmt.Errorf("BuildRun %q does not report the output image", br.Name)
+	}
+	return image, br.Status.Output.Digest, nil
+}
+
+// failureReason returns the reason of the BuildRun failure, and whether it has failed at all. Canceled
+// or deleted BuildRuns are not considered a failure.
+func failureReason(br *buildv1alpha1.BuildRun) (string, bool) {
+	if br == nil || br.DeletionTimestamp != nil || br.IsCanceled() {
+		return "", false
+	}
+	c := br.Status.GetCondition(buildv1alpha1.Succeeded)
+	if c == nil || c.Status != corev1.ConditionFalse {
+		return "", false
+	}
+	return c.Reason, true
+}
+
+// onInterrupt tells on stderr what happened to the BuildRun once following it is interrupted, it's
+// either left running or, with --cancel-on-interrupt, canceled using a context of its own since the
+// command context is done already. Failing to cancel is reported as well.
+func (r *RunCommand) onInterrupt(params *params.Params, ioStreams *genericclioptions.IOStreams, name string) {
+	logger := params.Logger(ioStreams.ErrOut)
+	if !r.cancelOnInterrupt {
+		logger.Info(fmt.Sprintf("Stopped following BuildRun %q, which is left running, cancel it with \"shp buildrun cancel %s\"",
+			name, name))
+		return
+	}
+	clientset, err := params.ShipwrightClientSet()
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), cancelTimeout)
+		defer cancel()
+		err = util.CancelBuildRun(ctx, clientset, r.namespace, name)
+	}
+	if err != nil {
+		logger.Warning(fmt.Sprintf("unable to cancel BuildRun %q, which is left running: %s", name, err))
+		return
+	}
+	logger.Info(fmt.Sprintf("BuildRun %q canceled on interrupt", name))
+}
+
+// printTimings prints the time to create the BuildRun, to schedule its pod, the build time and the
+// total time, as a table on stderr. The unknown durations, i.e. of a pod which never started, are
+// shown as such.
+func (r *RunCommand) printTimings(
+	params *params.Params,
+	ioStreams *genericclioptions.IOStreams,
+	name string,
+	pod *corev1.Pod,
+	create time.Duration,
+	total time.Duration,
+) {
+	var creation, podStart, completion *metav1.Time
+	clientset, err := params.ShipwrightClientSet()
+	if err == nil {
+		var br *buildv1alpha1.BuildRun
+		// the command context may be done already, i.e. on timeout
+		if br, err = clientset.ShipwrightV1alpha1().BuildRuns(r.namespace).Get(context.Background(), name, metav1.GetOptions{}); err == nil {
+			creation, completion = &br.CreationTimestamp, br.Status.CompletionTime
+		}
+	}
+	if pod != nil {
+		podStart = pod.Status.StartTime
+	}
+
+	between := func(from, to *metav1.Time) string {
+		if from == nil || to == nil || from.IsZero() || to.IsZero() {
+			return "<unknown>"
+		}
+		return to.Sub(from.Time).String()
+	}
+	writer := tabwriter.NewWriter(ioStreams.ErrOut, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(writer, "PHASE\tDURATION")
+	fmt.Fprintf(writer, "create\t%s\n", create.Round(time.Millisecond))
+	fmt.Fprintf(writer, "schedule\t%s\n", between(creation, podStart))
+	fmt.Fprintf(writer, "build\t%s\n", between(podStart, completion))
+	fmt.Fprintf(writer, "total\t%s\n", total.Round(time.Millisecond))
+	_ = writer.Flush()
+}
+
+// embedBuildSpec replaces the BuildRun's reference to the Build by a copy of the Build's spec, with
+// the source overrides and the environment variables applied, warning about the differences. The
+// Build itself is not modified.
+func (r *RunCommand) embedBuildSpec(
+	params *params.Params,
+	ioStreams *genericclioptions.IOStreams,
+	br *buildv1alpha1.BuildRun,
+) error {
+	ctx := r.cmd.Context()
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	b, err := clientset.ShipwrightV1alpha1().Builds(r.namespace).Get(ctx, r.buildName, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
-	close(r.followerReady)
-	_, err = r.follower.WaitForCompletion()
+
+	if r.validate && r.source.Credentials != nil {
+		kclientset, err := params.ClientSet()
+		if err != nil {
+			return err
+		}
+		secretName := r.source.Credentials.Name
+		if _, err = kclientset.CoreV1().Secrets(r.namespace).Get(ctx, secretName, metav1.GetOptions{}); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return fmt.Errorf("clone secret %q not found in namespace %q, use --%s=false to skip this check",
+					secretName, r.namespace, validateFlag)
+			}
+			return err
+		}
+	}
+
+	buildSpec := b.Spec.DeepCopy()
+	if r.source.URL != nil {
+		buildSpec.Source.URL = r.source.URL
+	}
+	if r.source.Revision != nil {
+		buildSpec.Source.Revision = r.source.Revision
+	}
+	if r.source.Credentials != nil {
+		buildSpec.Source.Credentials = r.source.Credentials
+	}
+	// the bundle image replaces the git repository, keeping the Build's prune option, if any
+	if r.source.BundleContainer != nil {
+		bundle := &buildv1alpha1.BundleContainer{Image: r.source.BundleContainer.Image}
+		if b.Spec.Source.BundleContainer != nil {
+			bundle.Prune = b.Spec.Source.BundleContainer.Prune
+		}
+		buildSpec.Source.BundleContainer = bundle
+		buildSpec.Source.URL = nil
+		buildSpec.Source.Revision = nil
+	}
+
+	if diff := sourceDiff(&b.Spec.Source, &buildSpec.Source); len(diff) > 0 && !r.quiet {
+		params.Logger(ioStreams.ErrOut).Info(fmt.Sprintf("overriding source: %s", strings.Join(diff, ", ")))
+	}
+
+	if len(r.envRemoved) > 0 {
+		buildSpec.Env = flags.EffectiveEnv(b.Spec.Env, br.Spec.Env, r.envRemoved)
+		br.Spec.Env = nil
+		if !r.quiet {
+			params.Logger(ioStreams.ErrOut).Info(fmt.Sprintf("removing environment variables: %s", strings.Join(r.envRemoved, ", ")))
+		}
+	}
+
+	br.Spec.BuildSpec = buildSpec
+	br.Spec.BuildRef = nil
+	if br.Labels == nil {
+		br.Labels = map[string]string{}
+	}
+	br.Labels[buildv1alpha1.LabelBuild] = r.buildName
+	return nil
+}
+
+// verifyServiceAccount checks the service account informed on the BuildRun exists in the namespace,
+// unless it's generated for the build. Users not allowed to read service accounts skip the check.
+func (r *RunCommand) verifyServiceAccount(params *params.Params, br *buildv1alpha1.BuildRun) error {
+	sa := br.Spec.ServiceAccount
+	if sa == nil || sa.Name == nil || *sa.Name == "" || (sa.Generate != nil && *sa.Generate) {
+		return nil
+	}
+	clientset, err := params.ClientSet()
+	if err != nil {
+		return err
+	}
+	_, err = clientset.CoreV1().ServiceAccounts(r.namespace).Get(r.cmd.Context(), *sa.Name, metav1.GetOptions{})
+	switch {
+	case err == nil, k8serrors.IsForbidden(err):
+		return nil
+	case k8serrors.IsNotFound(err):
+		return fmt.Errorf("service account %q not found in namespace %q, use --%s=false to skip this check",
+			*sa.Name, r.namespace, validateFlag)
+	}
 	return err
 }
 
+// buildSpec returns the BuildSpec embedded on the BuildRun, or otherwise the Build's.
+func (r *RunCommand) buildSpec(params *params.Params, br *buildv1alpha1.BuildRun) (*buildv1alpha1.BuildSpec, error) {
+	if br.Spec.BuildSpec != nil {
+		return br.Spec.BuildSpec, nil
+	}
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return nil, err
+	}
+	b, err := clientset.ShipwrightV1alpha1().Builds(r.namespace).Get(r.cmd.Context(), r.buildName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &b.Spec, nil
+}
+
+// buildStrategy returns the strategy referenced by the BuildSpec, either namespaced or cluster
+// scoped.
+func (r *RunCommand) buildStrategy(params *params.Params, buildSpec *buildv1alpha1.BuildSpec) (buildv1alpha1.BuilderStrategy, error) {
+	ctx := r.cmd.Context()
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return nil, err
+	}
+	strategyName := buildSpec.Strategy.Name
+	if buildSpec.Strategy.Kind != nil && *buildSpec.Strategy.Kind == buildv1alpha1.ClusterBuildStrategyKind {
+		return clientset.ShipwrightV1alpha1().ClusterBuildStrategies().Get(ctx, strategyName, metav1.GetOptions{})
+	}
+	return clientset.ShipwrightV1alpha1().BuildStrategies(r.namespace).Get(ctx, strategyName, metav1.GetOptions{})
+}
+
+// verifyParamFile verifies the parameters read from the param file, and not informed otherwise,
+// against the ones the build strategy declares. Undeclared parameters are warned about, while a
+// single value informed for an array parameter, or the other way around, is an error. When the
+// strategy can't be read the verification is skipped.
+func (r *RunCommand) verifyParamFile(params *params.Params, ioStreams *genericclioptions.IOStreams, br *buildv1alpha1.BuildRun) error {
+	logger := params.Logger(ioStreams.ErrOut)
+	buildSpec, err := r.buildSpec(params, br)
+	var strategy buildv1alpha1.BuilderStrategy
+	if err == nil {
+		strategy, err = r.buildStrategy(params, buildSpec)
+	}
+	if err != nil {
+		logger.Warning(fmt.Sprintf("unable to verify the parameters informed on --%s: %s", flags.ParamFileFlag, err))
+		return nil
+	}
+	strategyName := buildSpec.Strategy.Name
+	declared := map[string]buildv1alpha1.Parameter{}
+	for _, p := range strategy.GetParameters() {
+		declared[p.Name] = p
+	}
+
+	for _, param := range r.paramFileValues {
+		// the parameters informed on command-line take precedence
+		if paramValue(r.buildRunSpec.ParamValues, param.Name) != nil {
+			continue
+		}
+		p, ok := declared[param.Name]
+		if !ok {
+			logger.Warning(fmt.Sprintf("parameter %q informed on --%s is not declared by build strategy %q",
+				param.Name, flags.ParamFileFlag, strategyName))
+			continue
+		}
+		isArray := p.Type == buildv1alpha1.ParameterTypeArray
+		switch {
+		case isArray && param.SingleValue != nil:
+			return fmt.Errorf("parameter %q informed on --%s is an array on build strategy %q, a list of values is expected",
+				param.Name, flags.ParamFileFlag, strategyName)
+		case !isArray && param.Values != nil:
+			return fmt.Errorf("parameter %q informed on --%s is a string on build strategy %q, a single value is expected",
+				param.Name, flags.ParamFileFlag, strategyName)
+		}
+	}
+	return nil
+}
+
+// paramValue returns the parameter with the informed name, or nil when not found.
+func paramValue(paramValues []buildv1alpha1.ParamValue, name string) *buildv1alpha1.ParamValue {
+	for i := range paramValues {
+		if paramValues[i].Name == name {
+			return &paramValues[i]
+		}
+	}
+	return nil
+}
+
+// setSkipPush sets the skip-push parameter on the BuildRun, as long as the Build's strategy declares
+// it, otherwise the image would be pushed regardless, and an error is returned instead.
+func (r *RunCommand) setSkipPush(params *params.Params, br *buildv1alpha1.BuildRun) error {
+	buildSpec, err := r.buildSpec(params, br)
+	if err != nil {
+		return err
+	}
+	strategyName := buildSpec.Strategy.Name
+	strategy, err := r.buildStrategy(params, buildSpec)
+	if err != nil {
+		return fmt.Errorf("unable to verify whether build strategy %q supports --%s: %w", strategyName, skipPushFlag, err)
+	}
+
+	supported := false
+	for _, p := range strategy.GetParameters() {
+		if p.Name == skipPushParam {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("build strategy %q does not declare the %q parameter, the image would be pushed; run without --%s to push it",
+			strategyName, skipPushParam, skipPushFlag)
+	}
+
+	value := "true"
+	for i, p := range br.Spec.ParamValues {
+		if p.Name == skipPushParam {
+			br.Spec.ParamValues[i].SingleValue = &buildv1alpha1.SingleValue{Value: &value}
+			return nil
+		}
+	}
+	br.Spec.ParamValues = append(br.Spec.ParamValues, buildv1alpha1.ParamValue{
+		Name:        skipPushParam,
+		SingleValue: &buildv1alpha1.SingleValue{Value: &value},
+	})
+	return nil
+}
+
+// sourceDiff describes the source attributes which differ between the Build and the effective
+// source, in the format "attribute before → after".
+func sourceDiff(build, effective *buildv1alpha1.Source) []string {
+	valueOf := func(s *string) string {
+		if s == nil || *s == "" {
+			return "<none>"
+		}
+		return *s
+	}
+	credentialsOf := func(c *corev1.LocalObjectReference) *string {
+		if c == nil {
+			return nil
+		}
+		return &c.Name
+	}
+	bundleOf := func(b *buildv1alpha1.BundleContainer) *string {
+		if b == nil {
+			return nil
+		}
+		return &b.Image
+	}
+
+	diff := []string{}
+	for _, attr := range []struct {
+		name          string
+		before, after *string
+	}{
+		{"url", build.URL, effective.URL},
+		{"revision", build.Revision, effective.Revision},
+		{"clone secret", credentialsOf(build.Credentials), credentialsOf(effective.Credentials)},
+		{"bundle image", bundleOf(build.BundleContainer), bundleOf(effective.BundleContainer)},
+	} {
+		if before, after := valueOf(attr.before), valueOf(attr.after); before != after {
+			diff = append(diff, fmt.Sprintf("%s %s → %s", attr.name, before, after))
+		}
+	}
+	return diff
+}
+
 // runCmd instantiate the "build run" sub-command using common BuildRun flags.
 func runCmd() runner.SubCommand {
 	cmd := &cobra.Command{
-		Use:   "run <name>",
+		Use:   "run <name> [<name>...]",
 		Short: "Start a build specified by 'name'",
 		Long:  buildRunLongDesc,
 	}
 	runCommand := &RunCommand{
 		cmd:          cmd,
 		buildRunSpec: flags.BuildRunSpecFromFlags(cmd.Flags()),
+		source:       flags.SourceOverrideFromFlags(cmd.Flags()),
+		metadata:     flags.MetadataFromFlags(cmd.Flags()),
 	}
+	flags.EnableEnvRemoval(cmd.Flags(), &runCommand.envRemoved)
 	flags.FollowFlag(cmd.Flags(), &runCommand.follow)
+	flags.ReconnectTailFlag(cmd.Flags(), &runCommand.reconnectTail)
+	cmd.Flags().BoolVar(
+		&runCommand.validate,
+		validateFlag,
+		true,
+		fmt.Sprintf("verify the secret informed on --%s and the service account informed on --%s exist",
+			flags.SourceGitCloneSecretFlag, flags.ServiceAccountNameFlag),
+	)
+	cmd.Flags().StringVar(
+		&runCommand.paramFile,
+		flags.ParamFileFlag,
+		"",
+		`YAML or JSON file of "name: value" parameter values, lists for array parameters, "-" for stdin; --param-value takes precedence`,
+	)
+	cmd.Flags().StringVar(
+		&runCommand.envFile,
+		flags.EnvFromFileFlag,
+		"",
+		`dotenv file of "NAME=value" environment variables, "-" for stdin; --env takes precedence`,
+	)
+	cmd.Flags().BoolVar(
+		&runCommand.skipPush,
+		skipPushFlag,
+		false,
+		fmt.Sprintf("build without pushing the output image, requires a build strategy declaring the %q parameter", skipPushParam),
+	)
+	cmd.Flags().DurationVar(
+		&runCommand.schedulerTimeout,
+		schedulerTimeoutFlag,
+		0,
+		"together with --follow, fail when the BuildRun pod is not running within the given duration, e.g. 2m, disabled by default",
+	)
+	cmd.Flags().StringVar(
+		&runCommand.namePrefix,
+		generateNamePrefixFlag,
+		"",
+		"prefix of the generated BuildRun name, e.g. nightly-, defaults to the Build name",
+	)
+	cmd.Flags().BoolVar(
+		&runCommand.timings,
+		timingsFlag,
+		false,
+		"together with --follow, print the time to create the BuildRun, schedule its pod, build, and the total time on stderr",
+	)
+	cmd.Flags().BoolVar(
+		&runCommand.cancelOnInterrupt,
+		cancelOnInterruptFlag,
+		false,
+		"together with --follow, cancel the BuildRun when interrupted, i.e. with Ctrl-C, rather than only stop following it",
+	)
+	cmd.Flags().IntVar(
+		&runCommand.retryOnFailure,
+		retryOnFailureFlag,
+		0,
+		"together with --follow, create the BuildRun again up to the given amount of times when it fails for an infrastructure reason, i.e. an evicted pod",
+	)
+	cmd.Flags().IntVar(
+		&runCommand.parallelism,
+		parallelismFlag,
+		0,
+		"together with --follow, maximum amount of builds followed at once when running several builds, all of them by default",
+	)
+	cmd.Flags().StringVar(
+		&runCommand.digestFile,
+		digestFileFlag,
+		"",
+		"together with --follow, write the reference by digest of the image pushed to the given file once the BuildRun succeeds",
+	)
+	cmd.Flags().StringVar(
+		&runCommand.resultFormat,
+		resultFormatFlag,
+		"",
+		fmt.Sprintf(`together with --follow, write the image and digest pushed once the BuildRun succeeds, either as "key=value" lines (%q) or as JSON (%q), appended to $%s when set, otherwise on stdout with the logs on stderr`,
+			resultFormatEnv, resultFormatJSON, githubOutputEnv),
+	)
+	cmd.Flags().BoolVar(
+		&runCommand.stampGit,
+		stampGitFlag,
+		false,
+		"annotate the BuildRun with the commit SHA, branch and dirty state of the git checkout in the current directory",
+	)
 	return runCommand
 }
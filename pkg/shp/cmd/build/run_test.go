@@ -2,23 +2,32 @@ package build
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/onsi/gomega"
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
 	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
 	"github.com/shipwright-io/cli/pkg/shp/reactor"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes/fake"
 	fakekubetesting "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
 )
 
 func TestStartBuildRunFollowLog(t *testing.T) {
@@ -81,7 +90,7 @@ func TestStartBuildRunFollowLog(t *testing.T) {
 		{
 			name:    "timeout",
 			to:      "1ms",
-			logText: reactor.RequestTimeoutMessage,
+			logText: reactor.WatchTimeoutMessage,
 		},
 		{
 			name:     "no pod yet",
@@ -90,7 +99,7 @@ func TestStartBuildRunFollowLog(t *testing.T) {
 		},
 	}
 
-	for i, test := range tests {
+	for _, test := range tests {
 		name := "testpod"
 		containerName := "container"
 		pod := &corev1.Pod{
@@ -143,11 +152,16 @@ func TestStartBuildRunFollowLog(t *testing.T) {
 		// set up context
 		cmd.Cmd().ExecuteC()
 		pm := genericclioptions.NewConfigFlags(true)
-		if len(test.to) > 0 {
-			pm.Timeout = &tests[i].to
-		}
 		failureDuration := 1 * time.Millisecond
 		param := params.NewParamsForTest(kclientset, shpclientset, pm, metav1.NamespaceDefault, &failureDuration, &failureDuration)
+		if len(test.to) > 0 {
+			// the watch is bound to the context timeout, not to the request timeout
+			flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			param.AddFlags(flagSet)
+			if err := flagSet.Set("context-timeout", test.to); err != nil {
+				t.Fatal(err)
+			}
+		}
 
 		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
 
@@ -225,3 +239,1024 @@ func checkLog(name, text string, cmd *RunCommand, out *bytes.Buffer, t *testing.
 		t.Errorf("test %s: unexpected output: %s", name, out.String())
 	}
 }
+
+func TestStartBuildRunSourceOverride(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tests := []struct {
+		name      string
+		args      []string
+		secret    bool
+		expectErr bool
+		labels    map[string]string
+		warning   string
+		expected  *buildv1alpha1.BuildSpec
+	}{
+		{
+			name:   "no override",
+			args:   []string{"--label=team=build"},
+			labels: map[string]string{"team": "build"},
+		},
+		{
+			name:   "override url and revision",
+			args:   []string{"--source-url=https://github.com/fork/repo", "--git-revision=feature", "--label=team=build"},
+			labels: map[string]string{"team": "build"},
+			warning: "overriding source: url https://github.com/shipwright-io/sample-go → https://github.com/fork/repo, " +
+				"revision <none> → feature\n",
+			expected: &buildv1alpha1.BuildSpec{Source: buildv1alpha1.Source{
+				URL:      pointer.String("https://github.com/fork/repo"),
+				Revision: pointer.String("feature"),
+			}},
+		},
+		{
+			name:    "override clone secret",
+			args:    []string{"--source-git-clone-secret=fork-credentials"},
+			secret:  true,
+			warning: "overriding source: clone secret <none> → fork-credentials\n",
+			expected: &buildv1alpha1.BuildSpec{Source: buildv1alpha1.Source{
+				URL:         pointer.String("https://github.com/shipwright-io/sample-go"),
+				Credentials: &corev1.LocalObjectReference{Name: "fork-credentials"},
+			}},
+		},
+		{
+			name:      "missing clone secret",
+			args:      []string{"--source-git-clone-secret=fork-credentials"},
+			expectErr: true,
+		},
+		{
+			name: "source bundle image",
+			args: []string{"--source-bundle-image=ghcr.io/org/app-source:abc123"},
+			warning: "overriding source: url https://github.com/shipwright-io/sample-go → <none>, " +
+				"bundle image <none> → ghcr.io/org/app-source:abc123\n",
+			expected: &buildv1alpha1.BuildSpec{Source: buildv1alpha1.Source{
+				BundleContainer: &buildv1alpha1.BundleContainer{Image: "ghcr.io/org/app-source:abc123"},
+			}},
+		},
+		{
+			name:    "missing clone secret without validation",
+			args:    []string{"--source-git-clone-secret=fork-credentials", "--validate=false"},
+			warning: "overriding source: clone secret <none> → fork-credentials\n",
+			expected: &buildv1alpha1.BuildSpec{Source: buildv1alpha1.Source{
+				URL:         pointer.String("https://github.com/shipwright-io/sample-go"),
+				Credentials: &corev1.LocalObjectReference{Name: "fork-credentials"},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(_ *testing.T) {
+			name := "build"
+			b := &buildv1alpha1.Build{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name},
+				Spec: buildv1alpha1.BuildSpec{Source: buildv1alpha1.Source{
+					URL: pointer.String("https://github.com/shipwright-io/sample-go"),
+				}},
+			}
+			shpclientset := shpfake.NewSimpleClientset(b)
+			var created *buildv1alpha1.BuildRun
+			shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+				created = action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+				return true, created, nil
+			})
+			kclientset := fake.NewSimpleClientset()
+			if test.secret {
+				kclientset = fake.NewSimpleClientset(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+					Name:      "fork-credentials",
+				}})
+			}
+
+			cmd := runCmd().(*RunCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Cmd().ParseFlags(test.args)).To(gomega.Succeed())
+
+			param := params.NewParamsForTest(kclientset, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			g.Expect(cmd.Complete(param, &ioStreams, []string{name})).To(gomega.Succeed())
+
+			err := cmd.Run(param, &ioStreams)
+			if test.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(created).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(created).ToNot(gomega.BeNil())
+			g.Expect(errOut.String()).To(gomega.Equal(test.warning))
+			for k, v := range test.labels {
+				g.Expect(created.GetLabels()).To(gomega.HaveKeyWithValue(k, v))
+			}
+
+			if test.expected == nil {
+				g.Expect(created.Spec.BuildSpec).To(gomega.BeNil())
+				g.Expect(created.Spec.BuildRef.Name).To(gomega.Equal(name))
+				return
+			}
+			g.Expect(created.Spec.BuildRef).To(gomega.BeNil())
+			g.Expect(created.Spec.BuildSpec).To(gomega.Equal(test.expected))
+			g.Expect(created.GetLabels()).To(gomega.HaveKeyWithValue(buildv1alpha1.LabelBuild, name))
+
+			// the Build must be left untouched
+			build, err := shpclientset.ShipwrightV1alpha1().Builds(metav1.NamespaceDefault).Get(context.Background(), name, metav1.GetOptions{})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(build.Spec).To(gomega.Equal(b.Spec))
+		})
+	}
+}
+
+func TestStartBuildRunSkipPush(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterKind := buildv1alpha1.ClusterBuildStrategyKind
+	strategy := func(name string, parameters ...string) *buildv1alpha1.ClusterBuildStrategy {
+		s := &buildv1alpha1.ClusterBuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for _, p := range parameters {
+			s.Spec.Parameters = append(s.Spec.Parameters, buildv1alpha1.Parameter{Name: p})
+		}
+		return s
+	}
+
+	tests := []struct {
+		name      string
+		strategy  string
+		args      []string
+		expectErr string
+	}{
+		{name: "strategy declaring skip-push", strategy: "buildah", args: []string{"--skip-push"}},
+		{name: "skip-push informed as param value", strategy: "buildah", args: []string{"--skip-push", "--param-value=skip-push=false"}},
+		{name: "strategy without skip-push", strategy: "kaniko", args: []string{"--skip-push"}, expectErr: `build strategy "kaniko" does not declare the "skip-push" parameter`},
+		{name: "strategy not found", strategy: "ko", args: []string{"--skip-push"}, expectErr: `unable to verify whether build strategy "ko" supports --skip-push`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(_ *testing.T) {
+			name := "build"
+			b := &buildv1alpha1.Build{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name},
+				Spec:       buildv1alpha1.BuildSpec{Strategy: buildv1alpha1.Strategy{Name: test.strategy, Kind: &clusterKind}},
+			}
+			shpclientset := shpfake.NewSimpleClientset(b, strategy("buildah", "skip-push"), strategy("kaniko", "dockerfile"))
+			var created *buildv1alpha1.BuildRun
+			shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+				created = action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+				return true, created, nil
+			})
+
+			cmd := runCmd().(*RunCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Cmd().ParseFlags(test.args)).To(gomega.Succeed())
+
+			param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			g.Expect(cmd.Complete(param, &ioStreams, []string{name})).To(gomega.Succeed())
+
+			err := cmd.Run(param, &ioStreams)
+			if test.expectErr != "" {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Error()).To(gomega.ContainSubstring(test.expectErr))
+				g.Expect(created).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(created.Spec.ParamValues).To(gomega.HaveLen(1))
+			g.Expect(created.Spec.ParamValues[0].Name).To(gomega.Equal("skip-push"))
+			g.Expect(*created.Spec.ParamValues[0].SingleValue.Value).To(gomega.Equal("true"))
+		})
+	}
+}
+
+func TestStartBuildRunEnvRemoval(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	name := "build"
+	b := &buildv1alpha1.Build{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name},
+		Spec: buildv1alpha1.BuildSpec{
+			Source: buildv1alpha1.Source{URL: pointer.String("https://github.com/shipwright-io/sample-go")},
+			Env:    []corev1.EnvVar{{Name: "PROXY", Value: "http://proxy"}, {Name: "LOG_LEVEL", Value: "info"}},
+		},
+	}
+	shpclientset := shpfake.NewSimpleClientset(b)
+	var created *buildv1alpha1.BuildRun
+	shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		created = action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+		return true, created, nil
+	})
+
+	cmd := runCmd().(*RunCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--env=PROXY-", "--env=LOG_LEVEL=debug", "--env=EXTRA=1"})).To(gomega.Succeed())
+
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+	ioStreams, _, _, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{name})).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+
+	g.Expect(errOut.String()).To(gomega.Equal("removing environment variables: PROXY\n"))
+	g.Expect(created.Spec.BuildRef).To(gomega.BeNil())
+	g.Expect(created.Spec.Env).To(gomega.BeEmpty())
+	g.Expect(created.Spec.BuildSpec.Env).To(gomega.Equal([]corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "EXTRA", Value: "1"},
+	}))
+
+	// the Build must be left untouched
+	build, err := shpclientset.ShipwrightV1alpha1().Builds(metav1.NamespaceDefault).Get(context.Background(), name, metav1.GetOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(build.Spec).To(gomega.Equal(b.Spec))
+}
+
+func TestStartBuildRunGenerateNamePrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		generateName string
+		expectErr    string
+	}{
+		{name: "defaults to the build name", generateName: "build-"},
+		{name: "custom prefix", args: []string{"--generate-name-prefix=nightly-"}, generateName: "nightly-"},
+		{name: "prefix without dash", args: []string{"--generate-name-prefix=nightly"}, generateName: "nightly"},
+		{name: "uppercase prefix", args: []string{"--generate-name-prefix=Nightly-"}, expectErr: `invalid --generate-name-prefix "Nightly-"`},
+		{name: "empty prefix", args: []string{"--generate-name-prefix="}, expectErr: `invalid --generate-name-prefix ""`},
+		{name: "prefix too long", args: []string{"--generate-name-prefix=" + strings.Repeat("a", 59)}, expectErr: "must be no more than 63 characters"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			shpclientset := shpfake.NewSimpleClientset()
+			var created *buildv1alpha1.BuildRun
+			shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+				created = action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+				return true, created, nil
+			})
+
+			cmd := runCmd().(*RunCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Cmd().ParseFlags(test.args)).To(gomega.Succeed())
+
+			param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+
+			err := cmd.Validate()
+			if test.expectErr != "" {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Error()).To(gomega.ContainSubstring(test.expectErr))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+			g.Expect(created.GenerateName).To(gomega.Equal(test.generateName))
+		})
+	}
+}
+
+func TestStartBuildRunServiceAccount(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		expected  *buildv1alpha1.ServiceAccount
+		expectErr string
+	}{
+		{name: "no service account"},
+		{
+			name:     "existing service account",
+			args:     []string{"--service-account=registry-pusher"},
+			expected: &buildv1alpha1.ServiceAccount{Name: pointer.String("registry-pusher"), Generate: pointer.Bool(false)},
+		},
+		{
+			name:      "missing service account",
+			args:      []string{"--sa-name=missing"},
+			expectErr: `service account "missing" not found in namespace "default", use --validate=false to skip this check`,
+		},
+		{
+			name:     "missing service account without validation",
+			args:     []string{"--sa-name=missing", "--validate=false"},
+			expected: &buildv1alpha1.ServiceAccount{Name: pointer.String("missing"), Generate: pointer.Bool(false)},
+		},
+		{
+			name:     "service account not allowed to be read",
+			args:     []string{"--sa-name=forbidden"},
+			expected: &buildv1alpha1.ServiceAccount{Name: pointer.String("forbidden"), Generate: pointer.Bool(false)},
+		},
+		{
+			name:     "generated service account",
+			args:     []string{"--service-account-generate"},
+			expected: &buildv1alpha1.ServiceAccount{Name: pointer.String(""), Generate: pointer.Bool(true)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			shpclientset := shpfake.NewSimpleClientset()
+			var created *buildv1alpha1.BuildRun
+			shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+				created = action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+				return true, created, nil
+			})
+			kclientset := fake.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "registry-pusher",
+			}})
+			kclientset.PrependReactor("get", "serviceaccounts", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+				if name := action.(fakekubetesting.GetAction).GetName(); name == "forbidden" {
+					return true, nil, k8serrors.NewForbidden(corev1.Resource("serviceaccounts"), name, nil)
+				}
+				return false, nil, nil
+			})
+
+			cmd := runCmd().(*RunCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Cmd().ParseFlags(test.args)).To(gomega.Succeed())
+
+			param := params.NewParamsForTest(kclientset, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+			g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+			err := cmd.Run(param, &ioStreams)
+			if test.expectErr != "" {
+				g.Expect(err).To(gomega.MatchError(test.expectErr))
+				g.Expect(created).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(created.Spec.ServiceAccount).To(gomega.Equal(test.expected))
+		})
+	}
+}
+
+func TestStartBuildRunFetchesBuildOnlyWhenNeeded(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		fetchesIt bool
+	}{
+		{name: "build name only"},
+		{name: "buildrun overrides", args: []string{"--env=LOG_LEVEL=debug", "--output-image=registry/app:dev"}},
+		{name: "source override", args: []string{"--source-url=https://github.com/fork/sample-go"}, fetchesIt: true},
+		{name: "environment variable removal", args: []string{"--env=PROXY-"}, fetchesIt: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			shpclientset := shpfake.NewSimpleClientset(&buildv1alpha1.Build{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "build"},
+			})
+			shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+				return true, action.(fakekubetesting.CreateAction).GetObject(), nil
+			})
+
+			cmd := runCmd().(*RunCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Cmd().ParseFlags(append(test.args, "--validate=false"))).To(gomega.Succeed())
+
+			param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+			g.Expect(cmd.Validate()).To(gomega.Succeed())
+			g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+
+			fetched := false
+			for _, action := range shpclientset.Actions() {
+				if action.Matches("get", "builds") {
+					fetched = true
+				}
+			}
+			g.Expect(fetched).To(gomega.Equal(test.fetchesIt))
+		})
+	}
+}
+
+func TestStartBuildRunSourceBundleImageValidation(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expectErr string
+	}{
+		{args: []string{"--source-bundle-image=ghcr.io/org/app-source:abc123", "--source-git-clone-secret=registry"}},
+		{args: []string{"--source-bundle-image=ghcr.io/org/App-source"}, expectErr: `invalid --source-bundle-image "ghcr.io/org/App-source"`},
+		{args: []string{"--source-bundle-image=ghcr.io/org/app-source", "--source-url=https://github.com/fork/repo"}, expectErr: "--source-url can't be used together with --source-bundle-image"},
+		{args: []string{"--source-bundle-image=ghcr.io/org/app-source", "--git-revision=main"}, expectErr: "--git-revision can't be used together with --source-bundle-image"},
+	}
+
+	for _, test := range tests {
+		g := gomega.NewWithT(t)
+
+		cmd := runCmd().(*RunCommand)
+		g.Expect(cmd.Cmd().ParseFlags(test.args)).To(gomega.Succeed())
+		cmd.buildName = "build"
+
+		err := cmd.Validate()
+		if test.expectErr == "" {
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			continue
+		}
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(err.Error()).To(gomega.ContainSubstring(test.expectErr))
+	}
+}
+
+func TestStartBuildRunTimings(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	created := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	br := &buildv1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{
+		Namespace:         metav1.NamespaceDefault,
+		Name:              "build-abcde",
+		CreationTimestamp: metav1.NewTime(created),
+	}}
+	br.Status.CompletionTime = &metav1.Time{Time: created.Add(2 * time.Minute)}
+	pod := &corev1.Pod{}
+	pod.Status.StartTime = &metav1.Time{Time: created.Add(15 * time.Second)}
+
+	cmd := runCmd().(*RunCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--timings"})).To(gomega.Succeed())
+	param := params.NewParamsForTest(nil, shpfake.NewSimpleClientset(br), nil, metav1.NamespaceDefault, nil, nil)
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError("--timings can only be used together with --follow"))
+
+	cmd.printTimings(param, &ioStreams, br.Name, pod, 120*time.Millisecond, 2*time.Minute+30*time.Second)
+	g.Expect(out.String()).To(gomega.BeEmpty())
+	g.Expect(errOut.String()).To(gomega.Equal(`PHASE     DURATION
+create    120ms
+schedule  15s
+build     1m45s
+total     2m30s
+`))
+
+	// the pod never started
+	ioStreams, _, _, errOut = genericclioptions.NewTestIOStreams()
+	cmd.printTimings(param, &ioStreams, br.Name, nil, time.Second, time.Minute)
+	g.Expect(errOut.String()).To(gomega.ContainSubstring("schedule  <unknown>\nbuild     <unknown>\n"))
+}
+
+func TestStartBuildRunQuiet(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	shpclientset := shpfake.NewSimpleClientset(&buildv1alpha1.Build{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "build"},
+		Spec:       buildv1alpha1.BuildSpec{Env: []corev1.EnvVar{{Name: "PROXY", Value: "proxy:3128"}}},
+	})
+	shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		br := action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+		br.Name = br.GenerateName + "abcde"
+		return true, br, nil
+	})
+
+	// --quiet is a global flag, inherited from the root command
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, genericclioptions.NewConfigFlags(true), metav1.NamespaceDefault, nil, nil)
+	cmd := runCmd().(*RunCommand)
+	cmd.Cmd().SetContext(context.Background())
+	param.AddFlags(cmd.Cmd().PersistentFlags())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"-q", "--env=PROXY-"})).To(gomega.Succeed())
+
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.Equal("build-abcde\n"))
+	g.Expect(errOut.String()).To(gomega.BeEmpty())
+}
+
+func TestStartBuildRunFollowQuiet(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	shpclientset := shpfake.NewSimpleClientset()
+	shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		br := action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+		br.Name = br.GenerateName + "abcde"
+		return false, br, nil
+	})
+	kclientset := fake.NewSimpleClientset(succeededBuildPod("build", "build-abcde"))
+
+	param := params.NewParamsForTest(kclientset, shpclientset, genericclioptions.NewConfigFlags(true), metav1.NamespaceDefault, nil, nil)
+	cmd := runCmd().(*RunCommand)
+	cmd.Cmd().SetContext(context.Background())
+	param.AddFlags(cmd.Cmd().PersistentFlags())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--follow", "-q"})).To(gomega.Succeed())
+
+	// the BuildRun name is printed ahead of the logs
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.HavePrefix("build-abcde\n"))
+	g.Expect(out.String()).To(gomega.ContainSubstring("fake logs"))
+}
+
+func TestStartBuildRunCancelOnInterrupt(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	br := &buildv1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "build-abcde"}}
+	shpclientset := shpfake.NewSimpleClientset(br)
+	patched := ""
+	shpclientset.PrependReactor("patch", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		patched = string(action.(fakekubetesting.PatchAction).GetPatch())
+		return true, br, nil
+	})
+
+	cmd := runCmd().(*RunCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--cancel-on-interrupt"})).To(gomega.Succeed())
+	param := params.NewParamsForTest(nil, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError("--cancel-on-interrupt can only be used together with --follow"))
+
+	cmd.onInterrupt(param, &ioStreams, br.Name)
+	g.Expect(patched).To(gomega.Equal(`[{"op":"replace","path":"/spec/state","value":"BuildRunCanceled"}]`))
+	g.Expect(out.String()).To(gomega.BeEmpty())
+	g.Expect(errOut.String()).To(gomega.ContainSubstring(`BuildRun "build-abcde" canceled on interrupt`))
+
+	// by default the BuildRun is left running, and the command tells so
+	patched = ""
+	cmd.cancelOnInterrupt = false
+	ioStreams, _, _, errOut = genericclioptions.NewTestIOStreams()
+	cmd.onInterrupt(param, &ioStreams, br.Name)
+	g.Expect(patched).To(gomega.BeEmpty())
+	g.Expect(errOut.String()).To(gomega.ContainSubstring(`BuildRun "build-abcde", which is left running`))
+}
+
+func TestStartBuildRunRetryOnFailure(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	br := &buildv1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "build-abcde"}}
+	shpclientset := shpfake.NewSimpleClientset()
+	shpclientset.PrependReactor("get", "buildruns", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+		return true, br, nil
+	})
+
+	cmd := runCmd().(*RunCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--retry-on-failure=2"})).To(gomega.Succeed())
+	pollDuration := time.Millisecond
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, &pollDuration, &pollDuration)
+	ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError("--retry-on-failure can only be used together with --follow"))
+
+	cmd.follow = true
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	cmd.follower.SetBuildRunName(types.NamespacedName{Namespace: br.Namespace, Name: br.Name})
+
+	setCondition := func(status corev1.ConditionStatus, reason string) {
+		br.Status.Conditions = []buildv1alpha1.Condition{{Type: buildv1alpha1.Succeeded, Status: status, Reason: reason}}
+	}
+	tests := []struct {
+		name   string
+		status corev1.ConditionStatus
+		reason string
+		retry  int
+		want   bool
+		output string
+	}{
+		{"infrastructure failure", corev1.ConditionFalse, buildv1alpha1.BuildRunStatePodEvicted, 1, true,
+			`BuildRun "build-abcde" failed with reason "PodEvicted", retrying`},
+		{"no retries left", corev1.ConditionFalse, buildv1alpha1.BuildRunStatePodEvicted, 3, false,
+			`BuildRun "build-abcde" failed with reason "PodEvicted", no retries left`},
+		{"build failure", corev1.ConditionFalse, "Failed", 1, false,
+			`BuildRun "build-abcde" failed with reason "Failed", not retried since it's not an infrastructure failure`},
+		{"succeeded", corev1.ConditionTrue, "Succeeded", 1, false, `BuildRun "build-abcde" succeeded`},
+	}
+	for _, test := range tests {
+		setCondition(test.status, test.reason)
+		ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.retry(param, &ioStreams, br.Name, test.retry)).To(gomega.Equal(test.want), test.name)
+		g.Expect(out.String()).To(gomega.BeEmpty(), test.name)
+		g.Expect(errOut.String()).To(gomega.ContainSubstring(test.output), test.name)
+	}
+
+	// without the flag the BuildRun is never retried
+	cmd.retryOnFailure = 0
+	setCondition(corev1.ConditionFalse, buildv1alpha1.BuildRunStatePodEvicted)
+	ioStreams, _, _, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.retry(param, &ioStreams, br.Name, 1)).To(gomega.BeFalse())
+	g.Expect(errOut.String()).To(gomega.BeEmpty())
+}
+
+// succeededBuildPod returns the pod of the BuildRun, already succeeded. The follower finds it by
+// listing the pods, when no pod events are observed.
+func succeededBuildPod(build, buildRun string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      buildRun + "-pod",
+			Labels:    map[string]string{buildv1alpha1.LabelBuild: build, buildv1alpha1.LabelBuildRun: buildRun},
+		},
+		Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "step-build"}}},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+}
+
+func TestStartBuildRunRetryFollowsEachBuildRun(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// the first BuildRun is evicted, the retry succeeds
+	conditions := map[string]buildv1alpha1.Condition{
+		"build-1": {Type: buildv1alpha1.Succeeded, Status: corev1.ConditionFalse, Reason: buildv1alpha1.BuildRunStatePodEvicted},
+		"build-2": {Type: buildv1alpha1.Succeeded, Status: corev1.ConditionTrue, Reason: "Succeeded"},
+	}
+	shpclientset := shpfake.NewSimpleClientset()
+	created := 0
+	shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		br := action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+		created++
+		br.Name = fmt.Sprintf("%s%d", br.GenerateName, created)
+		return true, br, nil
+	})
+	shpclientset.PrependReactor("get", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		name := action.(fakekubetesting.GetAction).GetName()
+		return true, &buildv1alpha1.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name},
+			Status:     buildv1alpha1.BuildRunStatus{Conditions: buildv1alpha1.Conditions{conditions[name]}},
+		}, nil
+	})
+	kclientset := fake.NewSimpleClientset(succeededBuildPod("build", "build-1"), succeededBuildPod("build", "build-2"))
+
+	cmd := runCmd().(*RunCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--follow", "--retry-on-failure=1"})).To(gomega.Succeed())
+	pollDuration := time.Millisecond
+	param := params.NewParamsForTest(kclientset, shpclientset, nil, metav1.NamespaceDefault, &pollDuration, &pollDuration)
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+
+	// each BuildRun is followed until its pod is done, the retry on a watcher of its own
+	g.Expect(created).To(gomega.Equal(2))
+	g.Expect(out.String()).To(gomega.ContainSubstring(`Pod "build-1-pod" has succeeded!`))
+	g.Expect(out.String()).To(gomega.ContainSubstring(`Pod "build-2-pod" has succeeded!`))
+	g.Expect(errOut.String()).To(gomega.ContainSubstring(`BuildRun created "build-2" for build "build", retry 1/1`))
+	g.Expect(errOut.String()).To(gomega.ContainSubstring(`BuildRun "build-2" succeeded`))
+}
+
+func TestStartBuildRunDigestFile(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	br := &buildv1alpha1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "build-abcde"},
+		Status: buildv1alpha1.BuildRunStatus{
+			Conditions: []buildv1alpha1.Condition{{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionTrue}},
+			BuildSpec:  &buildv1alpha1.BuildSpec{Output: buildv1alpha1.Image{Image: "registry.example.com/org/app:latest"}},
+		},
+	}
+	shpclientset := shpfake.NewSimpleClientset()
+	shpclientset.PrependReactor("get", "buildruns", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+		return true, br, nil
+	})
+
+	digestFile := filepath.Join(t.TempDir(), "image-digest")
+	cmd := runCmd().(*RunCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--digest-file", digestFile})).To(gomega.Succeed())
+	pollDuration := time.Millisecond
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, &pollDuration, &pollDuration)
+	ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError("--digest-file can only be used together with --follow"))
+
+	cmd.follow = true
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	cmd.follower.SetBuildRunName(types.NamespacedName{Namespace: br.Namespace, Name: br.Name})
+
+	// the digest is not reported
+	g.Expect(cmd.writeDigestFile(br.Name)).To(gomega.MatchError(
+		`unable to write the image digest on --digest-file: BuildRun "build-abcde" does not report the image digest`))
+	g.Expect(digestFile).ToNot(gomega.BeAnExistingFile())
+
+	// the file is truncated, and the tag replaced by the digest
+	g.Expect(os.WriteFile(digestFile, []byte("previous content, longer than the reference by digest"), 0600)).To(gomega.Succeed())
+	digest := "sha256:" + strings.Repeat("a", 64)
+	br.Status.Output = &buildv1alpha1.Output{Digest: digest}
+	g.Expect(cmd.writeDigestFile(br.Name)).To(gomega.Succeed())
+	g.Expect(os.ReadFile(digestFile)).To(gomega.Equal([]byte("registry.example.com/org/app@" + digest)))
+
+	// the BuildRun output takes precedence over the Build's
+	br.Spec.Output = &buildv1alpha1.Image{Image: "registry.example.com/org/fork"}
+	g.Expect(cmd.writeDigestFile(br.Name)).To(gomega.Succeed())
+	g.Expect(os.ReadFile(digestFile)).To(gomega.Equal([]byte("registry.example.com/org/fork@" + digest)))
+
+	br.Status.Conditions[0].Status = corev1.ConditionFalse
+	g.Expect(cmd.writeDigestFile(br.Name)).To(gomega.MatchError(
+		`BuildRun "build-abcde" has not succeeded, no image digest to write on --digest-file`))
+}
+
+func TestStartBuildRunResultFormat(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	br := &buildv1alpha1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "build-abcde"},
+		Status: buildv1alpha1.BuildRunStatus{
+			Conditions: []buildv1alpha1.Condition{{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionTrue}},
+			BuildSpec:  &buildv1alpha1.BuildSpec{Output: buildv1alpha1.Image{Image: "registry.example.com/org/app:latest"}},
+			Output:     &buildv1alpha1.Output{Digest: digest},
+		},
+	}
+	shpclientset := shpfake.NewSimpleClientset()
+	shpclientset.PrependReactor("get", "buildruns", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+		return true, br, nil
+	})
+	pollDuration := time.Millisecond
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, &pollDuration, &pollDuration)
+
+	newCmd := func(args ...string) *RunCommand {
+		cmd := runCmd().(*RunCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().ParseFlags(args)).To(gomega.Succeed())
+		ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+		return cmd
+	}
+
+	g.Expect(newCmd("--result-format=env").Validate()).To(gomega.MatchError("--result-format can only be used together with --follow"))
+	g.Expect(newCmd("--result-format=yaml", "--follow").Validate()).To(gomega.MatchError(`invalid --result-format "yaml", use either "env" or "json"`))
+
+	cmd := newCmd("--result-format=env", "--follow")
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	cmd.follower.SetBuildRunName(types.NamespacedName{Namespace: br.Namespace, Name: br.Name})
+
+	// written on stdout without GITHUB_OUTPUT
+	t.Setenv(githubOutputEnv, "")
+	out := &bytes.Buffer{}
+	g.Expect(cmd.writeResult(out)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.Equal("buildrun=build-abcde\nimage=registry.example.com/org/app:latest\ndigest=" + digest + "\n"))
+
+	// appended to the GITHUB_OUTPUT file when set
+	githubOutput := filepath.Join(t.TempDir(), "github-output")
+	g.Expect(os.WriteFile(githubOutput, []byte("previous=output\n"), 0600)).To(gomega.Succeed())
+	t.Setenv(githubOutputEnv, githubOutput)
+	out.Reset()
+	g.Expect(cmd.writeResult(out)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.BeEmpty())
+	g.Expect(os.ReadFile(githubOutput)).To(gomega.Equal([]byte(
+		"previous=output\nbuildrun=build-abcde\nimage=registry.example.com/org/app:latest\ndigest=" + digest + "\n")))
+
+	// the JSON object is appended as a single line as well
+	cmd.resultFormat = resultFormatJSON
+	g.Expect(os.WriteFile(githubOutput, nil, 0600)).To(gomega.Succeed())
+	g.Expect(cmd.writeResult(out)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.BeEmpty())
+	g.Expect(os.ReadFile(githubOutput)).To(gomega.Equal([]byte(
+		`result={"buildrun":"build-abcde","image":"registry.example.com/org/app:latest","digest":"` + digest + "\"}\n")))
+
+	// written on stdout without GITHUB_OUTPUT, the logs and messages going to stderr
+	t.Setenv(githubOutputEnv, "")
+	ioStreams, _, stdout, stderr := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.logStreams(&ioStreams).Out).To(gomega.Equal(stderr))
+	g.Expect(cmd.writeResult(stdout)).To(gomega.Succeed())
+	g.Expect(stdout.String()).To(gomega.MatchJSON(`{"buildrun":"build-abcde","image":"registry.example.com/org/app:latest","digest":"` + digest + `"}`))
+
+	br.Status.Output = nil
+	g.Expect(cmd.writeResult(out)).To(gomega.MatchError(
+		`unable to write the result on --result-format: BuildRun "build-abcde" does not report the image digest`))
+
+	br.Status.Conditions[0].Status = corev1.ConditionFalse
+	g.Expect(cmd.writeResult(out)).To(gomega.MatchError(
+		`BuildRun "build-abcde" has not succeeded, no result to write on --result-format`))
+}
+
+func TestStartBuildRunResultFormatOnStdout(t *testing.T) {
+	g := gomega.NewWithT(t)
+	t.Setenv(githubOutputEnv, "")
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	shpclientset := shpfake.NewSimpleClientset()
+	shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		br := action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+		br.Name = br.GenerateName + "abcde"
+		return true, br, nil
+	})
+	shpclientset.PrependReactor("get", "buildruns", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+		return true, &buildv1alpha1.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "build-abcde"},
+			Spec:       buildv1alpha1.BuildRunSpec{Output: &buildv1alpha1.Image{Image: "registry/app"}},
+			Status: buildv1alpha1.BuildRunStatus{
+				Conditions: []buildv1alpha1.Condition{{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionTrue}},
+				Output:     &buildv1alpha1.Output{Digest: digest},
+			},
+		}, nil
+	})
+	kclientset := fake.NewSimpleClientset(succeededBuildPod("build", "build-abcde"))
+
+	cmd := runCmd().(*RunCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--follow", "--result-format=json"})).To(gomega.Succeed())
+	pollDuration := time.Millisecond
+	param := params.NewParamsForTest(kclientset, shpclientset, nil, metav1.NamespaceDefault, &pollDuration, &pollDuration)
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"build"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+
+	// only the result is written on stdout, to be captured
+	g.Expect(out.String()).To(gomega.MatchJSON(`{"buildrun":"build-abcde","image":"registry/app","digest":"` + digest + `"}`))
+	g.Expect(errOut.String()).To(gomega.ContainSubstring("fake logs"))
+	g.Expect(errOut.String()).To(gomega.ContainSubstring(`Pod "build-abcde-pod" has succeeded!`))
+}
+
+func TestStartBuildRunMultipleBuilds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	newCommand := func(args ...string) *RunCommand {
+		cmd := runCmd().(*RunCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().ParseFlags(args)).To(gomega.Succeed())
+		return cmd
+	}
+	shpclientset := shpfake.NewSimpleClientset()
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	for _, test := range []struct {
+		args      []string
+		builds    []string
+		expectErr string
+	}{
+		{builds: []string{"frontend", "backend", "frontend"}, expectErr: `build "frontend" is informed more than once`},
+		{builds: []string{"frontend", ""}, expectErr: "name is not informed"},
+		{args: []string{"--follow", "--digest-file=digest"}, builds: []string{"frontend", "backend"},
+			expectErr: "--digest-file can only be used when running a single build"},
+		{args: []string{"--parallelism=2"}, builds: []string{"frontend", "backend"},
+			expectErr: "--parallelism can only be used together with --follow"},
+		{args: []string{"--follow", "--parallelism=-1"}, builds: []string{"frontend", "backend"},
+			expectErr: "--parallelism must not be negative"},
+	} {
+		cmd := newCommand(test.args...)
+		ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.Complete(param, &ioStreams, test.builds)).To(gomega.Succeed())
+		g.Expect(cmd.Validate()).To(gomega.MatchError(test.expectErr))
+	}
+
+	// each BuildRun references its own build
+	created := []string{}
+	shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		br := action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+		br.Name = br.GenerateName + "abcde"
+		created = append(created, br.Spec.BuildRef.Name)
+		return true, br, nil
+	})
+	cmd := newCommand()
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"frontend", "backend"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(created).To(gomega.Equal([]string{"frontend", "backend"}))
+	g.Expect(out.String()).To(gomega.Equal("BuildRun created \"frontend-abcde\" for build \"frontend\"\n" +
+		"BuildRun created \"backend-abcde\" for build \"backend\"\n"))
+
+	// following, the output is prefixed by the build name, and summarized once all are done
+	shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		br := action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+		return true, nil, fmt.Errorf("build %q is not registered", br.Spec.BuildRef.Name)
+	})
+	cmd = newCommand("--follow", "--parallelism=1", "--output-image=registry/app")
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"frontend", "api"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.MatchError("2 of 2 builds failed"))
+	g.Expect(errOut.String()).To(gomega.ContainSubstring("frontend | --output-image"))
+	g.Expect(errOut.String()).To(gomega.ContainSubstring("api      | --output-image"))
+	g.Expect(out.String()).To(gomega.MatchRegexp(`BUILD +BUILDRUN +RESULT +MESSAGE\n`))
+	g.Expect(out.String()).To(gomega.MatchRegexp(`frontend +<none> +Failed +build "frontend" is not registered\n`))
+	g.Expect(out.String()).To(gomega.MatchRegexp(`api +<none> +Failed +build "api" is not registered\n`))
+}
+
+func TestStartBuildRunMultipleBuildsFollow(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	shpclientset := shpfake.NewSimpleClientset()
+	shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		br := action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+		br.Name = br.GenerateName + "abcde"
+		return false, br, nil
+	})
+	kclientset := fake.NewSimpleClientset(
+		succeededBuildPod("frontend", "frontend-abcde"),
+		succeededBuildPod("backend", "backend-abcde"),
+	)
+	param := params.NewParamsForTest(kclientset, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	// the builds are followed concurrently, each one until its own pod is done
+	cmd := runCmd().(*RunCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--follow"})).To(gomega.Succeed())
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"frontend", "backend"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.ContainSubstring(`frontend | Pod "frontend-abcde-pod" has succeeded!`))
+	g.Expect(out.String()).To(gomega.ContainSubstring(`backend  | Pod "backend-abcde-pod" has succeeded!`))
+	g.Expect(out.String()).NotTo(gomega.MatchRegexp(`frontend \| .*backend-abcde-pod`))
+	g.Expect(out.String()).NotTo(gomega.MatchRegexp(`backend  \| .*frontend-abcde-pod`))
+	g.Expect(out.String()).To(gomega.MatchRegexp(`frontend +frontend-abcde +Succeeded +\n`))
+	g.Expect(out.String()).To(gomega.MatchRegexp(`backend +backend-abcde +Succeeded +\n`))
+}
+
+func TestStartBuildRunParamFile(t *testing.T) {
+	clusterKind := buildv1alpha1.ClusterBuildStrategyKind
+	b := &buildv1alpha1.Build{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "build"},
+		Spec:       buildv1alpha1.BuildSpec{Strategy: buildv1alpha1.Strategy{Name: "buildah", Kind: &clusterKind}},
+	}
+	strategy := &buildv1alpha1.ClusterBuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: "buildah"}}
+	strategy.Spec.Parameters = []buildv1alpha1.Parameter{
+		{Name: "dockerfile"},
+		{Name: "build-args", Type: buildv1alpha1.ParameterTypeArray},
+	}
+
+	tests := []struct {
+		name       string
+		paramFile  string
+		args       []string
+		objects    []kruntime.Object
+		expected   map[string]string
+		expectWarn string
+		expectErr  string
+	}{{
+		name:      "merged with param values",
+		paramFile: "dockerfile: Containerfile\nbuild-args: [VERSION=1.0]\n",
+		args:      []string{"--param-value=verbose=true"},
+		objects:   []kruntime.Object{b, strategy},
+		expected:  map[string]string{"verbose": "true", "dockerfile": "Containerfile", "build-args": "[VERSION=1.0]"},
+	}, {
+		name:      "param values take precedence",
+		paramFile: "dockerfile: Containerfile\n",
+		args:      []string{"--param-value=dockerfile=Dockerfile"},
+		objects:   []kruntime.Object{b, strategy},
+		expected:  map[string]string{"dockerfile": "Dockerfile"},
+	}, {
+		name:       "undeclared parameter",
+		paramFile:  "context: src\n",
+		objects:    []kruntime.Object{b, strategy},
+		expected:   map[string]string{"context": "src"},
+		expectWarn: `parameter "context" informed on --param-file is not declared by build strategy "buildah"`,
+	}, {
+		name:      "single value on array parameter",
+		paramFile: "build-args: VERSION=1.0\n",
+		objects:   []kruntime.Object{b, strategy},
+		expectErr: `parameter "build-args" informed on --param-file is an array on build strategy "buildah", a list of values is expected`,
+	}, {
+		name:       "strategy not found",
+		paramFile:  "dockerfile: Containerfile\n",
+		objects:    []kruntime.Object{b},
+		expected:   map[string]string{"dockerfile": "Containerfile"},
+		expectWarn: "unable to verify the parameters informed on --param-file",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			shpclientset := shpfake.NewSimpleClientset(test.objects...)
+			var created *buildv1alpha1.BuildRun
+			shpclientset.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+				created = action.(fakekubetesting.CreateAction).GetObject().(*buildv1alpha1.BuildRun)
+				return true, created, nil
+			})
+
+			cmd := runCmd().(*RunCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Cmd().ParseFlags(append(test.args, "--param-file=-"))).To(gomega.Succeed())
+
+			param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, in, _, errOut := genericclioptions.NewTestIOStreams()
+			in.WriteString(test.paramFile)
+			g.Expect(cmd.Complete(param, &ioStreams, []string{b.Name})).To(gomega.Succeed())
+			g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+			err := cmd.Run(param, &ioStreams)
+			if test.expectErr != "" {
+				g.Expect(err).To(gomega.MatchError(test.expectErr))
+				g.Expect(created).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(errOut.String()).To(gomega.ContainSubstring(test.expectWarn))
+
+			values := map[string]string{}
+			for _, p := range created.Spec.ParamValues {
+				if p.SingleValue != nil {
+					values[p.Name] = *p.SingleValue.Value
+					continue
+				}
+				items := []string{}
+				for _, v := range p.Values {
+					items = append(items, *v.Value)
+				}
+				values[p.Name] = fmt.Sprintf("[%s]", strings.Join(items, ","))
+			}
+			g.Expect(values).To(gomega.Equal(test.expected))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package build
+
+import (
+	"fmt"
+	"os"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/pflag"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// sourceGitUsernameFlag command-line flag, username of the basic-auth source secret.
+	sourceGitUsernameFlag = "source-git-username"
+	// sourceGitPasswordFlag command-line flag, password or token of the basic-auth source secret.
+	sourceGitPasswordFlag = "source-git-password" // #nosec G101
+	// sourceGitSSHKeyFlag command-line flag, private key file of the SSH source secret.
+	sourceGitSSHKeyFlag = "source-git-ssh-key"
+	// sourceSecretSuffix suffix of the source secret name generated out of the Build name.
+	sourceSecretSuffix = "-git-credentials" // #nosec G101
+)
+
+// sourceSecretOptions the git credentials the source secret is created with, either basic-auth or
+// SSH. The values are never printed.
+type sourceSecretOptions struct {
+	username   string // basic-auth username
+	password   string // basic-auth password or token
+	sshKeyFile string // SSH private key file
+}
+
+// addFlags registers the git credentials flags.
+func (s *sourceSecretOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&s.username, sourceGitUsernameFlag, "",
+		"Username of the basic-auth secret created for the source repository, together with --"+sourceGitPasswordFlag)
+	fs.StringVar(&s.password, sourceGitPasswordFlag, "",
+		"Password or token of the basic-auth secret created for the source repository, together with --"+sourceGitUsernameFlag)
+	fs.StringVar(&s.sshKeyFile, sourceGitSSHKeyFlag, "",
+		"Private key file of the SSH secret created for the source repository")
+}
+
+// enabled tells whether the source secret is created.
+func (s *sourceSecretOptions) enabled() bool {
+	return s.username != "" || s.password != "" || s.sshKeyFile != ""
+}
+
+// validate the basic-auth username and password must be informed together, and can't be combined
+// with the SSH private key.
+func (s *sourceSecretOptions) validate() error {
+	basicAuth := s.username != "" || s.password != ""
+	switch {
+	case basicAuth && s.sshKeyFile != "":
+		return fmt.Errorf("--%s can not be used together with --%s and --%s",
+			sourceGitSSHKeyFlag, sourceGitUsernameFlag, sourceGitPasswordFlag)
+	case s.username != "" && s.password == "":
+		return fmt.Errorf("--%s must be informed together with --%s", sourceGitPasswordFlag, sourceGitUsernameFlag)
+	case s.password != "" && s.username == "":
+		return fmt.Errorf("--%s must be informed together with --%s", sourceGitUsernameFlag, sourceGitPasswordFlag)
+	}
+	return nil
+}
+
+// newSecret returns the source secret typed after the credentials, annotated so the build
+// controller reconciles the Build referencing it, and labeled with the Build name.
+func (s *sourceSecretOptions) newSecret(namespace, name, buildName string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Labels:      map[string]string{buildv1alpha1.LabelBuild: buildName},
+			Annotations: map[string]string{buildv1alpha1.AnnotationBuildRefSecret: "true"},
+		},
+	}
+	if s.sshKeyFile == "" {
+		secret.Type = corev1.SecretTypeBasicAuth
+		secret.Data = map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte(s.username),
+			corev1.BasicAuthPasswordKey: []byte(s.password),
+		}
+		return secret, nil
+	}
+
+	key, err := os.ReadFile(s.sshKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the SSH private key informed on --%s: %w", sourceGitSSHKeyFlag, err)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("the SSH private key file %q informed on --%s is empty", s.sshKeyFile, sourceGitSSHKeyFlag)
+	}
+	secret.Type = corev1.SecretTypeSSHAuth
+	secret.Data = map[string][]byte{corev1.SSHAuthPrivateKey: key}
+	return secret, nil
+}
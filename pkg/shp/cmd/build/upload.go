@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 	"time"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
@@ -18,6 +20,7 @@ import (
 	"github.com/shipwright-io/cli/pkg/shp/reactor"
 	"github.com/shipwright-io/cli/pkg/shp/streamer"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -26,17 +29,22 @@ import (
 
 // UploadCommand represents the "build upload" subcommand, implements runner.SubCommand interface.
 type UploadCommand struct {
-	cmd          *cobra.Command              // cobra command instance
-	buildRunSpec *buildv1alpha1.BuildRunSpec // command-line flags stored directly on the BuildRun
-	follow       bool                        // flag to tail pod logs
+	cmd           *cobra.Command              // cobra command instance
+	buildRunSpec  *buildv1alpha1.BuildRunSpec // command-line flags stored directly on the BuildRun
+	follow        bool                        // flag to tail pod logs
+	quiet         bool                        // flag to suppress the pod status while following
+	reconnectTail int64                       // log lines repeated when reconnecting a log stream
 
 	buildRefName string // build name
 	sourceDir    string // local directory to be streamed
+	contextDir   string // Build's context directory, relative to the source directory
 
 	dataStreamer    *streamer.Streamer // tar streamer instance
 	streamingIsDone bool               // marks the streaming is completed
 
-	sourceBundleImage string // image to be used as the source bundle
+	sourceBundleImage string                 // image to be used as the source bundle
+	forceUpload       bool                   // pushes the source bundle even when unchanged
+	registryOpts      bundle.RegistryOptions // CLI's own registry connection settings
 
 	pw       *reactor.PodWatcher // pod-watcher instance
 	follower *follower.Follower  // follower instance
@@ -56,8 +64,22 @@ In case a source bundle image is defined, the bundling feature is used, which wi
 source code into a bundle container and upload it to the specified container registry. Instead of
 executing using Git in the source step, it will use the container registry to obtain the source code.
 
+The source bundle is only pushed when the local source has changed. The bundle image digest is the
+SHA-256 of its manifest, which only depends on the files packed, their names, modes and modification
+times, since the image creation time is fixed. Besides the Build's tag, the image is pushed with the
+"sha256-<hex>" tag of its digest, so identical source trees map to the same image. The upload is
+skipped when the Build's tag already points to the digest, and when only the "sha256-<hex>" tag does,
+the Build's tag is moved to it. Use --force-new-source-upload to always push the bundle.
+
+When the Build defines a context directory, it must exist within the directory uploaded.
+
 	$ shp buildrun upload <build-name>
 	$ shp buildrun upload <build-name> /path/to/repository
+
+The local directory is informed either as the second argument or with --source-path, informing both
+is an error. It defaults to the current directory:
+
+	$ shp build upload <build-name> --source-path=/path/to/repository
 `
 
 	// targetBaseDir directory where data will be uploaded.
@@ -66,6 +88,11 @@ executing using Git in the source step, it will use the container registry to ob
 	buildNameAnnotation = "build.shipwright.io/name"
 	// buildRunNameAnnotation label to identify the BuildRun name.
 	buildRunNameAnnotation = "buildrun.shipwright.io/name"
+
+	// sourcePathFlag command-line flag, local directory uploaded.
+	sourcePathFlag = "source-path"
+	// localSourcePathFlag command-line flag, alias for --source-path.
+	localSourcePathFlag = "local-source-path"
 )
 
 // Cmd exposes the Cobra command instance.
@@ -73,18 +100,34 @@ func (u *UploadCommand) Cmd() *cobra.Command {
 	return u.cmd
 }
 
-// extractArgs inspect the command-line arguments to extract the name and source directory path.
+// extractArgs inspect the command-line arguments to extract the name and source directory path,
+// the latter is either informed as argument or flag, never both.
 func (u *UploadCommand) extractArgs(args []string) error {
+	flagSet := u.cmd.Flags()
+	if flagSet.Changed(sourcePathFlag) && flagSet.Changed(localSourcePathFlag) {
+		return fmt.Errorf("--%s is an alias for --%s, inform only one of them", localSourcePathFlag, sourcePathFlag)
+	}
+	pathFlagged := flagSet.Changed(sourcePathFlag) || flagSet.Changed(localSourcePathFlag)
+
 	switch len(args) {
 	case 1:
 		u.buildRefName = args[0]
-		u.sourceDir = "."
+		if !pathFlagged {
+			u.sourceDir = "."
+		}
 	case 2:
+		if pathFlagged {
+			return fmt.Errorf("the source directory %q is informed together with --%s, choose either one",
+				args[1], sourcePathFlag)
+		}
 		u.buildRefName = args[0]
 		u.sourceDir = args[1]
 	default:
 		return fmt.Errorf("wrong amount of arguments, expected one or two")
 	}
+	if u.sourceDir == "" {
+		return fmt.Errorf("--%s must not be empty", sourcePathFlag)
+	}
 
 	if u.sourceDir == "." {
 		var err error
@@ -101,6 +144,7 @@ func (u *UploadCommand) extractArgs(args []string) error {
 
 // Complete instantiate the dependencies for the log following and the data streaming.
 func (u *UploadCommand) Complete(p *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	u.quiet = p.Quiet()
 	// extracting the command-line arguments to store the build-name and the path to the directory
 	// to be uploaded, in subsequent steps
 	if err := u.extractArgs(args); err != nil {
@@ -126,6 +170,10 @@ func (u *UploadCommand) Complete(p *params.Params, _ *genericclioptions.IOStream
 		return err
 	}
 
+	if build.Spec.Source.ContextDir != nil {
+		u.contextDir = *build.Spec.Source.ContextDir
+	}
+
 	// detect upload method, if build has bundle container image set, it
 	// is assumed that the source bundle upload via registry is used
 	if build.Spec.Source.BundleContainer != nil && build.Spec.Source.BundleContainer.Image != "" {
@@ -139,7 +187,13 @@ func (u *UploadCommand) Complete(p *params.Params, _ *genericclioptions.IOStream
 	return err
 }
 
-// Validate the current subcommand state, make sure the directory to be uploaded exists.
+// Mutating the BuildRun is created in the namespace.
+func (u *UploadCommand) Mutating() bool {
+	return true
+}
+
+// Validate the current subcommand state, make sure the directory to be uploaded exists, as well as
+// the Build's context directory within it.
 func (u *UploadCommand) Validate() error {
 	stat, err := os.Stat(u.sourceDir)
 	if err != nil {
@@ -148,6 +202,40 @@ func (u *UploadCommand) Validate() error {
 	if !stat.IsDir() {
 		return fmt.Errorf("informed path is not a directory: '%s'", u.sourceDir)
 	}
+	if err = validateContextDir(u.sourceDir, u.contextDir); err != nil {
+		return err
+	}
+	if err = flags.ValidateBuildRunOutput(u.buildRunSpec.Output); err != nil {
+		return err
+	}
+	return flags.ValidateReconnectTail(u.reconnectTail)
+}
+
+// validateContextDir makes sure the context directory is a relative path pointing to an existing
+// directory within the source directory, otherwise the build would run on an empty context.
+func validateContextDir(sourceDir, contextDir string) error {
+	if contextDir == "" {
+		return nil
+	}
+	if filepath.IsAbs(contextDir) {
+		return fmt.Errorf("context directory '%s' must be relative to the source directory '%s'", contextDir, sourceDir)
+	}
+	cleaned := filepath.Clean(contextDir)
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("context directory '%s' is outside of the source directory '%s'", contextDir, sourceDir)
+	}
+
+	dir := filepath.Join(sourceDir, cleaned)
+	stat, err := os.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("context directory '%s' not found in the source directory '%s'", contextDir, sourceDir)
+		}
+		return err
+	}
+	if !stat.IsDir() {
+		return fmt.Errorf("context directory '%s' is not a directory: '%s'", contextDir, dir)
+	}
 	return nil
 }
 
@@ -276,6 +364,8 @@ func (u *UploadCommand) onPodModifiedEventBundling(pod *corev1.Pod) error {
 // Run executes the primary business logic of this subcommand, by starting to watch over the build
 // pod status and react accordingly.
 func (u *UploadCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	p.WarnUnsupportedFlags(u.cmd.Context(), u.cmd.Flags(), ioStreams.ErrOut, flags.BuildRunFeatures)
+
 	// creating a BuildRun with settings for the local source upload
 	br, err := u.createBuildRun(p)
 	if err != nil {
@@ -283,16 +373,19 @@ func (u *UploadCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStr
 	}
 
 	if u.follow {
-		// when follow flag is enabled, instantiating the "follower" to live tail logs
-		if u.follower, err = p.NewFollower(u.Cmd().Context(), types.NamespacedName{Namespace: br.Namespace, Name: br.Name}, ioStreams); err != nil {
+		// when follow flag is enabled, instantiating the "follower" to live tail logs, reacting on the
+		// events of the pod watcher started below
+		if u.follower, err = p.NewFollowerWithPodWatcher(u.Cmd().Context(), types.NamespacedName{Namespace: br.Namespace, Name: br.Name}, ioStreams, u.pw); err != nil {
 			return err
 		}
+		u.follower.SetQuiet(u.quiet)
+		u.follower.SetReconnectTail(u.reconnectTail)
 	}
 
 	switch {
 	// Using bundling to upload local source code
 	case u.sourceBundleImage != "":
-		_, err = bundle.Push(u.cmd.Context(), ioStreams, u.sourceDir, u.sourceBundleImage)
+		_, err = bundle.Push(u.cmd.Context(), ioStreams, u.sourceDir, u.sourceBundleImage, u.registryOpts, u.forceUpload)
 		if err != nil {
 			return err
 		}
@@ -334,5 +427,34 @@ func uploadCmd() runner.SubCommand {
 		follow:       false,
 	}
 	flags.FollowFlag(cmd.Flags(), &u.follow)
+	flags.ReconnectTailFlag(cmd.Flags(), &u.reconnectTail)
+	cmd.Flags().StringVar(&u.sourceDir, sourcePathFlag, "", "local directory uploaded, instead of the second argument, defaults to the current directory")
+	cmd.Flags().StringVar(&u.sourceDir, localSourcePathFlag, "", fmt.Sprintf("alias for --%s", sourcePathFlag))
+	cmd.Flags().BoolVar(&u.forceUpload, "force-new-source-upload", false,
+		"push the source bundle even when the registry already has an identical one")
+	sourceBundleRegistryFlags(cmd.Flags(), &u.registryOpts)
 	return u
 }
+
+// sourceBundleRegistryFlags registers the flags controlling how the CLI itself connects to the
+// source bundle registry.
+func sourceBundleRegistryFlags(flagSet *pflag.FlagSet, registryOpts *bundle.RegistryOptions) {
+	flagSet.BoolVar(
+		&registryOpts.InsecureSkipTLSVerify,
+		"source-bundle-insecure-skip-tls-verify",
+		false,
+		"DANGEROUS: skip the TLS verification, and allow plain HTTP, when accessing the source bundle registry, only affects the CLI's own registry access",
+	)
+	flagSet.StringVar(
+		&registryOpts.CAFile,
+		"source-bundle-ca-file",
+		"",
+		"path to a PEM encoded CA bundle to trust when accessing the source bundle registry, only affects the CLI's own registry access",
+	)
+	flagSet.StringVar(
+		&registryOpts.DockerConfig,
+		"docker-config",
+		"",
+		"path to a Docker config.json file with the source bundle registry credentials, by default the local Docker and Podman logins are used",
+	)
+}
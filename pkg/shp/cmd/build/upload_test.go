@@ -0,0 +1,89 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestValidateContextDir(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	sourceDir := t.TempDir()
+	g.Expect(os.MkdirAll(filepath.Join(sourceDir, "docker", "app"), 0o755)).To(gomega.Succeed())
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "Dockerfile"), []byte("FROM scratch"), 0o600)).To(gomega.Succeed())
+
+	tests := []struct {
+		name       string
+		contextDir string
+		err        string
+	}{
+		{name: "no context directory", contextDir: ""},
+		{name: "relative context directory", contextDir: "docker/app"},
+		{name: "relative context directory with dot", contextDir: "./docker/../docker/app/"},
+		{name: "nonexistent context directory", contextDir: "dokcer", err: "context directory 'dokcer' not found"},
+		{name: "context directory is a file", contextDir: "Dockerfile", err: "is not a directory"},
+		{name: "absolute context directory", contextDir: filepath.Join(sourceDir, "docker"), err: "must be relative to the source directory"},
+		{name: "context directory outside of the source", contextDir: "../docker", err: "is outside of the source directory"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			err := validateContextDir(sourceDir, test.contextDir)
+			if test.err == "" {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).To(gomega.HaveOccurred())
+			g.Expect(err.Error()).To(gomega.ContainSubstring(test.err))
+		})
+	}
+}
+
+func TestUploadSourcePath(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		flags     []string
+		sourceDir string
+		err       string
+	}{
+		{name: "defaults to the current directory", args: []string{"build"}, sourceDir: cwd},
+		{name: "directory argument", args: []string{"build", "/src/app/"}, sourceDir: "/src/app"},
+		{name: "source path flag", args: []string{"build"}, flags: []string{"--source-path=/src/app"}, sourceDir: "/src/app"},
+		{name: "local source path alias", args: []string{"build"}, flags: []string{"--local-source-path=/src/app"}, sourceDir: "/src/app"},
+		{name: "current directory flag", args: []string{"build"}, flags: []string{"--source-path=."}, sourceDir: cwd},
+		{name: "argument and flag", args: []string{"build", "/src/app"}, flags: []string{"--source-path=/src/other"},
+			err: `the source directory "/src/app" is informed together with --source-path`},
+		{name: "flag and alias", args: []string{"build"}, flags: []string{"--source-path=/src/app", "--local-source-path=/src/app"},
+			err: "--local-source-path is an alias for --source-path"},
+		{name: "empty flag", args: []string{"build"}, flags: []string{"--source-path="}, err: "--source-path must not be empty"},
+		{name: "too many arguments", args: []string{"build", "/src/app", "/src/other"}, err: "wrong amount of arguments"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			u := uploadCmd().(*UploadCommand)
+			g.Expect(u.Cmd().ParseFlags(test.flags)).To(gomega.Succeed())
+			err := u.extractArgs(test.args)
+			if test.err != "" {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Error()).To(gomega.ContainSubstring(test.err))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(u.buildRefName).To(gomega.Equal("build"))
+			g.Expect(u.sourceDir).To(gomega.Equal(test.sourceDir))
+		})
+	}
+}
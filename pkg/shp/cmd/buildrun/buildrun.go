@@ -27,6 +27,8 @@ func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Co
 		runner.NewRunner(p, ioStreams, createCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, cancelCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, deleteCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, statusCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, describeCmd()).Cmd(),
 	)
 	return command
 }
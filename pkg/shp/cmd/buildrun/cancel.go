@@ -1,18 +1,19 @@
 package buildrun
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/reactor"
+	"github.com/shipwright-io/cli/pkg/shp/util"
 )
 
 // CancelCommand contains data input from user for delete sub-command
@@ -20,16 +21,39 @@ type CancelCommand struct {
 	cmd *cobra.Command
 
 	name string
+	wait bool // waits for the controller to acknowledge the cancellation
 }
 
+// waitFlag command-line flag, waits for the BuildRun to be marked as canceled.
+const waitFlag = "wait"
+
+const buildRunCancelLongDesc = `
+Cancels the BuildRun informed by name, setting its state to "BuildRunCanceled", the controller then
+stops the BuildRun pod. BuildRuns which have already finished can't be canceled.
+
+By default the command returns once the cancellation is requested. With --wait it waits for the
+controller to mark the BuildRun as canceled, bounded by --context-timeout when informed, and fails
+when the BuildRun has finished before the cancellation took effect:
+
+	$ shp buildrun cancel --wait my-app-xyz12
+`
+
 func cancelCmd() runner.SubCommand {
-	return &CancelCommand{
+	cancelCommand := &CancelCommand{
 		cmd: &cobra.Command{
 			Use:   "cancel <name>",
 			Short: "Cancel BuildRun",
+			Long:  buildRunCancelLongDesc,
 			Args:  cobra.ExactArgs(1),
 		},
 	}
+	cancelCommand.cmd.Flags().BoolVar(
+		&cancelCommand.wait,
+		waitFlag,
+		false,
+		"Wait for the controller to mark the BuildRun as canceled",
+	)
+	return cancelCommand
 }
 
 // Cmd returns cobra command object
@@ -44,6 +68,11 @@ func (c *CancelCommand) Complete(_ *params.Params, _ *genericclioptions.IOStream
 	return nil
 }
 
+// Mutating the BuildRun is updated in the namespace.
+func (c *CancelCommand) Mutating() bool {
+	return true
+}
+
 // Validate validates data input by user
 func (c *CancelCommand) Validate() error {
 	return nil
@@ -64,25 +93,37 @@ func (c *CancelCommand) Run(params *params.Params, ioStreams *genericclioptions.
 		return fmt.Errorf("failed to cancel BuildRun %s: execution has already finished", c.name)
 	}
 
-	type patchStringValue struct {
-		Op    string `json:"op"`
-		Path  string `json:"path"`
-		Value string `json:"value"`
-	}
-	payload := []patchStringValue{{
-		Op:    "replace",
-		Path:  "/spec/state",
-		Value: buildv1alpha1.BuildRunStateCancel,
-	}}
-	var data []byte
-	if data, err = json.Marshal(payload); err != nil {
+	if err = util.CancelBuildRun(c.cmd.Context(), clientset, params.Namespace(), c.name); err != nil {
 		return err
 	}
-	if _, err = clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()).Patch(c.Cmd().Context(), c.name, types.JSONPatchType, data, metav1.PatchOptions{}); err != nil {
-		return err
+	if c.wait {
+		if err = c.waitForCancellation(params, ioStreams); err != nil {
+			return err
+		}
 	}
 
 	fmt.Fprintf(ioStreams.Out, "BuildRun successfully canceled '%v'\n", c.name)
 
 	return nil
 }
+
+// waitForCancellation waits for the BuildRun to reach a terminal state, failing when it's not
+// because of the cancellation, i.e. the BuildRun has finished in the meantime.
+func (c *CancelCommand) waitForCancellation(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(c.cmd.Context(), params.ContextTimeout())
+	defer cancel()
+
+	params.Logger(ioStreams.ErrOut).Info(fmt.Sprintf("Waiting for BuildRun %q to be canceled...", c.name))
+	condition, err := reactor.WaitForCompletion(ctx, clientset, params.Namespace(), c.name)
+	if err != nil {
+		return err
+	}
+	if condition.Reason != buildv1alpha1.BuildRunStateCancel {
+		return fmt.Errorf("BuildRun %q has finished before being canceled, reason: %s", c.name, condition.Reason)
+	}
+	return nil
+}
@@ -2,11 +2,14 @@ package buildrun
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakekubetesting "k8s.io/client-go/testing"
 
 	"github.com/spf13/cobra"
 
@@ -123,3 +126,66 @@ func TestCancelBuildRun(t *testing.T) {
 		}
 	}
 }
+
+func TestCancelBuildRunWait(t *testing.T) {
+	tests := map[string]struct {
+		reason    string // reason of the terminal condition once the cancellation is requested
+		expectErr string
+	}{
+		"canceled": {
+			reason: v1alpha1.BuildRunStateCancel,
+		},
+		"finished-in-the-meantime": {
+			reason:    "Succeeded",
+			expectErr: `BuildRun "br" has finished before being canceled, reason: Succeeded`,
+		},
+	}
+	for testName, test := range tests {
+		br := &v1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{Name: "br", Namespace: metav1.NamespaceDefault}}
+		clientset := fake.NewSimpleClientset(br)
+		// the controller marks the BuildRun as finished once the cancellation is requested
+		clientset.PrependReactor("get", "buildruns", func(action fakekubetesting.Action) (bool, runtime.Object, error) {
+			obj, err := clientset.Tracker().Get(action.GetResource(), action.GetNamespace(), br.Name)
+			if err != nil {
+				return true, nil, err
+			}
+			current := obj.(*v1alpha1.BuildRun)
+			if current.IsCanceled() {
+				status := corev1.ConditionFalse
+				if test.reason == "Succeeded" {
+					status = corev1.ConditionTrue
+				}
+				current.Status.Conditions = v1alpha1.Conditions{{Type: v1alpha1.Succeeded, Status: status, Reason: test.reason}}
+			}
+			return true, current, nil
+		})
+
+		cmd := cancelCmd().(*CancelCommand)
+		cmd.Cmd().SetContext(context.Background())
+		if err := cmd.Cmd().ParseFlags([]string{"--wait"}); err != nil {
+			t.Fatal(err)
+		}
+		param := params.NewParamsForTest(nil, clientset, nil, metav1.NamespaceDefault, nil, nil)
+		ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+		if err := cmd.Complete(param, &ioStreams, []string{br.Name}); err != nil {
+			t.Fatal(err)
+		}
+		err := cmd.Run(param, &ioStreams)
+
+		if test.expectErr != "" {
+			if err == nil || err.Error() != test.expectErr {
+				t.Errorf("%s: unexpected error: %v", testName, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: did not expect err: %s", testName, err.Error())
+		}
+		if !strings.Contains(errOut.String(), `Waiting for BuildRun "br" to be canceled...`) {
+			t.Errorf("%s: unexpected stderr: %q", testName, errOut.String())
+		}
+		if out.String() != "BuildRun successfully canceled 'br'\n" {
+			t.Errorf("%s: unexpected output: %q", testName, out.String())
+		}
+	}
+}
@@ -2,10 +2,13 @@ package buildrun
 
 import (
 	"fmt"
+	"math/rand"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
@@ -18,15 +21,47 @@ import (
 type CreateCommand struct {
 	cmd *cobra.Command // cobra command instance
 
-	name         string                      // buildrun name
-	buildRunSpec *buildv1alpha1.BuildRunSpec // stores command-line flags
+	name          string                      // buildrun name
+	buildRunSpec  *buildv1alpha1.BuildRunSpec // stores command-line flags
+	buildSpec     *buildv1alpha1.BuildSpec    // inline Build, used instead of --buildref-name
+	metadata      *flags.Metadata             // labels and annotations informed on command-line
+	retries       int                         // attempts with a random name suffix when the name is taken
+	quiet         bool                        // print only the BuildRun name
+	envFile       string                      // dotenv file with environment variables, "-" for stdin
+	envFileValues []corev1.EnvVar             // environment variables read from the env file
 }
 
+const (
+	// createRetriesFlag command-line flag, retries with a random name suffix on name conflicts.
+	createRetriesFlag = "create-retries"
+	// nameSuffixLength length of the random suffix appended to the name on conflicts.
+	nameSuffixLength = 5
+	// nameSuffixAlphabet characters of the random suffix, the same the API server uses for
+	// generated names, avoiding vowels and look-alike characters.
+	nameSuffixAlphabet = "bcdfghjklmnpqrstvwxz2456789"
+)
+
 const buildRunCreateLongDesc = `
 Creates a new BuildRun instance using the given name, and requires --buildref-name to
 find the Build object. Example:
 
 	$ shp buildrun create my-app-build --buildref-name="..."
+
+Alternatively, an ad-hoc build can be run without a Build object, by informing the Build
+inline instead of --buildref-name:
+
+	$ shp buildrun create my-app-build --source-url="..." --strategy-name="..." --output-image="..."
+
+When concurrent clients use the same name, --create-retries appends a random suffix to the name and
+tries again, up to the given amount of times, the name used is printed at the end.
+
+Environment variables are set with --env NAME=value, or many at once with --env-from-file, a
+dotenv file of "NAME=value" lines, or "-" to read it from stdin. The variables informed with --env
+take precedence:
+
+	$ shp buildrun create my-app-build --buildref-name="..." --env-from-file=build.env
+
+With --quiet only the BuildRun name is printed, the warnings and errors are still written to stderr.
 `
 
 // Cmd returns cobra.Command object of the create sub-command.
@@ -34,23 +69,54 @@ func (c *CreateCommand) Cmd() *cobra.Command {
 	return c.cmd
 }
 
-// Complete checks if the arguments is informing the BuildRun name.
-func (c *CreateCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+// Complete checks if the arguments is informing the BuildRun name, and reads the env file.
+func (c *CreateCommand) Complete(params *params.Params, ioStreams *genericclioptions.IOStreams, args []string) error {
+	c.quiet = params.Quiet()
 	switch len(args) {
 	case 1:
 		c.name = args[0]
 	default:
 		return fmt.Errorf("wrong amount of arguments, expected only one")
 	}
+	if c.envFile != "" {
+		var err error
+		if c.envFileValues, err = flags.ReadEnvFile(c.envFile, ioStreams.In); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// Validate makes sure a name is informed.
+// Mutating the BuildRun is created in the namespace.
+func (c *CreateCommand) Mutating() bool {
+	return true
+}
+
+// Validate makes sure a name is informed, and either a Build reference or an inline Build.
 func (c *CreateCommand) Validate() error {
 	if c.name == "" {
 		return fmt.Errorf("name is not informed")
 	}
-	return nil
+
+	inline := flags.SanitizeInlineBuildSpec(c.buildSpec)
+	switch {
+	case c.buildRunSpec.BuildRef.Name != "" && inline:
+		return fmt.Errorf("either --%s or an inline Build must be informed, not both",
+			flags.BuildrefNameFlag)
+	case c.buildRunSpec.BuildRef.Name == "" && !inline:
+		return fmt.Errorf("either --%s or an inline Build, with --%s, --%s and --%s, must be informed",
+			flags.BuildrefNameFlag, flags.SourceURLFlag, flags.StrategyNameFlag, flags.OutputImageFlag)
+	case inline && (c.buildSpec.Source.URL == nil || c.buildSpec.Strategy.Name == "" || c.buildRunSpec.Output.Image == ""):
+		return fmt.Errorf("an inline Build requires --%s, --%s and --%s",
+			flags.SourceURLFlag, flags.StrategyNameFlag, flags.OutputImageFlag)
+	}
+	if err := flags.ValidateBuildRunOutput(c.buildRunSpec.Output); err != nil {
+		return err
+	}
+	if c.retries < 0 {
+		return fmt.Errorf("--%s must not be negative", createRetriesFlag)
+	}
+	return flags.ValidateOutputImage(c.buildRunSpec.Output.Image)
 }
 
 // Run executes the creation of BuildRun object.
@@ -63,18 +129,66 @@ func (c *CreateCommand) Run(params *params.Params, ioStreams *genericclioptions.
 	}
 
 	flags.SanitizeBuildRunSpec(&br.Spec)
+	br.Spec.Env = flags.MergeEnv(br.Spec.Env, c.envFileValues)
+	if err := c.metadata.ApplyTo(&br.ObjectMeta); err != nil {
+		return err
+	}
+	if warning := flags.OutputImageWarning(c.buildRunSpec.Output.Image); warning != "" {
+		params.Logger(ioStreams.ErrOut).Warning(warning)
+	}
+	params.WarnUnsupportedFlags(c.cmd.Context(), c.cmd.Flags(), ioStreams.ErrOut, flags.BuildRunFeatures)
+
+	// the inline Build takes the output image, which is mandatory, from the BuildRun flags
+	inline := flags.SanitizeInlineBuildSpec(c.buildSpec)
+	if inline {
+		br.Spec.BuildSpec = c.buildSpec
+		br.Spec.BuildSpec.Output = *br.Spec.Output
+		br.Spec.Output = nil
+	}
 
 	clientset, err := params.ShipwrightClientSet()
 	if err != nil {
 		return err
 	}
-	if _, err = clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()).Create(c.cmd.Context(), br, metav1.CreateOptions{}); err != nil {
-		return err
+	brClient := clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace())
+	for attempt := 0; ; attempt++ {
+		_, err = brClient.Create(c.cmd.Context(), br, metav1.CreateOptions{})
+		if err == nil {
+			break
+		}
+		if !k8serrors.IsAlreadyExists(err) || attempt == c.retries {
+			return err
+		}
+		name := fmt.Sprintf("%s-%s", c.name, nameSuffix())
+		if !c.quiet {
+			params.Logger(ioStreams.ErrOut).Info(fmt.Sprintf("BuildRun %q already exists, retrying as %q", br.Name, name))
+		}
+		br.Name = name
 	}
-	fmt.Fprintf(ioStreams.Out, "BuildRun created %q for Build %q\n", c.name, br.Spec.BuildRef.Name)
+
+	if c.quiet {
+		fmt.Fprintln(ioStreams.Out, br.Name)
+		return nil
+	}
+
+	if inline {
+		fmt.Fprintf(ioStreams.Out, "BuildRun created %q with an inline Build\n", br.Name)
+		return nil
+	}
+	fmt.Fprintf(ioStreams.Out, "BuildRun created %q for Build %q\n", br.Name, br.Spec.BuildRef.Name)
 	return nil
 }
 
+// nameSuffix returns a random suffix to tell apart BuildRuns created with the same name.
+func nameSuffix() string {
+	b := make([]byte, nameSuffixLength)
+	for i := range b {
+		// #nosec G404 the suffix avoids name clashes, it's not security sensitive
+		b[i] = nameSuffixAlphabet[rand.Intn(len(nameSuffixAlphabet))]
+	}
+	return string(b)
+}
+
 // createCmd instantiate a new CreateCommand, by wiring it as a cobra.Command and registering the
 // flags and marking flags required.
 func createCmd() runner.SubCommand {
@@ -85,14 +199,16 @@ func createCmd() runner.SubCommand {
 	}
 
 	// instantiating command-line flags, using an actual BuildRunSpec object to receive the flags
-	// issued on command-line, also marking flags as required
+	// issued on command-line, either the Build reference or the inline Build is validated later on
 	buildRunSpecFlags := flags.BuildRunSpecFromFlags(cmd.Flags())
-	if err := cmd.MarkFlagRequired(flags.BuildrefNameFlag); err != nil {
-		panic(err)
-	}
 
-	return &CreateCommand{
+	createCommand := &CreateCommand{
 		cmd:          cmd,
 		buildRunSpec: buildRunSpecFlags,
+		buildSpec:    flags.InlineBuildSpecFromFlags(cmd.Flags()),
+		metadata:     flags.MetadataFromFlags(cmd.Flags()),
 	}
+	cmd.Flags().StringVar(&createCommand.envFile, flags.EnvFromFileFlag, "", `Dotenv file of "NAME=value" environment variables, "-" for stdin; --env takes precedence`)
+	cmd.Flags().IntVar(&createCommand.retries, createRetriesFlag, 0, "When the name is already taken, retry up to the given amount of times appending a random suffix to the name")
+	return createCommand
 }
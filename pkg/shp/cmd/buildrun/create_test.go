@@ -0,0 +1,179 @@
+package buildrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestCreateBuildRun(t *testing.T) {
+	clusterKind := buildv1alpha1.ClusterBuildStrategyKind
+
+	tests := []struct {
+		name      string
+		args      []string
+		expectErr string
+		buildRef  string
+		buildSpec *buildv1alpha1.BuildSpec
+		output    string
+	}{
+		{
+			name:     "build reference",
+			args:     []string{"--buildref-name=build", "--output-image=registry/app"},
+			buildRef: "build",
+			output:   "BuildRun created \"br\" for Build \"build\"\n",
+		},
+		{
+			name: "inline build",
+			args: []string{"--source-url=https://github.com/shipwright-io/sample-go", "--source-context-dir=source-build",
+				"--strategy-name=buildah", "--output-image=registry/app"},
+			buildSpec: &buildv1alpha1.BuildSpec{
+				Source: buildv1alpha1.Source{
+					URL:        pointer.String("https://github.com/shipwright-io/sample-go"),
+					ContextDir: pointer.String("source-build"),
+				},
+				Strategy: buildv1alpha1.Strategy{Name: "buildah", Kind: &clusterKind},
+				Output:   buildv1alpha1.Image{Image: "registry/app", Labels: map[string]string{}, Annotations: map[string]string{}},
+			},
+			output: "BuildRun created \"br\" with an inline Build\n",
+		},
+		{
+			name:      "build reference and inline build",
+			args:      []string{"--buildref-name=build", "--source-url=https://github.com/shipwright-io/sample-go"},
+			expectErr: "either --buildref-name or an inline Build must be informed, not both",
+		},
+		{
+			name:      "neither build reference nor inline build",
+			expectErr: "either --buildref-name or an inline Build",
+		},
+		{
+			name:      "inline build without output image",
+			args:      []string{"--source-url=https://github.com/shipwright-io/sample-go", "--strategy-name=buildah"},
+			expectErr: "an inline Build requires --source-url, --strategy-name and --output-image",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			cmd := createCmd().(*CreateCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Cmd().ParseFlags(test.args)).To(gomega.Succeed())
+
+			shpclientset := shpfake.NewSimpleClientset()
+			param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			g.Expect(cmd.Complete(param, &ioStreams, []string{"br"})).To(gomega.Succeed())
+
+			err := cmd.Validate()
+			if test.expectErr != "" {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(test.expectErr)))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+			g.Expect(out.String()).To(gomega.Equal(test.output))
+
+			br, err := shpclientset.ShipwrightV1alpha1().BuildRuns(metav1.NamespaceDefault).Get(context.Background(), "br", metav1.GetOptions{})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			if test.buildSpec == nil {
+				g.Expect(br.Spec.BuildRef.Name).To(gomega.Equal(test.buildRef))
+				g.Expect(br.Spec.BuildSpec).To(gomega.BeNil())
+				return
+			}
+			g.Expect(br.Spec.BuildRef).To(gomega.BeNil())
+			g.Expect(br.Spec.Output).To(gomega.BeNil())
+			g.Expect(br.Spec.BuildSpec).To(gomega.Equal(test.buildSpec))
+		})
+	}
+}
+
+func TestCreateBuildRunRetries(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	existing := &buildv1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br"}}
+
+	run := func(args ...string) (string, string, *shpfake.Clientset, error) {
+		shpclientset := shpfake.NewSimpleClientset(existing)
+		param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, genericclioptions.NewConfigFlags(true), metav1.NamespaceDefault, nil, nil)
+		cmd := createCmd().(*CreateCommand)
+		cmd.Cmd().SetContext(context.Background())
+		// --quiet is a global flag, inherited from the root command
+		param.AddFlags(cmd.Cmd().PersistentFlags())
+		g.Expect(cmd.Cmd().ParseFlags(append([]string{"--buildref-name=build"}, args...))).To(gomega.Succeed())
+
+		ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.Complete(param, &ioStreams, []string{"br"})).To(gomega.Succeed())
+		if err := cmd.Validate(); err != nil {
+			return "", "", nil, err
+		}
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), errOut.String(), shpclientset, err
+	}
+
+	// without retries the conflict is returned as is
+	_, _, _, err := run()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`"br" already exists`)))
+
+	out, errOut, shpclientset, err := run("--create-retries=2")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.MatchRegexp(`^BuildRun created "br-[bcdfghjklmnpqrstvwxz2456789]{5}" for Build "build"\n$`))
+	g.Expect(errOut).To(gomega.MatchRegexp(`^BuildRun "br" already exists, retrying as "br-[a-z0-9]{5}"\n$`))
+	brs, err := shpclientset.ShipwrightV1alpha1().BuildRuns(metav1.NamespaceDefault).List(context.Background(), metav1.ListOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(brs.Items).To(gomega.HaveLen(2))
+
+	// only the name actually used is printed, without the retry messages
+	out, errOut, _, err = run("--create-retries=2", "--quiet")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.MatchRegexp(`^br-[bcdfghjklmnpqrstvwxz2456789]{5}\n$`))
+	g.Expect(errOut).To(gomega.BeEmpty())
+
+	_, _, _, err = run("--create-retries=-1")
+	g.Expect(err).To(gomega.MatchError("--create-retries must not be negative"))
+}
+
+func TestCreateBuildRunEnvFromFile(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	cmd := createCmd().(*CreateCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--buildref-name=build", "--env=LOG_LEVEL=info", "--env-from-file=-"})).To(gomega.Succeed())
+
+	shpclientset := shpfake.NewSimpleClientset()
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+	ioStreams, in, _, _ := genericclioptions.NewTestIOStreams()
+	in.WriteString("# defaults\nLOG_LEVEL=debug\nPROXY=http://proxy:3128\n")
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"br"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+
+	// the variables informed with --env take precedence over the file
+	br, err := shpclientset.ShipwrightV1alpha1().BuildRuns(metav1.NamespaceDefault).Get(context.Background(), "br", metav1.GetOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(br.Spec.Env).To(gomega.Equal([]corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "PROXY", Value: "http://proxy:3128"},
+	}))
+
+	// duplicated names on the file are rejected
+	cmd = createCmd().(*CreateCommand)
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--buildref-name=build", "--env-from-file=-"})).To(gomega.Succeed())
+	ioStreams, in, _, _ = genericclioptions.NewTestIOStreams()
+	in.WriteString("A=1\nA=2\n")
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"br"})).To(gomega.MatchError(
+		"invalid --env-from-file line 2, environment variable 'A' is already set on line 1"))
+}
@@ -1,32 +1,62 @@
 package buildrun
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/util"
 )
 
 // DeleteCommand contains data input from user for delete sub-command
 type DeleteCommand struct {
 	cmd *cobra.Command
 
-	name string
+	name      string
+	all       bool          // flag to delete all BuildRuns in the namespace
+	completed bool          // flag to only delete BuildRuns in a terminal state
+	olderThan time.Duration // only delete BuildRuns created before this duration
+	force     bool          // flag to allow deleting BuildRuns still in progress
+	yes       bool          // skips the confirmation prompt
 }
 
+const buildRunDeleteLongDesc = `
+Deletes the BuildRun informed by name, or all BuildRuns in the namespace with --all. For
+example, to remove the BuildRuns which finished more than a week ago:
+
+	$ shp buildrun delete --all --completed --older-than=168h
+
+BuildRuns still in progress are kept unless --force is informed. Deleting with --all is confirmed
+interactively, unless --yes is informed or stdin is not a terminal.
+`
+
 func deleteCmd() runner.SubCommand {
-	return &DeleteCommand{
+	deleteCommand := &DeleteCommand{
 		cmd: &cobra.Command{
-			Use:   "delete <name>",
+			Use:   "delete [name] [flags]",
 			Short: "Delete BuildRun",
-			Args:  cobra.ExactArgs(1),
+			Long:  buildRunDeleteLongDesc,
+			Args:  cobra.MaximumNArgs(1),
 		},
 	}
+
+	deleteCommand.cmd.Flags().BoolVar(&deleteCommand.all, "all", false, "Delete all BuildRuns in the namespace")
+	deleteCommand.cmd.Flags().BoolVar(&deleteCommand.completed, "completed", false, "Together with --all, only delete BuildRuns which have succeeded or failed")
+	deleteCommand.cmd.Flags().DurationVar(&deleteCommand.olderThan, "older-than", 0, "Together with --all, only delete BuildRuns created before the given duration, e.g. 24h")
+	deleteCommand.cmd.Flags().BoolVar(&deleteCommand.force, "force", false, "Together with --all, also delete BuildRuns still in progress")
+	flags.YesFlags(deleteCommand.cmd.Flags(), &deleteCommand.yes)
+
+	return deleteCommand
 }
 
 // Cmd returns cobra command object
@@ -36,13 +66,30 @@ func (c *DeleteCommand) Cmd() *cobra.Command {
 
 // Complete fills in data provided by user
 func (c *DeleteCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
-	c.name = args[0]
+	if len(args) == 1 {
+		c.name = args[0]
+	}
 
 	return nil
 }
 
+// Mutating the BuildRuns are deleted from the namespace.
+func (c *DeleteCommand) Mutating() bool {
+	return true
+}
+
 // Validate validates data input by user
 func (c *DeleteCommand) Validate() error {
+	switch {
+	case c.all && c.name != "":
+		return errors.New("either a BuildRun name or --all must be informed, not both")
+	case !c.all && c.name == "":
+		return errors.New("a BuildRun name or --all must be informed")
+	case !c.all && (c.completed || c.olderThan != 0 || c.force):
+		return errors.New("--completed, --older-than and --force can only be used together with --all")
+	case c.olderThan < 0:
+		return errors.New("--older-than must be a positive duration")
+	}
 	return nil
 }
 
@@ -53,6 +100,10 @@ func (c *DeleteCommand) Run(params *params.Params, ioStreams *genericclioptions.
 		return err
 	}
 
+	if c.all {
+		return c.deleteAll(params, ioStreams)
+	}
+
 	if err = clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()).Delete(c.cmd.Context(), c.name, metav1.DeleteOptions{}); err != nil {
 		return err
 	}
@@ -61,3 +112,66 @@ func (c *DeleteCommand) Run(params *params.Params, ioStreams *genericclioptions.
 
 	return nil
 }
+
+// deleteAll deletes the BuildRuns in the namespace matching the filters, BuildRuns in progress are
+// skipped unless forced. The deletion is confirmed when prompting is possible. The errors are
+// collected and returned together at the end.
+func (c *DeleteCommand) deleteAll(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	buildRunClient := clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace())
+
+	brs, err := buildRunClient.List(c.cmd.Context(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for i := range brs.Items {
+		br := &brs.Items[i]
+		if !c.shouldDelete(br) {
+			continue
+		}
+		if !br.IsDone() && !c.force {
+			fmt.Fprintf(ioStreams.Out, "BuildRun skipped '%v', it is still in progress (use --force to delete it)\n", br.Name)
+			continue
+		}
+		names = append(names, br.Name)
+	}
+
+	if len(names) > 0 && !c.yes && params.Interactive(ioStreams.In) {
+		question := fmt.Sprintf("About to delete %d BuildRuns in namespace %q, continue?", len(names), params.Namespace())
+		confirmed, err := util.Confirm(ioStreams.In, ioStreams.ErrOut, question)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(ioStreams.Out, "Deletion canceled, no BuildRuns deleted")
+			return nil
+		}
+	}
+
+	errs := []error{}
+	for _, name := range names {
+		if err := buildRunClient.Delete(c.cmd.Context(), name, metav1.DeleteOptions{}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete BuildRun %q: %w", name, err))
+			continue
+		}
+		fmt.Fprintf(ioStreams.Out, "BuildRun deleted '%v'\n", name)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// shouldDelete checks the BuildRun against the completed and older-than filters.
+func (c *DeleteCommand) shouldDelete(br *buildv1alpha1.BuildRun) bool {
+	if c.completed && !br.IsDone() {
+		return false
+	}
+	if c.olderThan > 0 && time.Since(br.CreationTimestamp.Time) < c.olderThan {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package buildrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	fakekubetesting "k8s.io/client-go/testing"
+
+	"github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestDeleteAllBuildRuns(t *testing.T) {
+	buildRun := func(name string, status corev1.ConditionStatus, age time.Duration) *v1alpha1.BuildRun {
+		br := newBuildRun(name, "a", "")
+		br.Status.Conditions[0].Status = status
+		br.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		return br
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		failOn    string
+		expectErr bool
+		skipped   bool
+		deleted   []string
+	}{{
+		name:    "all finished",
+		args:    []string{"--all"},
+		skipped: true,
+		deleted: []string{"failed", "old-succeeded", "succeeded"},
+	}, {
+		name:    "all including in progress",
+		args:    []string{"--all", "--force"},
+		deleted: []string{"failed", "old-succeeded", "running", "succeeded"},
+	}, {
+		name:    "completed older than",
+		args:    []string{"--all", "--completed", "--older-than=24h"},
+		deleted: []string{"old-succeeded"},
+	}, {
+		name:      "aggregate errors",
+		args:      []string{"--all", "--completed"},
+		failOn:    "failed",
+		expectErr: true,
+		deleted:   []string{"old-succeeded", "succeeded"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			shpclientset := shpfake.NewSimpleClientset(
+				buildRun("running", corev1.ConditionUnknown, time.Hour),
+				buildRun("succeeded", corev1.ConditionTrue, time.Hour),
+				buildRun("failed", corev1.ConditionFalse, time.Hour),
+				buildRun("old-succeeded", corev1.ConditionTrue, 48*time.Hour),
+			)
+			deleted := []string{}
+			shpclientset.PrependReactor("delete", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+				name := action.(fakekubetesting.DeleteAction).GetName()
+				if name == test.failOn {
+					return true, nil, errors.New("forbidden")
+				}
+				deleted = append(deleted, name)
+				return true, nil, nil
+			})
+			param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+			cmd := deleteCmd().(*DeleteCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Cmd().ParseFlags(test.args)).To(gomega.Succeed())
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			g.Expect(cmd.Complete(param, &ioStreams, []string{})).To(gomega.Succeed())
+			g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+			err := cmd.Run(param, &ioStreams)
+			if test.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(err.Error()).To(gomega.ContainSubstring(test.failOn))
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			g.Expect(deleted).To(gomega.ConsistOf(test.deleted))
+			for _, name := range test.deleted {
+				g.Expect(out.String()).To(gomega.ContainSubstring("BuildRun deleted '" + name + "'"))
+			}
+			if test.skipped {
+				g.Expect(out.String()).To(gomega.ContainSubstring("BuildRun skipped 'running'"))
+			}
+		})
+	}
+}
+
+func TestDeleteValidate(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tests := []struct {
+		args      []string
+		flags     []string
+		expectErr bool
+	}{
+		{args: []string{"name"}},
+		{flags: []string{"--all", "--completed", "--older-than=1h", "--force"}},
+		{expectErr: true},
+		{args: []string{"name"}, flags: []string{"--all"}, expectErr: true},
+		{args: []string{"name"}, flags: []string{"--completed"}, expectErr: true},
+		{flags: []string{"--all", "--older-than=-1h"}, expectErr: true},
+	}
+
+	for _, test := range tests {
+		cmd := deleteCmd().(*DeleteCommand)
+		g.Expect(cmd.Cmd().ParseFlags(test.flags)).To(gomega.Succeed())
+		g.Expect(cmd.Complete(nil, nil, test.args)).To(gomega.Succeed())
+		if test.expectErr {
+			g.Expect(cmd.Validate()).ToNot(gomega.Succeed(), "args %v, flags %v", test.args, test.flags)
+		} else {
+			g.Expect(cmd.Validate()).To(gomega.Succeed(), "args %v, flags %v", test.args, test.flags)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package buildrun
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/util"
+)
+
+// describeEventsLimit maximum number of events shown by describe.
+const describeEventsLimit = 20
+
+// DescribeCommand represents the "buildrun describe" sub-command.
+type DescribeCommand struct {
+	cmd *cobra.Command
+
+	name   string
+	output *flags.OutputOptions // prints the BuildRun object instead of the description
+}
+
+const buildRunDescribeLongDesc = `
+Shows the details of the BuildRun, like "kubectl describe" does: the Build it runs, the effective
+source, output and strategy, the conditions, the TaskRun and pods, and the recent events of the
+BuildRun, its TaskRun and pods. For example:
+
+	$ shp buildrun describe my-app-xyz12
+
+The effective source and strategy are taken from the Build specification the BuildRun has resolved,
+and the output is the BuildRun's own, when informed. With --output the BuildRun object is printed
+instead, i.e. as YAML:
+
+	$ shp buildrun describe my-app-xyz12 -o yaml
+`
+
+func describeCmd() runner.SubCommand {
+	describeCommand := &DescribeCommand{
+		cmd: &cobra.Command{
+			Use:   "describe <name>",
+			Short: "Show the details of a BuildRun",
+			Long:  buildRunDescribeLongDesc,
+			Args:  cobra.ExactArgs(1),
+		},
+	}
+	describeCommand.output = flags.OutputFlags(describeCommand.cmd.Flags())
+	return describeCommand
+}
+
+// Cmd returns cobra command object
+func (c *DescribeCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *DescribeCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *DescribeCommand) Validate() error {
+	return c.output.Validate()
+}
+
+// Run prints the BuildRun details, or the BuildRun object in the output format informed.
+func (c *DescribeCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	ctx := c.cmd.Context()
+	br, err := clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()).Get(ctx, c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if c.output.Enabled() {
+		return c.output.PrintObject(ioStreams.Out, br)
+	}
+
+	// the BuildRun resolved by the controller carries the Build specification, otherwise it's
+	// either embedded or taken from the Build
+	spec := br.Status.BuildSpec
+	if spec == nil {
+		spec = br.Spec.BuildSpec
+	}
+	if spec == nil && br.Spec.BuildRef != nil {
+		if b, err := clientset.ShipwrightV1alpha1().Builds(br.Namespace).Get(ctx, br.Spec.BuildRef.Name, metav1.GetOptions{}); err == nil {
+			spec = &b.Spec
+		}
+	}
+
+	logger := params.Logger(ioStreams.ErrOut)
+	kclientset, err := params.ClientSet()
+	if err != nil {
+		return err
+	}
+	pods, err := kclientset.CoreV1().Pods(br.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", buildv1alpha1.LabelBuildRun, br.Name),
+	})
+	if err != nil {
+		logger.Warning(fmt.Sprintf("unable to list the BuildRun pods: %s", err))
+		pods = &corev1.PodList{}
+	}
+	events, err := util.BuildRunEvents(ctx, kclientset, br, describeEventsLimit)
+	if err != nil {
+		logger.Warning(fmt.Sprintf("unable to list the BuildRun events: %s", err))
+	}
+
+	if err = describeBuildRun(ioStreams.Out, br, spec, pods.Items); err != nil {
+		return err
+	}
+	return describeEvents(ioStreams.Out, events)
+}
+
+// describeBuildRun writes the BuildRun details, the spec is the effective Build specification, nil
+// when unknown.
+func describeBuildRun(out io.Writer, br *buildv1alpha1.BuildRun, spec *buildv1alpha1.BuildSpec, pods []corev1.Pod) error {
+	writer := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(writer, "Name:\t%s\n", br.Name)
+	fmt.Fprintf(writer, "Namespace:\t%s\n", br.Namespace)
+	fmt.Fprintf(writer, "Labels:\t%s\n", describeMap(br.Labels))
+	fmt.Fprintf(writer, "Annotations:\t%s\n", describeMap(br.Annotations))
+	fmt.Fprintf(writer, "Created:\t%s\n", describeAge(&br.CreationTimestamp))
+	switch {
+	case br.Spec.BuildRef != nil:
+		fmt.Fprintf(writer, "Build:\t%s\n", br.Spec.BuildRef.Name)
+	case br.Spec.BuildSpec != nil:
+		fmt.Fprintf(writer, "Build:\t<embedded>\n")
+	}
+
+	source := buildv1alpha1.Source{}
+	strategy := "<unknown>"
+	var output *buildv1alpha1.Image
+	if spec != nil {
+		source = spec.Source
+		strategy = spec.Strategy.Name
+		if spec.Strategy.Kind != nil {
+			strategy = fmt.Sprintf("%s/%s", *spec.Strategy.Kind, spec.Strategy.Name)
+		}
+		output = &spec.Output
+	}
+	if br.Spec.Output != nil {
+		output = br.Spec.Output
+	}
+	fmt.Fprintf(writer, "Strategy:\t%s\n", strategy)
+	fmt.Fprintf(writer, "Source:\t\n")
+	fmt.Fprintf(writer, "  URL:\t%s\n", describeString(source.URL))
+	fmt.Fprintf(writer, "  Revision:\t%s\n", describeString(source.Revision))
+	fmt.Fprintf(writer, "  Context Dir:\t%s\n", describeString(source.ContextDir))
+	if source.BundleContainer != nil {
+		fmt.Fprintf(writer, "  Bundle Image:\t%s\n", source.BundleContainer.Image)
+	}
+	for _, result := range br.Status.Sources {
+		if result.Git != nil && result.Git.CommitSha != "" {
+			fmt.Fprintf(writer, "  Commit:\t%s\n", result.Git.CommitSha)
+		}
+		if result.Bundle != nil && result.Bundle.Digest != "" {
+			fmt.Fprintf(writer, "  Bundle Digest:\t%s\n", result.Bundle.Digest)
+		}
+	}
+	fmt.Fprintf(writer, "Output:\t\n")
+	if output != nil {
+		fmt.Fprintf(writer, "  Image:\t%s\n", output.Image)
+	} else {
+		fmt.Fprintf(writer, "  Image:\t<unknown>\n")
+	}
+	if br.Status.Output != nil && br.Status.Output.Digest != "" {
+		fmt.Fprintf(writer, "  Digest:\t%s\n", br.Status.Output.Digest)
+	}
+
+	fmt.Fprintf(writer, "Start Time:\t%s\n", formatTime(br.Status.StartTime))
+	fmt.Fprintf(writer, "Completion Time:\t%s\n", formatTime(br.Status.CompletionTime))
+	fmt.Fprintf(writer, "Duration:\t%s\n", buildRunDuration(br))
+	fmt.Fprintf(writer, "TaskRun:\t%s\n", describeString(br.Status.LatestTaskRunRef))
+	podNames := []string{}
+	for _, pod := range pods {
+		podNames = append(podNames, fmt.Sprintf("%s (%s)", pod.Name, pod.Status.Phase))
+	}
+	if len(podNames) == 0 {
+		podNames = append(podNames, "<none>")
+	}
+	fmt.Fprintf(writer, "Pods:\t%s\n", strings.Join(podNames, ", "))
+	if details := br.Status.FailureDetails; details != nil {
+		fmt.Fprintf(writer, "Failure:\t%s: %s\n", details.Reason, strings.TrimSpace(details.Message))
+		if details.Location != nil && details.Location.Container != "" {
+			fmt.Fprintf(writer, "  Location:\t%s/%s\n", details.Location.Pod, details.Location.Container)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "Conditions:")
+	if len(br.Status.Conditions) == 0 {
+		fmt.Fprintln(out, "  <none>")
+		return nil
+	}
+	writer = tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(writer, "  Type\tStatus\tLastTransitionTime\tReason\tMessage")
+	fmt.Fprintln(writer, "  ----\t------\t------------------\t------\t-------")
+	for _, condition := range br.Status.Conditions {
+		fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status,
+			formatTime(&condition.LastTransitionTime), condition.Reason, strings.TrimSpace(condition.Message))
+	}
+	return writer.Flush()
+}
+
+// describeEvents writes the events section, like "kubectl describe" does.
+func describeEvents(out io.Writer, events []corev1.Event) error {
+	if len(events) == 0 {
+		fmt.Fprintln(out, "Events:  <none>")
+		return nil
+	}
+	fmt.Fprintln(out, "Events:")
+	writer := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(writer, "  Type\tReason\tAge\tObject\tMessage")
+	fmt.Fprintln(writer, "  ----\t------\t---\t------\t-------")
+	for i := range events {
+		event := &events[i]
+		fmt.Fprintf(writer, "  %s\t%s\t%s\t%s/%s\t%s\n", event.Type, event.Reason,
+			duration.HumanDuration(time.Since(util.EventTime(event))),
+			strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name, strings.TrimSpace(event.Message))
+	}
+	return writer.Flush()
+}
+
+// describeMap returns the map entries as sorted "key=value" pairs, or a placeholder when empty.
+func describeMap(m map[string]string) string {
+	if len(m) == 0 {
+		return "<none>"
+	}
+	pairs := []string{}
+	for k, v := range m {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// describeString returns the string value, or a placeholder when not set.
+func describeString(s *string) string {
+	if s == nil || *s == "" {
+		return "<none>"
+	}
+	return *s
+}
+
+// describeAge returns the time in RFC3339 format followed by how long ago it was.
+func describeAge(t *metav1.Time) string {
+	if t == nil || t.IsZero() {
+		return "<none>"
+	}
+	return fmt.Sprintf("%s (%s ago)", formatTime(t), duration.HumanDuration(time.Since(t.Time)))
+}
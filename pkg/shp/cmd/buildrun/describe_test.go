@@ -0,0 +1,102 @@
+package buildrun
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestDescribeBuildRun(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	started := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	kind := buildv1alpha1.ClusterBuildStrategyKind
+	br := &buildv1alpha1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "app-xyz12",
+			Labels:    map[string]string{buildv1alpha1.LabelBuild: "app"},
+		},
+		Spec: buildv1alpha1.BuildRunSpec{
+			BuildRef: &buildv1alpha1.BuildRef{Name: "app"},
+			Output:   &buildv1alpha1.Image{Image: "registry/app:override"},
+		},
+		Status: buildv1alpha1.BuildRunStatus{
+			BuildSpec: &buildv1alpha1.BuildSpec{
+				Source:   buildv1alpha1.Source{URL: pointer.String("https://github.com/org/app")},
+				Strategy: buildv1alpha1.Strategy{Name: "buildah", Kind: &kind},
+				Output:   buildv1alpha1.Image{Image: "registry/app:latest"},
+			},
+			Conditions: buildv1alpha1.Conditions{{
+				Type:               buildv1alpha1.Succeeded,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(started.Add(time.Minute)),
+				Reason:             "Failed",
+				Message:            "build step failed",
+			}},
+			LatestTaskRunRef: pointer.String("app-xyz12-taskrun"),
+			StartTime:        &metav1.Time{Time: started},
+			CompletionTime:   &metav1.Time{Time: started.Add(time.Minute)},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "app-xyz12-pod",
+			Labels:    map[string]string{buildv1alpha1.LabelBuildRun: br.Name},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodFailed},
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "app-xyz12-pod.scheduled"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: pod.Name},
+		Type:           corev1.EventTypeNormal,
+		Reason:         "Scheduled",
+		Message:        "Successfully assigned",
+		LastTimestamp:  metav1.Now(),
+	}
+	param := params.NewParamsForTest(fake.NewSimpleClientset(pod, event), shpfake.NewSimpleClientset(br), nil,
+		metav1.NamespaceDefault, nil, nil)
+
+	run := func(args ...string) string {
+		cmd := describeCmd().(*DescribeCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().ParseFlags(args)).To(gomega.Succeed())
+		g.Expect(cmd.Complete(param, nil, []string{br.Name})).To(gomega.Succeed())
+		g.Expect(cmd.Validate()).To(gomega.Succeed())
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+		return out.String()
+	}
+
+	out := run()
+	for _, line := range []string{
+		"Name:             app-xyz12\n",
+		"Labels:           build.shipwright.io/name=app\n",
+		"Build:            app\n",
+		"Strategy:         ClusterBuildStrategy/buildah\n",
+		"  URL:            https://github.com/org/app\n",
+		"  Image:          registry/app:override\n",
+		"Duration:         60s\n",
+		"TaskRun:          app-xyz12-taskrun\n",
+		"Pods:             app-xyz12-pod (Failed)\n",
+		"  Succeeded  False   2023-01-01T10:01:00Z  Failed  build step failed\n",
+		"  Normal  Scheduled  0s   pod/app-xyz12-pod  Successfully assigned\n",
+	} {
+		g.Expect(out).To(gomega.ContainSubstring(line))
+	}
+
+	g.Expect(run("-o", "yaml")).To(gomega.ContainSubstring("kind: BuildRun\n"))
+}
@@ -1,40 +1,102 @@
 package buildrun
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"sort"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	buildclientv1alpha1 "github.com/shipwright-io/build/pkg/client/clientset/versioned/typed/build/v1alpha1"
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/util"
 )
 
 // ListCommand contains data input from user for list sub-command
 type ListCommand struct {
 	cmd *cobra.Command
 
-	noHeader bool
+	noHeader      bool
+	allNamespaces bool     // list BuildRuns across all namespaces
+	watch         bool     // flag to watch for changes after listing
+	selector      string   // label selector to filter BuildRuns
+	fieldSelector string   // field selector to filter BuildRuns
+	buildName     string   // only list BuildRuns of the informed Build
+	statuses      []string // only list BuildRuns in one of the informed statuses
+	sort          *flags.SortOptions
+	page          *flags.PageOptions
+	output        *flags.OutputOptions
+	color         bool // colored status output
 }
 
+const buildRunListLongDesc = `
+Lists the BuildRuns in the current namespace, or across all namespaces with --all-namespaces.
+
+In large namespaces, use --limit to list a page of BuildRuns at a time, the token to list the next
+page is printed on stderr and informed with --continue. The label and field selectors, including
+--build, are applied before the limit, and the sorting only applies to the BuildRuns of the page:
+
+	$ shp buildrun list --build my-app --limit 50
+	$ shp buildrun list --build my-app --limit 50 --continue "..."
+
+Use --status to only list the BuildRuns in the informed statuses, comma-separated, which are
+filtered after listing, thus a page may hold fewer BuildRuns than the limit:
+
+	$ shp buildrun list --build my-app --status failed,cancelled
+
+Use --output custom-columns to choose the columns shown, like kubectl, i.e.:
+
+	$ shp buildrun list -o custom-columns=NAME:.metadata.name,REASON:.status.conditions[0].reason
+`
+
+// statusPending, statusRunning, statusSucceeded, statusFailed and statusCancelled the BuildRun
+// statuses accepted by the status flag.
+const (
+	statusPending   = "pending"
+	statusRunning   = "running"
+	statusSucceeded = "succeeded"
+	statusFailed    = "failed"
+	statusCancelled = "cancelled"
+)
+
+// buildRunStatuses the BuildRun statuses accepted by the status flag.
+var buildRunStatuses = []string{statusRunning, statusSucceeded, statusFailed, statusPending, statusCancelled}
+
 func listCmd() runner.SubCommand {
 	listCmd := &ListCommand{
 		cmd: &cobra.Command{
 			Use:   "list [flags]",
 			Short: "List Builds",
+			Long:  buildRunListLongDesc,
 		},
 	}
 
 	listCmd.cmd.Flags().BoolVar(&listCmd.noHeader, "no-header", false, "Do not show columns header in list output")
+	listCmd.cmd.Flags().BoolVarP(&listCmd.watch, "watch", "w", false, "After listing, watch for BuildRun changes until interrupted")
+	listCmd.cmd.Flags().StringVarP(&listCmd.selector, "selector", "l", "", "Label selector to filter BuildRuns, e.g. -l key1=value1,key2=value2")
+	listCmd.cmd.Flags().StringVar(&listCmd.buildName, "build", "", "Only list BuildRuns of the given Build")
+	listCmd.cmd.Flags().StringSliceVar(&listCmd.statuses, "status", []string{}, fmt.Sprintf(
+		"Only list BuildRuns in the given statuses, comma-separated, out of: %s", strings.Join(buildRunStatuses, ", ")))
+	flags.AllNamespacesFlags(listCmd.cmd.Flags(), &listCmd.allNamespaces)
+	flags.FieldSelectorFlags(listCmd.cmd.Flags(), &listCmd.fieldSelector)
+	listCmd.sort = flags.SortFlags(listCmd.cmd.Flags(), "name", "creation", "duration", "status")
+	listCmd.page = flags.PageFlags(listCmd.cmd.Flags())
+	listCmd.output = flags.OutputFlags(listCmd.cmd.Flags())
 
 	return listCmd
 }
@@ -46,47 +108,108 @@ func (c *ListCommand) Cmd() *cobra.Command {
 
 // Complete fills in data provided by user
 func (c *ListCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, _ []string) error {
+	c.output.NoHeaders = c.noHeader
 	return nil
 }
 
 // Validate validates data input by user
 func (c *ListCommand) Validate() error {
-	return nil
+	if c.watch && c.output.Enabled() {
+		return fmt.Errorf("--%s is not supported together with --watch", flags.OutputFlag)
+	}
+	if c.watch && c.page.Enabled() {
+		return fmt.Errorf("--%s and --%s are not supported together with --watch", flags.LimitFlag, flags.ContinueFlag)
+	}
+	if err := c.page.Validate(); err != nil {
+		return err
+	}
+	for _, status := range c.statuses {
+		if !sets.NewString(buildRunStatuses...).Has(status) {
+			return fmt.Errorf("invalid --status %q, must be one of: %s", status, strings.Join(buildRunStatuses, ", "))
+		}
+	}
+	if err := flags.ValidateAllNamespaces(c.cmd.Flags(), c.allNamespaces); err != nil {
+		return err
+	}
+	if err := c.sort.Validate(); err != nil {
+		return err
+	}
+	return c.output.Validate()
+}
+
+// listOptions assembles the label selector out of the selector and build flags, and the field
+// selector.
+func (c *ListCommand) listOptions() metav1.ListOptions {
+	selectors := []string{}
+	if c.selector != "" {
+		selectors = append(selectors, c.selector)
+	}
+	if c.buildName != "" {
+		selectors = append(selectors, fmt.Sprintf("%s=%s", buildv1alpha1.LabelBuild, c.buildName))
+	}
+	return metav1.ListOptions{
+		LabelSelector: strings.Join(selectors, ","),
+		FieldSelector: c.fieldSelector,
+	}
 }
 
 // Run executes list sub-command logic
 func (c *ListCommand) Run(params *params.Params, io *genericclioptions.IOStreams) error {
-	// TODO: Support multiple output formats here, not only tabwriter
-	//       find out more in kubectl libraries and use them
-
-	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 2, '\t', 0)
-	columnNames := "NAME\tSTATUS\tAGE"
-	columnTemplate := "%s\t%s\t%s\n"
+	writer := tabwriter.NewWriter(io.Out, 0, 8, 2, '\t', 0)
+	c.color = params.Color(io.Out)
+	columnNames := "NAME\tSTATUS\tDURATION\tAGE"
+	if c.allNamespaces {
+		columnNames = "NAMESPACE\t" + columnNames
+	}
+	if c.watch {
+		columnNames = "EVENT\t" + columnNames
+	}
 
 	clientset, err := params.ShipwrightClientSet()
 	if err != nil {
 		return err
 	}
 
-	k8sclient, err := params.ClientSet()
-	if err != nil {
-		return fmt.Errorf("failed to get k8s client: %w", err)
-	}
-	_, err = k8sclient.CoreV1().Namespaces().Get(c.cmd.Context(), params.Namespace(), metav1.GetOptions{})
-	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			fmt.Fprintf(io.Out, "Namespace '%s' not found. Please ensure that the namespace exists and try again.\n", params.Namespace())
-			return nil
+	namespace := metav1.NamespaceAll
+	if !c.allNamespaces {
+		namespace = params.Namespace()
+		k8sclient, err := params.ClientSet()
+		if err != nil {
+			return fmt.Errorf("failed to get k8s client: %w", err)
+		}
+		_, err = k8sclient.CoreV1().Namespaces().Get(c.cmd.Context(), namespace, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				fmt.Fprintf(io.Out, "Namespace '%s' not found. Please ensure that the namespace exists and try again.\n", namespace)
+				return nil
+			}
+			return err
 		}
-		return err
 	}
 
+	buildRunClient := clientset.ShipwrightV1alpha1().BuildRuns(namespace)
 	var brs *buildv1alpha1.BuildRunList
-	if brs, err = clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()).List(c.cmd.Context(), metav1.ListOptions{}); err != nil {
-		return err
+	listOpts := c.listOptions()
+	c.page.ApplyTo(&listOpts)
+	if brs, err = buildRunClient.List(c.cmd.Context(), listOpts); err != nil {
+		return c.page.Error(util.FieldSelectorError(err, c.fieldSelector))
+	}
+	brs.Items = c.filterBuildRuns(brs.Items)
+	c.sortBuildRuns(brs.Items)
+	// telling how to list the next page after the list is printed
+	if msg := c.page.NextPage(brs); msg != "" {
+		defer params.Logger(io.ErrOut).Info(msg)
+	}
+
+	if c.output.Enabled() {
+		return c.output.PrintObject(io.Out, brs)
 	}
-	if len(brs.Items) == 0 {
-		fmt.Fprintf(io.Out, "No buildruns found in namespace '%s'. Please create a buildrun or verify the namespace.\n", params.Namespace())
+	if len(brs.Items) == 0 && !c.watch {
+		if c.allNamespaces {
+			fmt.Fprintln(io.Out, "No buildruns found in any namespace. Please create a buildrun.")
+			return nil
+		}
+		fmt.Fprintf(io.Out, "No buildruns found in namespace '%s'. Please create a buildrun or verify the namespace.\n", namespace)
 		return nil
 	}
 
@@ -94,19 +217,214 @@ func (c *ListCommand) Run(params *params.Params, io *genericclioptions.IOStreams
 		fmt.Fprintln(writer, columnNames)
 	}
 
-	for _, br := range brs.Items {
-		name := br.Name
-		status := string(metav1.ConditionUnknown)
-		for _, condition := range br.Status.Conditions {
-			if condition.Type == buildv1alpha1.Succeeded {
-				status = condition.Reason
-				break
+	for i := range brs.Items {
+		c.printBuildRun(writer, watch.Added, &brs.Items[i])
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+
+	if !c.watch {
+		return nil
+	}
+
+	// watching until the user interrupts the command, which cancels the context, the watch is not
+	// bound to the request timeout
+	watchClientset, err := params.WatchShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	watchClient := watchClientset.ShipwrightV1alpha1().BuildRuns(namespace)
+	return c.watchBuildRuns(c.cmd.Context(), watchClient, writer, brs.ResourceVersion)
+}
+
+// printBuildRun writes the BuildRun row, when watching the event type is added as first column,
+// followed by the namespace when listing across all namespaces.
+func (c *ListCommand) printBuildRun(writer *tabwriter.Writer, eventType watch.EventType, br *buildv1alpha1.BuildRun) {
+	age := duration.ShortHumanDuration(time.Since((br.ObjectMeta.CreationTimestamp).Time))
+	status := corev1.ConditionUnknown
+	if condition := br.Status.GetCondition(buildv1alpha1.Succeeded); condition != nil {
+		status = condition.Status
+	}
+	statusText := util.ColorStatus(buildRunStatus(br), status, c.color)
+	if c.watch {
+		fmt.Fprintf(writer, "%s\t", eventType)
+	}
+	if c.allNamespaces {
+		fmt.Fprintf(writer, "%s\t", br.Namespace)
+	}
+	fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", br.Name, statusText, buildRunDuration(br), age)
+}
+
+// filterBuildRuns returns the BuildRuns in one of the informed statuses, or all of them when the
+// status flag is not informed.
+func (c *ListCommand) filterBuildRuns(brs []buildv1alpha1.BuildRun) []buildv1alpha1.BuildRun {
+	if len(c.statuses) == 0 {
+		return brs
+	}
+	filtered := []buildv1alpha1.BuildRun{}
+	for i := range brs {
+		if c.matchesStatus(&brs[i]) {
+			filtered = append(filtered, brs[i])
+		}
+	}
+	return filtered
+}
+
+// matchesStatus checks whether the BuildRun is in one of the informed statuses, any BuildRun matches
+// when the status flag is not informed.
+func (c *ListCommand) matchesStatus(br *buildv1alpha1.BuildRun) bool {
+	return len(c.statuses) == 0 || sets.NewString(c.statuses...).Has(buildRunPhase(br))
+}
+
+// sortBuildRuns sorts the BuildRuns according to the sort flags, when informed. Pending BuildRuns
+// are considered to have the shortest duration.
+func (c *ListCommand) sortBuildRuns(brs []buildv1alpha1.BuildRun) {
+	elapsed := func(br *buildv1alpha1.BuildRun) time.Duration {
+		if d, started := buildRunElapsed(br); started {
+			return d
+		}
+		return -1
+	}
+
+	less := c.sort.Less(map[string]func(i, j int) bool{
+		"name": func(i, j int) bool {
+			return brs[i].Name < brs[j].Name
+		},
+		"creation": func(i, j int) bool {
+			return brs[i].CreationTimestamp.Before(&brs[j].CreationTimestamp)
+		},
+		"duration": func(i, j int) bool {
+			return elapsed(&brs[i]) < elapsed(&brs[j])
+		},
+		"status": func(i, j int) bool {
+			return buildRunStatus(&brs[i]) < buildRunStatus(&brs[j])
+		},
+	})
+	if less != nil {
+		sort.SliceStable(brs, less)
+	}
+}
+
+// watchBuildRuns prints the BuildRun events as they arrive, starting from the informed resource
+// version. The watch is established again when the API server closes it, or when the resource
+// version expired, in which case the BuildRuns are listed again to obtain a recent version.
+func (c *ListCommand) watchBuildRuns(
+	ctx context.Context,
+	client buildclientv1alpha1.BuildRunInterface,
+	writer *tabwriter.Writer,
+	resourceVersion string,
+) error {
+	for {
+		listOpts := c.listOptions()
+		listOpts.ResourceVersion = resourceVersion
+		w, err := client.Watch(ctx, listOpts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		expired := false
+	events:
+		for {
+			select {
+			case <-ctx.Done():
+				w.Stop()
+				return nil
+
+			case event, ok := <-w.ResultChan():
+				if !ok {
+					break events
+				}
+				switch event.Type {
+				case watch.Added, watch.Modified, watch.Deleted:
+					br, ok := event.Object.(*buildv1alpha1.BuildRun)
+					if !ok {
+						continue
+					}
+					resourceVersion = br.ResourceVersion
+					if !c.matchesStatus(br) {
+						continue
+					}
+					c.printBuildRun(writer, event.Type, br)
+					if err = writer.Flush(); err != nil {
+						w.Stop()
+						return err
+					}
+				case watch.Error:
+					w.Stop()
+					err = k8serrors.FromObject(event.Object)
+					if !k8serrors.IsResourceExpired(err) && !k8serrors.IsGone(err) {
+						return err
+					}
+					expired = true
+					break events
+				}
 			}
 		}
-		age := duration.ShortHumanDuration(time.Since((br.ObjectMeta.CreationTimestamp).Time))
 
-		fmt.Fprintf(writer, columnTemplate, name, status, age)
+		if expired {
+			brs, err := client.List(ctx, c.listOptions())
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+			resourceVersion = brs.ResourceVersion
+		}
 	}
+}
 
-	return writer.Flush()
+// buildRunStatus returns the reason of the BuildRun succeeded condition, or unknown.
+func buildRunStatus(br *buildv1alpha1.BuildRun) string {
+	for _, condition := range br.Status.Conditions {
+		if condition.Type == buildv1alpha1.Succeeded {
+			return condition.Reason
+		}
+	}
+	return string(metav1.ConditionUnknown)
+}
+
+// buildRunPhase classifies the BuildRun in one of the statuses accepted by the status flag, based
+// on the succeeded condition, the cancellation request, and whether it has started.
+func buildRunPhase(br *buildv1alpha1.BuildRun) string {
+	condition := br.Status.GetCondition(buildv1alpha1.Succeeded)
+	switch {
+	case br.IsCanceled() || (condition != nil && condition.Reason == buildv1alpha1.BuildRunStateCancel):
+		return statusCancelled
+	case condition != nil && condition.Status == corev1.ConditionTrue:
+		return statusSucceeded
+	case condition != nil && condition.Status == corev1.ConditionFalse:
+		return statusFailed
+	case br.Status.StartTime == nil:
+		return statusPending
+	default:
+		return statusRunning
+	}
+}
+
+// buildRunElapsed returns the time the BuildRun took to complete, or the time elapsed so far when
+// it's still running. Returns false when the BuildRun has not started yet.
+func buildRunElapsed(br *buildv1alpha1.BuildRun) (time.Duration, bool) {
+	if br.Status.StartTime == nil {
+		return 0, false
+	}
+	end := time.Now()
+	if br.Status.CompletionTime != nil {
+		end = br.Status.CompletionTime.Time
+	}
+	return end.Sub(br.Status.StartTime.Time), true
+}
+
+// buildRunDuration returns the BuildRun elapsed time in human readable form, or pending when the
+// BuildRun has not started yet.
+func buildRunDuration(br *buildv1alpha1.BuildRun) string {
+	d, started := buildRunElapsed(br)
+	if !started {
+		return "<pending>"
+	}
+	return duration.HumanDuration(d)
 }
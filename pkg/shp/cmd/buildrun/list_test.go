@@ -0,0 +1,401 @@
+package buildrun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	fakekubetesting "k8s.io/client-go/testing"
+
+	"github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func newBuildRun(name, build, reason string) *v1alpha1.BuildRun {
+	return &v1alpha1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      name,
+			Labels:    map[string]string{v1alpha1.LabelBuild: build},
+		},
+		Status: v1alpha1.BuildRunStatus{
+			Conditions: v1alpha1.Conditions{{
+				Type:   v1alpha1.Succeeded,
+				Status: corev1.ConditionUnknown,
+				Reason: reason,
+			}},
+		},
+	}
+}
+
+func TestListBuildRuns(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}}
+	shpclientset := shpfake.NewSimpleClientset(
+		newBuildRun("a-1", "a", "Running"),
+		newBuildRun("b-1", "b", "Pending"),
+	)
+	param := params.NewParamsForTest(fake.NewSimpleClientset(namespace), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := listCmd().(*ListCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set("build", "a")).To(gomega.Succeed())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+
+	g.Expect(out.String()).To(gomega.ContainSubstring("NAME"))
+	g.Expect(out.String()).To(gomega.ContainSubstring("a-1"))
+	g.Expect(out.String()).To(gomega.ContainSubstring("Running"))
+	g.Expect(out.String()).ToNot(gomega.ContainSubstring("b-1"))
+}
+
+func TestBuildRunDuration(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	br := newBuildRun("a-1", "a", "Pending")
+	g.Expect(buildRunDuration(br)).To(gomega.Equal("<pending>"))
+
+	start := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	br.Status.StartTime = &start
+	g.Expect(buildRunDuration(br)).To(gomega.Equal("5m"))
+
+	completion := metav1.NewTime(start.Add(150 * time.Second))
+	br.Status.CompletionTime = &completion
+	g.Expect(buildRunDuration(br)).To(gomega.Equal("2m30s"))
+}
+
+func TestListBuildRunsSort(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	buildRun := func(name, reason string, created, elapsed time.Duration) *v1alpha1.BuildRun {
+		br := newBuildRun(name, "a", reason)
+		now := time.Now()
+		br.CreationTimestamp = metav1.NewTime(now.Add(-created))
+		if elapsed > 0 {
+			start := metav1.NewTime(now.Add(-elapsed))
+			br.Status.StartTime = &start
+		}
+		return br
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}}
+	shpclientset := shpfake.NewSimpleClientset(
+		buildRun("b", "Running", 3*time.Hour, time.Minute),
+		buildRun("c", "Pending", time.Hour, 0),
+		buildRun("a", "Succeeded", 2*time.Hour, time.Hour),
+	)
+	param := params.NewParamsForTest(fake.NewSimpleClientset(namespace), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	tests := []struct {
+		sortBy    string
+		sortOrder string
+		expected  []string
+	}{
+		{sortBy: "name", expected: []string{"a", "b", "c"}},
+		{sortBy: "name", sortOrder: "desc", expected: []string{"c", "b", "a"}},
+		{sortBy: "creation", expected: []string{"b", "a", "c"}},
+		{sortBy: "duration", expected: []string{"c", "b", "a"}},
+		{sortBy: "status", sortOrder: "desc", expected: []string{"a", "b", "c"}},
+	}
+
+	for _, test := range tests {
+		cmd := listCmd().(*ListCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().Flags().Set(flags.SortByFlag, test.sortBy)).To(gomega.Succeed())
+		if test.sortOrder != "" {
+			g.Expect(cmd.Cmd().Flags().Set(flags.SortOrderFlag, test.sortOrder)).To(gomega.Succeed())
+		}
+		g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+
+		names := []string{}
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n")[1:] {
+			names = append(names, strings.Fields(line)[0])
+		}
+		g.Expect(names).To(gomega.Equal(test.expected), "sort by %s %s", test.sortBy, test.sortOrder)
+	}
+
+	cmd := listCmd().(*ListCommand)
+	g.Expect(cmd.Cmd().Flags().Set(flags.SortByFlag, "output")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).ToNot(gomega.Succeed())
+}
+
+func TestListBuildRunsWatch(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}}
+	shpclientset := shpfake.NewSimpleClientset(newBuildRun("a-1", "a", "Pending"))
+
+	// the first watch expires, the command is expected to list again and watch once more
+	watchers := []*watch.FakeWatcher{watch.NewFake(), watch.NewFake()}
+	watches := make(chan *watch.FakeWatcher, len(watchers))
+	shpclientset.PrependWatchReactor("buildruns", func(action fakekubetesting.Action) (bool, watch.Interface, error) {
+		g.Expect(action.(fakekubetesting.WatchAction).GetWatchRestrictions().Labels.String()).
+			To(gomega.Equal(v1alpha1.LabelBuild + "=a"))
+		w := watchers[0]
+		watchers = watchers[1:]
+		watches <- w
+		return true, w, nil
+	})
+	param := params.NewParamsForTest(fake.NewSimpleClientset(namespace), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := listCmd().(*ListCommand)
+	cmd.Cmd().SetContext(ctx)
+	g.Expect(cmd.Cmd().Flags().Set("watch", "true")).To(gomega.Succeed())
+	g.Expect(cmd.Cmd().Flags().Set("build", "a")).To(gomega.Succeed())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	done := make(chan error)
+	go func() {
+		done <- cmd.Run(param, &ioStreams)
+	}()
+
+	w := <-watches
+	w.Modify(newBuildRun("a-1", "a", "Running"))
+	w.Error(&k8serrors.NewResourceExpired("too old resource version").ErrStatus)
+
+	w = <-watches
+	w.Add(newBuildRun("a-2", "a", "Pending"))
+	w.Delete(newBuildRun("a-1", "a", "Succeeded"))
+	cancel()
+	g.Expect(<-done).To(gomega.Succeed())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	g.Expect(lines).To(gomega.HaveLen(5))
+	g.Expect(strings.Fields(lines[0])).To(gomega.Equal([]string{"EVENT", "NAME", "STATUS", "DURATION", "AGE"}))
+	g.Expect(strings.Fields(lines[1])[:3]).To(gomega.Equal([]string{"ADDED", "a-1", "Pending"}))
+	g.Expect(strings.Fields(lines[2])[:3]).To(gomega.Equal([]string{"MODIFIED", "a-1", "Running"}))
+	g.Expect(strings.Fields(lines[3])[:3]).To(gomega.Equal([]string{"ADDED", "a-2", "Pending"}))
+	g.Expect(strings.Fields(lines[4])[:3]).To(gomega.Equal([]string{"DELETED", "a-1", "Succeeded"}))
+}
+
+func TestListBuildRunsFieldSelector(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}}
+	shpclientset := shpfake.NewSimpleClientset()
+	shpclientset.PrependReactor("list", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		fieldSelector := action.(fakekubetesting.ListAction).GetListRestrictions().Fields.String()
+		if fieldSelector != "metadata.name=a-1" {
+			return true, nil, k8serrors.NewBadRequest(fmt.Sprintf("Unable to find %q field label not supported: %s", "buildruns", fieldSelector))
+		}
+		return true, &v1alpha1.BuildRunList{Items: []v1alpha1.BuildRun{*newBuildRun("a-1", "a", "Running")}}, nil
+	})
+	param := params.NewParamsForTest(fake.NewSimpleClientset(namespace), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := listCmd().(*ListCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set(flags.FieldSelectorFlag, "metadata.name=a-1")).To(gomega.Succeed())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.ContainSubstring("a-1"))
+
+	g.Expect(cmd.Cmd().Flags().Set(flags.FieldSelectorFlag, "status.reason=Running")).To(gomega.Succeed())
+	err := cmd.Run(param, &ioStreams)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring("metadata.name, metadata.namespace"))
+}
+
+func TestListBuildRunsOutput(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}}
+	br := newBuildRun("a-1", "a", "Running")
+	br.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "shipwright-build-controller"}}
+	param := params.NewParamsForTest(fake.NewSimpleClientset(namespace), shpfake.NewSimpleClientset(br), nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := listCmd().(*ListCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputFlag, "yaml")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.ContainSubstring("kind: BuildRun\n"))
+	g.Expect(out.String()).To(gomega.ContainSubstring("name: a-1"))
+	g.Expect(out.String()).ToNot(gomega.ContainSubstring("managedFields"))
+
+	g.Expect(cmd.Cmd().Flags().Set(flags.ShowManagedFieldsFlag, "true")).To(gomega.Succeed())
+	out.Reset()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.ContainSubstring("manager: shipwright-build-controller"))
+
+	g.Expect(cmd.Cmd().Flags().Set("watch", "true")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).ToNot(gomega.Succeed())
+}
+
+func TestListBuildRunsAllNamespaces(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	other := newBuildRun("b-1", "b", "Pending")
+	other.Namespace = "other"
+	shpclientset := shpfake.NewSimpleClientset(newBuildRun("a-1", "a", "Running"), other)
+	// the namespaces are not looked up when listing across all of them
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := listCmd().(*ListCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set(flags.AllNamespacesFlag, "true")).To(gomega.Succeed())
+	g.Expect(cmd.Cmd().Flags().Set(flags.SortByFlag, "name")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	g.Expect(lines).To(gomega.HaveLen(3))
+	g.Expect(strings.Fields(lines[0])).To(gomega.Equal([]string{"NAMESPACE", "NAME", "STATUS", "DURATION", "AGE"}))
+	g.Expect(strings.Fields(lines[1])[:3]).To(gomega.Equal([]string{metav1.NamespaceDefault, "a-1", "Running"}))
+	g.Expect(strings.Fields(lines[2])[:3]).To(gomega.Equal([]string{"other", "b-1", "Pending"}))
+
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputFlag, "json")).To(gomega.Succeed())
+	out.Reset()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.ContainSubstring(`"namespace": "default"`))
+	g.Expect(out.String()).To(gomega.ContainSubstring(`"namespace": "other"`))
+}
+
+func TestListBuildRunsPage(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}}
+	shpclientset := shpfake.NewSimpleClientset()
+	continued := false
+	shpclientset.PrependReactor("list", "buildruns", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+		if continued {
+			return true, nil, k8serrors.NewResourceExpired("too old resource version")
+		}
+		list := &v1alpha1.BuildRunList{Items: []v1alpha1.BuildRun{*newBuildRun("a-1", "a", "Running")}}
+		list.Continue = "token"
+		remaining := int64(3)
+		list.RemainingItemCount = &remaining
+		return true, list, nil
+	})
+	param := params.NewParamsForTest(fake.NewSimpleClientset(namespace), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := listCmd().(*ListCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set(flags.LimitFlag, "1")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.ContainSubstring("a-1"))
+	g.Expect(errOut.String()).To(gomega.Equal("3 more resources are available, use --continue \"token\" to list the next page\n"))
+
+	continued = true
+	g.Expect(cmd.Cmd().Flags().Set(flags.ContinueFlag, "token")).To(gomega.Succeed())
+	err := cmd.Run(param, &ioStreams)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.HavePrefix("the --continue token has expired"))
+
+	g.Expect(cmd.Cmd().Flags().Set("watch", "true")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError("--limit and --continue are not supported together with --watch"))
+}
+
+func TestListBuildRunsStatus(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	buildRun := func(name, build string, status corev1.ConditionStatus, reason string, started bool) *v1alpha1.BuildRun {
+		br := newBuildRun(name, build, reason)
+		br.Status.Conditions[0].Status = status
+		if started {
+			start := metav1.Now()
+			br.Status.StartTime = &start
+		}
+		return br
+	}
+	canceled := buildRun("e-1", "a", corev1.ConditionUnknown, "Running", true)
+	canceled.Spec.State = v1alpha1.BuildRunRequestedStatePtr(v1alpha1.BuildRunStateCancel)
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}}
+	shpclientset := shpfake.NewSimpleClientset(
+		buildRun("a-1", "a", corev1.ConditionUnknown, "Running", true),
+		buildRun("b-1", "a", corev1.ConditionUnknown, "Pending", false),
+		buildRun("c-1", "a", corev1.ConditionTrue, "Succeeded", true),
+		buildRun("d-1", "a", corev1.ConditionFalse, "Failed", true),
+		buildRun("d-2", "b", corev1.ConditionFalse, "Failed", true),
+		canceled,
+		buildRun("f-1", "a", corev1.ConditionFalse, v1alpha1.BuildRunStateCancel, true),
+	)
+	param := params.NewParamsForTest(fake.NewSimpleClientset(namespace), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	tests := []struct {
+		status   string
+		expected []string
+	}{
+		{status: "running", expected: []string{"a-1"}},
+		{status: "pending", expected: []string{"b-1"}},
+		{status: "succeeded", expected: []string{"c-1"}},
+		{status: "failed", expected: []string{"d-1"}},
+		{status: "cancelled", expected: []string{"e-1", "f-1"}},
+		{status: "failed,cancelled", expected: []string{"d-1", "e-1", "f-1"}},
+	}
+
+	for _, test := range tests {
+		cmd := listCmd().(*ListCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().Flags().Set("status", test.status)).To(gomega.Succeed())
+		g.Expect(cmd.Cmd().Flags().Set("build", "a")).To(gomega.Succeed())
+		g.Expect(cmd.Cmd().Flags().Set(flags.SortByFlag, "name")).To(gomega.Succeed())
+		g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+
+		names := []string{}
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n")[1:] {
+			names = append(names, strings.Fields(line)[0])
+		}
+		g.Expect(names).To(gomega.Equal(test.expected), "status %s", test.status)
+	}
+
+	cmd := listCmd().(*ListCommand)
+	g.Expect(cmd.Cmd().Flags().Set("status", "failed,done")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError(
+		`invalid --status "done", must be one of: running, succeeded, failed, pending, cancelled`))
+}
+
+func TestListBuildRunsCustomColumns(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}}
+	param := params.NewParamsForTest(fake.NewSimpleClientset(namespace),
+		shpfake.NewSimpleClientset(newBuildRun("a-1", "a", "Running")), nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := listCmd().(*ListCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputFlag, "custom-columns=NAME:.metadata.name,REASON:.status.conditions[0].reason")).
+		To(gomega.Succeed())
+	g.Expect(cmd.Cmd().Flags().Set("no-header", "true")).To(gomega.Succeed())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, nil)).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.MatchRegexp(`^a-1\s+Running\n$`))
+
+	// malformed specs fail the validation, before listing
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputFlag, "custom-columns=NAME")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError(gomega.ContainSubstring("expected <header>:<json-path-expr>")))
+}
@@ -3,20 +3,26 @@ package buildrun
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/spf13/cobra"
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/follower"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
 	"github.com/shipwright-io/cli/pkg/shp/util"
 )
@@ -27,20 +33,123 @@ type LogsCommand struct {
 
 	name string
 
-	follow   bool
-	follower *follower.Follower
+	follow        bool
+	quiet         bool
+	reconnectTail int64         // log lines repeated when reconnecting a log stream
+	imageDigest   bool          // print the output image digest after the logs
+	output        string        // output format, digest prints only the output image digest on stdout
+	container     string        // only show the logs of this container
+	pod           string        // only show the logs of this pod, when the BuildRun has several
+	raw           bool          // write the logs exactly as received, without headers and prefixes
+	colorByStep   bool          // colors the container name of the log lines, one color per step
+	watchOnly     bool          // when following, only stream the logs written from now on
+	summary       bool          // print the timing of each step after the logs
+	podTimeout    time.Duration // maximum time waiting for the BuildRun pod to be created
+	failFast      bool          // stop following as soon as the BuildRun fails
+	joinTimeout   time.Duration // maximum time waiting for the log streams once the pod is done
+	follower      *follower.Follower
 }
 
+const (
+	// digestOutput output format printing only the output image reference with digest.
+	digestOutput = "digest"
+	// watchOnlyFlag command-line flag to only stream the logs written from now on.
+	watchOnlyFlag = "watch-only"
+	// podTimeoutFlag command-line flag, maximum time waiting for the BuildRun pod to be created.
+	podTimeoutFlag = "pod-timeout"
+	// defaultPodTimeout default maximum time waiting for the BuildRun pod to be created.
+	defaultPodTimeout = time.Minute
+	// podFlag command-line flag to pick the pod, when the BuildRun has several.
+	podFlag = "pod"
+	// colorByStepFlag command-line flag to color the container name of the log lines per step.
+	colorByStepFlag = "color-by-step"
+	// failFastFlag command-line flag to stop following as soon as the BuildRun fails.
+	failFastFlag = "fail-fast"
+	// joinTimeoutFlag command-line flag, maximum time waiting for the log streams once the pod is done.
+	joinTimeoutFlag = "join-timeout"
+)
+
+const buildRunLogsLongDesc = `
+Shows the logs of the BuildRun pod containers, with --follow the logs are streamed until the
+BuildRun completes or fails.
+
+With --output-image-digest the digest of the image pushed by a successful BuildRun is printed after
+the logs. To capture only the image reference with the digest, use the digest output format, the
+logs are then written to stderr:
+
+	$ IMAGE=$(shp buildrun logs --follow --output digest my-buildrun)
+
+With --raw the logs are written exactly as received from the API server, without the headers and
+the container name prefix, and the CLI messages are written to stderr. A broken log stream is not
+reconnected, the lines repeated on reconnection would be written twice. Use --container to pick a
+single container, the "step-" prefix of the container name is optional:
+
+	$ shp buildrun logs --raw --container build my-buildrun > build.log
+
+To attach to a running BuildRun without the logs written so far, use --watch-only together with
+--follow, only the logs written from now on are streamed until the BuildRun completes or fails.
+
+With --summary the start, end and duration of each step are printed after the logs, up to the step
+which has failed, if any, to spot the steps dominating the build time:
+
+	$ shp buildrun logs --follow --summary my-buildrun
+
+Right after the BuildRun is created its pod may not exist yet, the command then waits up to
+--pod-timeout for the pod, i.e. when running "shp build run" and "shp buildrun logs" in a row. It
+fails right away when the BuildRun does not exist, or has finished without a pod.
+
+Strategies may produce more than one pod for the same BuildRun, the command then lists the pods and
+asks to pick one with --pod:
+
+	$ shp buildrun logs --pod my-buildrun-abcde-pod my-buildrun
+
+To tell apart the steps streaming concurrently, --color-by-step colors the container name of the log
+lines, and the container headers, with a color per step. The same steps get the same colors on every
+run. The colors are only written to terminals, and never with --no-color:
+
+	$ shp buildrun logs --follow --color-by-step my-buildrun
+
+When following, the command waits for the log streams to drain once the BuildRun is done. To exit
+the moment the BuildRun fails instead, use --fail-fast, the BuildRun is watched while the logs are
+streamed, the log lines received so far are written and the command exits with non-zero status:
+
+	$ shp buildrun logs --follow --fail-fast my-buildrun
+
+Sidecars may start after the build steps, and write their last lines once the steps are done. When
+following, the log streams of the containers yet to start are kept open, and the command waits up to
+--join-timeout for all of them to finish once the pod is done, the lines written afterwards are
+discarded:
+
+	$ shp buildrun logs --follow --join-timeout 30s my-buildrun
+
+When the pod is evicted or deleted while the BuildRun goes on, i.e. on node pressure, the command
+waits for the pod replacing it and reattaches the log streams to its containers once it's running.
+With --pod only the given pod is followed, and its failure stops the command.
+`
+
 func logsCmd() runner.SubCommand {
 	cmd := &cobra.Command{
 		Use:   "logs <name>",
 		Short: "See BuildRun log output",
+		Long:  buildRunLogsLongDesc,
 		Args:  cobra.ExactArgs(1),
 	}
 	logCommand := &LogsCommand{
 		cmd: cmd,
 	}
-	cmd.Flags().BoolVarP(&logCommand.follow, "follow", "F", logCommand.follow, "Follow the log of a buildrun until it completes or fails.")
+	cmd.Flags().BoolVarP(&logCommand.follow, "follow", "F", logCommand.follow, "Follow the log of a buildrun until it completes or fails, exiting with a non-zero status when the buildrun fails.")
+	flags.ReconnectTailFlag(cmd.Flags(), &logCommand.reconnectTail)
+	cmd.Flags().BoolVar(&logCommand.imageDigest, "output-image-digest", false, "Print the output image digest and size after the logs of a successful buildrun")
+	cmd.Flags().StringVarP(&logCommand.output, flags.OutputFlag, "o", "", "Output format, either empty for the logs or digest to print only the output image digest, the logs are written to stderr")
+	cmd.Flags().StringVarP(&logCommand.container, "container", "c", "", "Only show the logs of the given container")
+	cmd.Flags().StringVar(&logCommand.pod, podFlag, "", "Only show the logs of the given pod, required when the BuildRun has several")
+	cmd.Flags().BoolVar(&logCommand.raw, "raw", false, "Write the logs exactly as received, without headers and container name prefixes")
+	cmd.Flags().BoolVar(&logCommand.colorByStep, colorByStepFlag, false, "Color the container name of the log lines with a color per step, only on terminals")
+	cmd.Flags().BoolVar(&logCommand.watchOnly, watchOnlyFlag, false, "Together with --follow, only stream the logs written from now on, skipping the previous logs")
+	cmd.Flags().BoolVar(&logCommand.summary, "summary", false, "Print the start, end and duration of each step after the logs")
+	cmd.Flags().BoolVar(&logCommand.failFast, failFastFlag, false, "Together with --follow, exit as soon as the BuildRun fails, without waiting for the log streams to drain")
+	cmd.Flags().DurationVar(&logCommand.podTimeout, podTimeoutFlag, defaultPodTimeout, "Maximum time waiting for the BuildRun pod to be created")
+	cmd.Flags().DurationVar(&logCommand.joinTimeout, joinTimeoutFlag, follower.DefaultJoinTimeout, "Together with --follow, maximum time waiting for the log streams to finish once the pod is done, i.e. for late sidecars")
 	return logCommand
 }
 
@@ -52,6 +161,7 @@ func (c *LogsCommand) Cmd() *cobra.Command {
 // Complete fills in data provided by user
 func (c *LogsCommand) Complete(params *params.Params, ioStreams *genericclioptions.IOStreams, args []string) error {
 	c.name = args[0]
+	c.quiet = params.Quiet()
 	if !c.follow {
 		return nil
 	}
@@ -61,71 +171,395 @@ func (c *LogsCommand) Complete(params *params.Params, ioStreams *genericclioptio
 		Name:      c.name,
 	}
 	var err error
-	c.follower, err = params.NewFollower(c.Cmd().Context(), br, ioStreams)
-	return err
+	if c.follower, err = params.NewFollower(c.Cmd().Context(), br, c.logStreams(ioStreams)); err != nil {
+		return err
+	}
+	c.follower.SetQuiet(c.quiet)
+	c.follower.SetReconnectTail(c.reconnectTail)
+	c.follower.SetRaw(c.raw)
+	c.follower.SetWatchOnly(c.watchOnly)
+	c.follower.SetPod(c.pod)
+	c.follower.SetColorByStep(c.stepColorsEnabled(params, ioStreams))
+	c.follower.SetFailFast(c.failFast)
+	c.follower.SetJoinTimeout(c.joinTimeout)
+	return nil
 }
 
 // Validate validates data input by user
 func (c *LogsCommand) Validate() error {
-	return nil
+	if c.output != "" && c.output != digestOutput {
+		return fmt.Errorf("unsupported output format %q, only %s is supported", c.output, digestOutput)
+	}
+	if c.colorByStep && c.raw {
+		return fmt.Errorf("--%s can not be used together with --raw", colorByStepFlag)
+	}
+	if c.watchOnly && !c.follow {
+		return fmt.Errorf("--%s can only be used together with --follow", watchOnlyFlag)
+	}
+	if c.failFast && !c.follow {
+		return fmt.Errorf("--%s can only be used together with --follow", failFastFlag)
+	}
+	if c.podTimeout <= 0 {
+		return fmt.Errorf("--%s must be positive", podTimeoutFlag)
+	}
+	if c.joinTimeout < 0 {
+		return fmt.Errorf("--%s must not be negative", joinTimeoutFlag)
+	}
+	if c.cmd.Flags().Changed(joinTimeoutFlag) && !c.follow {
+		return fmt.Errorf("--%s can only be used together with --follow", joinTimeoutFlag)
+	}
+	return flags.ValidateReconnectTail(c.reconnectTail)
+}
+
+// stepColorsEnabled tells whether the steps are colored, only when the logs are written to a terminal
+// and the colors are not disabled with --no-color.
+func (c *LogsCommand) stepColorsEnabled(params *params.Params, ioStreams *genericclioptions.IOStreams) bool {
+	return c.colorByStep && params.Color(c.logStreams(ioStreams).Out)
+}
+
+// logStreams returns the streams the logs are written to, when only the digest is printed the
+// logs are moved to stderr.
+func (c *LogsCommand) logStreams(ioStreams *genericclioptions.IOStreams) *genericclioptions.IOStreams {
+	if c.output != digestOutput {
+		return ioStreams
+	}
+	logStreams := *ioStreams
+	logStreams.Out = ioStreams.ErrOut
+	return &logStreams
 }
 
 // Run executes logs sub-command logic
 func (c *LogsCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
-	clientset, err := params.ClientSet()
+	err := c.printLogs(params, c.logStreams(ioStreams))
+	// the summary is printed regardless of the BuildRun outcome, i.e. to tell which step failed
+	if c.summary {
+		if summaryErr := c.printSummary(params, ioStreams); err == nil {
+			err = summaryErr
+		}
+	}
 	if err != nil {
 		return err
 	}
+	if c.imageDigest || c.output == digestOutput {
+		return c.printImageDigest(params, ioStreams)
+	}
+	return nil
+}
 
-	lo := v1.ListOptions{
-		LabelSelector: fmt.Sprintf("%v=%v", buildv1alpha1.LabelBuildRun, c.name),
+// printLogs prints the logs of the BuildRun pod containers, following them when requested.
+func (c *LogsCommand) printLogs(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ClientSet()
+	if err != nil {
+		return err
 	}
 
+	lo := c.podListOptions()
+
 	// first see if pod is already done; if so, even if we have follow == true, just do the normal path;
 	// we don't employ a pod watch here since the buildrun may already be complete before 'shp buildrun logs -F'
 	// is invoked.
 	justGetLogs := false
-	var pods *corev1.PodList
-	err = wait.PollUntilContextTimeout(c.cmd.Context(), 1*time.Second, 10*time.Second, true, func(ctx context.Context) (done bool, err error) {
-		if pods, err = clientset.CoreV1().Pods(params.Namespace()).List(ctx, lo); err != nil {
-			fmt.Fprintf(ioStreams.ErrOut, "error listing Pods for BuildRun %q: %s\n", c.name, err.Error())
-			return false, nil
-		}
-		if len(pods.Items) == 0 {
-			fmt.Fprintf(ioStreams.ErrOut, "no builder pod found for BuildRun %q\n", c.name)
-			return false, nil
-		}
-		return true, nil
-	})
+	pod, err := c.waitForPod(params, ioStreams, clientset, lo)
 	if err != nil {
 		return err
 	}
-	pod := pods.Items[0]
 	phase := pod.Status.Phase
 	if phase == corev1.PodFailed || phase == corev1.PodSucceeded {
 		justGetLogs = true
 	}
 
+	containers := append(pod.Spec.InitContainers, pod.Spec.Containers...)
+	if c.container != "" {
+		container, err := podContainer(pod, c.container)
+		if err != nil {
+			return err
+		}
+		containers = []corev1.Container{container}
+		if c.follow {
+			c.follower.SetContainer(container.Name)
+		}
+	}
+
+	// the pod is done, there are no new logs to watch for
+	if c.watchOnly && justGetLogs {
+		return c.follower.BuildRunError()
+	}
+
 	if !c.follow || justGetLogs {
-		fmt.Fprintf(ioStreams.Out, "Obtaining logs for BuildRun %q\n\n", c.name)
+		if !c.raw {
+			fmt.Fprintf(ioStreams.Out, "Obtaining logs for BuildRun %q\n\n", c.name)
+		}
 
+		var stepColors util.StepColors
+		if c.stepColorsEnabled(params, ioStreams) {
+			names := []string{}
+			for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+				names = append(names, container.Name)
+			}
+			stepColors = util.NewStepColors(names)
+		}
 		var b strings.Builder
-		containers := append(pod.Spec.InitContainers, pod.Spec.Containers...)
 		for _, container := range containers {
-			logs, err := util.GetPodLogs(c.cmd.Context(), clientset, pod, container.Name)
+			logs, err := util.GetPodLogs(c.cmd.Context(), clientset, *pod, container.Name)
 			if err != nil {
 				return err
 			}
 
-			fmt.Fprintf(&b, "*** Pod %q, container %q: ***\n\n", pod.Name, container.Name)
+			if c.raw {
+				b.WriteString(logs)
+				continue
+			}
+			fmt.Fprintf(&b, "%s\n\n", stepColors.Color(container.Name, fmt.Sprintf("*** Pod %q, container %q: ***", pod.Name, container.Name)))
 			fmt.Fprintln(&b, logs)
 		}
 
-		fmt.Fprintln(ioStreams.Out, b.String())
+		if c.raw {
+			fmt.Fprint(ioStreams.Out, b.String())
+		} else {
+			fmt.Fprintln(ioStreams.Out, b.String())
+		}
 
+		// when following, the exit status reflects the BuildRun outcome, even if it's already done
+		if c.follow {
+			return c.follower.BuildRunError()
+		}
 		return nil
 
 	}
-	_, err = c.follower.Start(lo)
-	return err
+	// with several pods the watch is narrowed down to the one picked, otherwise the watch also
+	// observes the pod replacing the current one, i.e. when it's evicted and rescheduled
+	if c.pod != "" {
+		lo.FieldSelector = fmt.Sprintf("metadata.name=%s", pod.Name)
+	}
+	if _, err = c.follower.Start(lo); err != nil {
+		return err
+	}
+	return c.follower.BuildRunError()
+}
+
+// waitForPod waits up to the pod timeout for the BuildRun pod, which is only created a moment after
+// the BuildRun. It fails right away when the BuildRun does not exist, or has finished without a pod.
+func (c *LogsCommand) waitForPod(
+	params *params.Params,
+	ioStreams *genericclioptions.IOStreams,
+	clientset kubernetes.Interface,
+	lo v1.ListOptions,
+) (*corev1.Pod, error) {
+	var pod *corev1.Pod
+	waiting := false
+	err := wait.PollUntilContextTimeout(c.cmd.Context(), time.Second, c.podTimeout, true, func(ctx context.Context) (bool, error) {
+		pods, err := clientset.CoreV1().Pods(params.Namespace()).List(ctx, lo)
+		if err != nil {
+			params.Logger(ioStreams.ErrOut).Warning(fmt.Sprintf("error listing Pods for BuildRun %q: %s", c.name, err.Error()))
+			return false, nil
+		}
+		if len(pods.Items) > 0 {
+			pod, err = c.selectPod(pods.Items)
+			return err == nil, err
+		}
+		if err = c.buildRunWithoutPod(ctx, params); err != nil {
+			return false, err
+		}
+		if !waiting {
+			waiting = true
+			params.Logger(ioStreams.ErrOut).Info(fmt.Sprintf("Waiting for the pod of BuildRun %q to be created...", c.name))
+		}
+		return false, nil
+	})
+	// interrupted by the user, i.e. Ctrl-C, rather than by the pod timeout
+	if ctxErr := c.cmd.Context().Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if wait.Interrupted(err) {
+		return nil, fmt.Errorf("no builder pod found for BuildRun %q within %s", c.name, c.podTimeout)
+	}
+	return pod, err
+}
+
+// podListOptions returns the list options selecting the BuildRun pods.
+func (c *LogsCommand) podListOptions() v1.ListOptions {
+	return v1.ListOptions{
+		LabelSelector: fmt.Sprintf("%v=%v", buildv1alpha1.LabelBuildRun, c.name),
+	}
+}
+
+// selectPod returns the pod informed with --pod, or the only pod of the BuildRun. When the BuildRun
+// has several pods and none is informed, the error lists the pods to pick from.
+func (c *LogsCommand) selectPod(pods []corev1.Pod) (*corev1.Pod, error) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+	})
+	if c.pod == "" && len(pods) == 1 {
+		return &pods[0], nil
+	}
+
+	var b strings.Builder
+	for i := range pods {
+		if pods[i].Name == c.pod {
+			return &pods[i], nil
+		}
+		fmt.Fprintf(&b, "\n  %s (%s)", pods[i].Name, pods[i].Status.Phase)
+	}
+	if c.pod != "" {
+		return nil, fmt.Errorf("pod %q not found for BuildRun %q, must be one of:%s", c.pod, c.name, b.String())
+	}
+	return nil, fmt.Errorf("BuildRun %q has %d pods, pick one with --%s:%s", c.name, len(pods), podFlag, b.String())
+}
+
+// buildRunWithoutPod returns an error when the BuildRun won't ever have a pod, because it does not
+// exist or has finished already. Other errors are ignored, the pod is waited for regardless.
+func (c *LogsCommand) buildRunWithoutPod(ctx context.Context, params *params.Params) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return nil
+	}
+	br, err := clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()).Get(ctx, c.name, v1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+		return fmt.Errorf("BuildRun %q not found", c.name)
+	case err != nil:
+		return nil
+	}
+	if cond := br.Status.GetCondition(buildv1alpha1.Succeeded); cond != nil && cond.Status != corev1.ConditionUnknown {
+		return fmt.Errorf("BuildRun %q has finished without a builder pod, it may have been removed: %s", c.name, cond.Message)
+	}
+	return nil
+}
+
+// printSummary prints the timing of each step of the BuildRun pod, as recorded on the container
+// statuses, up to the first step which has failed. The summary is written together with the logs,
+// or to stderr when the logs are raw.
+func (c *LogsCommand) printSummary(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ClientSet()
+	if err != nil {
+		return err
+	}
+	pods, err := clientset.CoreV1().Pods(params.Namespace()).List(c.cmd.Context(), c.podListOptions())
+	if err != nil {
+		return err
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("no builder pod found for BuildRun %q", c.name)
+	}
+	pod, err := c.selectPod(pods.Items)
+	if err != nil {
+		return err
+	}
+
+	out := c.logStreams(ioStreams).Out
+	if c.raw {
+		out = ioStreams.ErrOut
+	}
+	writer := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(writer, "Steps of BuildRun %q:\n", c.name)
+	fmt.Fprintln(writer, "STEP\tSTARTED\tFINISHED\tDURATION\tRESULT")
+	for _, step := range stepSummary(pod) {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", step.name, formatTime(step.started), formatTime(step.finished),
+			step.duration(), step.result)
+	}
+	return writer.Flush()
+}
+
+// stepTiming contains the timing and result of a single BuildRun step.
+type stepTiming struct {
+	name     string
+	started  *v1.Time
+	finished *v1.Time
+	result   string
+}
+
+// duration returns the time the step took, or has taken so far when still running.
+func (s stepTiming) duration() string {
+	switch {
+	case s.started == nil:
+		return "<none>"
+	case s.finished == nil:
+		return duration.HumanDuration(time.Since(s.started.Time))
+	default:
+		return duration.HumanDuration(s.finished.Sub(s.started.Time))
+	}
+}
+
+// stepSummary returns the timing of the pod steps, in the order they are executed, up to the first
+// step which has failed, the following steps are skipped.
+func stepSummary(pod *corev1.Pod) []stepTiming {
+	statuses := map[string]corev1.ContainerStatus{}
+	for _, status := range pod.Status.ContainerStatuses {
+		statuses[status.Name] = status
+	}
+
+	steps := []stepTiming{}
+	for _, container := range pod.Spec.Containers {
+		step := stepTiming{name: strings.TrimPrefix(container.Name, "step-"), result: "Waiting"}
+		state := statuses[container.Name].State
+		switch {
+		case state.Terminated != nil:
+			step.started = &state.Terminated.StartedAt
+			step.finished = &state.Terminated.FinishedAt
+			step.result = "Succeeded"
+			if state.Terminated.ExitCode != 0 {
+				step.result = fmt.Sprintf("Failed (exit code %d)", state.Terminated.ExitCode)
+			}
+		case state.Running != nil:
+			step.started = &state.Running.StartedAt
+			step.result = "Running"
+		}
+		steps = append(steps, step)
+		if state.Terminated != nil && state.Terminated.ExitCode != 0 {
+			break
+		}
+	}
+	return steps
+}
+
+// podContainer returns the pod container, or init-container, with the informed name, which may be
+// informed without the "step-" prefix.
+func podContainer(pod *corev1.Pod, name string) (corev1.Container, error) {
+	containers := append(pod.Spec.InitContainers, pod.Spec.Containers...)
+	names := make([]string, 0, len(containers))
+	for _, container := range containers {
+		if container.Name == name || container.Name == "step-"+name {
+			return container, nil
+		}
+		names = append(names, container.Name)
+	}
+	return corev1.Container{}, fmt.Errorf("container %q not found in pod %q, must be one of: %s",
+		name, pod.Name, strings.Join(names, ", "))
+}
+
+// printImageDigest prints the output image digest reported by the BuildRun, either only the image
+// reference with the digest, or together with the image size. A missing digest is reported on
+// stderr, and only considered an error when the digest is the requested output.
+func (c *LogsCommand) printImageDigest(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	br, err := clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()).Get(c.cmd.Context(), c.name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if br.Status.Output == nil || br.Status.Output.Digest == "" {
+		err = fmt.Errorf("BuildRun %q does not report the output image digest", c.name)
+		if condition := br.Status.GetCondition(buildv1alpha1.Succeeded); condition == nil || condition.Status != corev1.ConditionTrue {
+			err = fmt.Errorf("BuildRun %q has not succeeded, the output image digest is not available", c.name)
+		}
+		if c.output == digestOutput {
+			return err
+		}
+		params.Logger(ioStreams.ErrOut).Warning(err.Error())
+		return nil
+	}
+
+	ref := util.ImageDigestReference(util.OutputImage(br), br.Status.Output.Digest)
+	if c.output == digestOutput {
+		fmt.Fprintln(ioStreams.Out, ref)
+		return nil
+	}
+	fmt.Fprintf(ioStreams.Out, "Image digest: %s\n", ref)
+	if br.Status.Output.Size > 0 {
+		fmt.Fprintf(ioStreams.Out, "Image size: %d bytes\n", br.Status.Output.Size)
+	}
+	return nil
 }
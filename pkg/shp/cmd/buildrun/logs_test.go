@@ -2,10 +2,16 @@ package buildrun
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
 
 	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/follower"
 	"github.com/shipwright-io/cli/pkg/shp/reactor"
 	kruntime "k8s.io/apimachinery/pkg/runtime"
 	fakekubetesting "k8s.io/client-go/testing"
@@ -19,6 +25,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func TestStreamBuildLogs(t *testing.T) {
@@ -35,7 +42,7 @@ func TestStreamBuildLogs(t *testing.T) {
 		},
 	}
 
-	cmd := LogsCommand{cmd: &cobra.Command{}}
+	cmd := LogsCommand{cmd: &cobra.Command{}, podTimeout: defaultPodTimeout}
 	cmd.name = name
 	// set up context
 	cmd.Cmd().ExecuteC()
@@ -65,6 +72,7 @@ func TestStreamBuildRunFollowLogs(t *testing.T) {
 		cancelled  bool
 		brDeleted  bool
 		podDeleted bool
+		failed     bool
 	}{
 		{
 			name:    "succeeded",
@@ -97,11 +105,13 @@ func TestStreamBuildRunFollowLogs(t *testing.T) {
 			name:       "failed-pod-deleted",
 			phase:      corev1.PodFailed,
 			podDeleted: true,
+			failed:     true,
 			logText:    "Pod \"testpod\" has been deleted.",
 		},
 		{
 			name:    "failed-something-else",
 			phase:   corev1.PodFailed,
+			failed:  true,
 			logText: "BuildRun \"testpod\" has failed.",
 		},
 		{
@@ -115,7 +125,7 @@ func TestStreamBuildRunFollowLogs(t *testing.T) {
 		{
 			name:    "timeout",
 			to:      "1s",
-			logText: reactor.RequestTimeoutMessage,
+			logText: reactor.WatchTimeoutMessage,
 		},
 		{
 			name:     "no pod yet",
@@ -124,7 +134,8 @@ func TestStreamBuildRunFollowLogs(t *testing.T) {
 		},
 	}
 
-	for i, test := range tests {
+	for _, test := range tests {
+		test := test
 		name := "testpod"
 		containerName := "container"
 		pod := &corev1.Pod{
@@ -169,18 +180,25 @@ func TestStreamBuildRunFollowLogs(t *testing.T) {
 		}
 		ccmd := &cobra.Command{}
 		cmd := &LogsCommand{
-			cmd:    ccmd,
-			name:   name,
-			follow: true,
+			cmd:        ccmd,
+			name:       name,
+			follow:     true,
+			podTimeout: defaultPodTimeout,
 		}
 
 		// set up context
 		cmd.Cmd().ExecuteC()
 		pm := genericclioptions.NewConfigFlags(true)
+		failureDuration := 100 * time.Millisecond
+		param := params.NewParamsForTest(kclientset, shpclientset, pm, metav1.NamespaceDefault, &failureDuration, &failureDuration)
 		if len(test.to) > 0 {
-			pm.Timeout = &tests[i].to
+			// the watch is bound to the context timeout, not to the request timeout
+			flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			param.AddFlags(flagSet)
+			if err := flagSet.Set("context-timeout", test.to); err != nil {
+				t.Fatal(err)
+			}
 		}
-		param := params.NewParamsForTest(kclientset, shpclientset, pm, metav1.NamespaceDefault, nil, nil)
 
 		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
 
@@ -201,14 +219,23 @@ func TestStreamBuildRunFollowLogs(t *testing.T) {
 					Status: corev1.ConditionFalse,
 				},
 			}
-		case test.podDeleted:
-			pod.DeletionTimestamp = &metav1.Time{}
+		case test.podDeleted, test.failed:
+			if test.podDeleted {
+				pod.DeletionTimestamp = &metav1.Time{}
+			}
 			br.Status.Conditions = []v1alpha1.Condition{
 				{
 					Type:   v1alpha1.Succeeded,
 					Status: corev1.ConditionFalse,
 				},
 			}
+		case test.phase == corev1.PodSucceeded:
+			br.Status.Conditions = []v1alpha1.Condition{
+				{
+					Type:   v1alpha1.Succeeded,
+					Status: corev1.ConditionTrue,
+				},
+			}
 		}
 
 		cmd.Complete(param, &ioStreams, []string{name})
@@ -219,8 +246,9 @@ func TestStreamBuildRunFollowLogs(t *testing.T) {
 		}
 
 		go func() {
+			// a failed BuildRun makes the command exit with error
 			err := cmd.Run(param, &ioStreams)
-			if err != nil {
+			if err != nil && !test.failed {
 				t.Errorf("%s", err.Error())
 			}
 
@@ -246,3 +274,508 @@ func checkLog(name, text string, cmd *LogsCommand, out *bytes.Buffer, t *testing
 		t.Errorf("test %s: unexpected output: %s", name, out.String())
 	}
 }
+
+func TestStreamBuildRunFollowLogsExitStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    corev1.ConditionStatus
+		canceled  bool
+		expectErr bool
+	}{
+		{
+			name:   "succeeded",
+			status: corev1.ConditionTrue,
+		},
+		{
+			name:      "failed",
+			status:    corev1.ConditionFalse,
+			expectErr: true,
+		},
+		{
+			name:     "canceled",
+			status:   corev1.ConditionFalse,
+			canceled: true,
+		},
+		{
+			name:      "never finished",
+			status:    corev1.ConditionUnknown,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		name := "testpod"
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    map[string]string{v1alpha1.LabelBuildRun: name},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "container"}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		}
+		br := &v1alpha1.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+			},
+			Status: v1alpha1.BuildRunStatus{
+				Conditions: v1alpha1.Conditions{{
+					Type:   v1alpha1.Succeeded,
+					Status: test.status,
+					Reason: "Reason",
+				}},
+			},
+		}
+		if test.canceled {
+			br.Spec.State = v1alpha1.BuildRunRequestedStatePtr(v1alpha1.BuildRunStateCancel)
+		}
+
+		cmd := &LogsCommand{cmd: &cobra.Command{}, follow: true, podTimeout: defaultPodTimeout}
+		cmd.Cmd().ExecuteC()
+		failureDuration := 1 * time.Millisecond
+		param := params.NewParamsForTest(fake.NewSimpleClientset(pod), shpfake.NewSimpleClientset(br), genericclioptions.NewConfigFlags(true), metav1.NamespaceDefault, &failureDuration, &failureDuration)
+		ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+		if err := cmd.Complete(param, &ioStreams, []string{name}); err != nil {
+			t.Fatalf("%s: %s", test.name, err.Error())
+		}
+		err := cmd.Run(param, &ioStreams)
+		if test.expectErr && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		}
+	}
+}
+
+func TestBuildRunLogsImageDigest(t *testing.T) {
+	name := "test-obj"
+	pod := &corev1.Pod{}
+	pod.Name = name
+	pod.Namespace = metav1.NamespaceDefault
+	pod.Labels = map[string]string{v1alpha1.LabelBuildRun: name}
+	pod.Spec.Containers = []corev1.Container{{Name: name}}
+	pod.Status.Phase = corev1.PodSucceeded
+
+	br := &v1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name}}
+	br.Status.BuildSpec = &v1alpha1.BuildSpec{Output: v1alpha1.Image{Image: "registry:5000/app:latest"}}
+	br.Status.Conditions = v1alpha1.Conditions{{Type: v1alpha1.Succeeded, Status: corev1.ConditionTrue}}
+	shpclientset := shpfake.NewSimpleClientset(br)
+	param := params.NewParamsForTest(fake.NewSimpleClientset(pod), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	run := func(output string, imageDigest bool) (string, string, error) {
+		cmd := logsCmd().(*LogsCommand)
+		cmd.Cmd().ExecuteC()
+		cmd.name = name
+		cmd.output = output
+		cmd.imageDigest = imageDigest
+		if err := cmd.Validate(); err != nil {
+			return "", "", err
+		}
+		ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), errOut.String(), err
+	}
+
+	// the digest is not populated yet
+	out, errOut, err := run("", true)
+	if err != nil || !strings.Contains(out, "fake logs") || !strings.Contains(errOut, "does not report the output image digest") {
+		t.Fatalf("unexpected result, err: %v, out: %q, errOut: %q", err, out, errOut)
+	}
+	if _, _, err = run(digestOutput, false); err == nil {
+		t.Fatal("expected an error when the digest is not populated")
+	}
+
+	br.Status.Output = &v1alpha1.Output{Digest: "sha256:abc", Size: 1024}
+	if _, err = shpclientset.ShipwrightV1alpha1().BuildRuns(metav1.NamespaceDefault).UpdateStatus(context.Background(), br, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, _, err = run("", true)
+	if err != nil || !strings.Contains(out, "Image digest: registry:5000/app@sha256:abc\nImage size: 1024 bytes\n") {
+		t.Fatalf("unexpected result, err: %v, out: %q", err, out)
+	}
+
+	out, errOut, err = run(digestOutput, false)
+	if err != nil || out != "registry:5000/app@sha256:abc\n" || !strings.Contains(errOut, "fake logs") {
+		t.Fatalf("unexpected result, err: %v, out: %q, errOut: %q", err, out, errOut)
+	}
+
+	if _, _, err = run("json", false); err == nil {
+		t.Fatal("expected an error for an unsupported output format")
+	}
+}
+
+func TestBuildRunLogsRawContainer(t *testing.T) {
+	name := "test-obj"
+	pod := &corev1.Pod{}
+	pod.Name = name
+	pod.Namespace = metav1.NamespaceDefault
+	pod.Labels = map[string]string{v1alpha1.LabelBuildRun: name}
+	pod.Spec.InitContainers = []corev1.Container{{Name: "prepare"}}
+	pod.Spec.Containers = []corev1.Container{{Name: "step-build"}, {Name: "step-push"}}
+	pod.Status.Phase = corev1.PodSucceeded
+	param := params.NewParamsForTest(fake.NewSimpleClientset(pod), nil, nil, metav1.NamespaceDefault, nil, nil)
+
+	run := func(container string, raw bool) (string, error) {
+		cmd := logsCmd().(*LogsCommand)
+		cmd.Cmd().ExecuteC()
+		cmd.name = name
+		cmd.container = container
+		cmd.raw = raw
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), err
+	}
+
+	// the fake client returns the same logs for every container
+	out, err := run("", true)
+	if err != nil || out != "fake logsfake logsfake logs" {
+		t.Fatalf("unexpected result, err: %v, out: %q", err, out)
+	}
+
+	out, err = run("build", true)
+	if err != nil || out != "fake logs" {
+		t.Fatalf("unexpected result, err: %v, out: %q", err, out)
+	}
+
+	out, err = run("step-push", false)
+	if err != nil || !strings.Contains(out, "*** Pod \"test-obj\", container \"step-push\": ***") || strings.Contains(out, "step-build") {
+		t.Fatalf("unexpected result, err: %v, out: %q", err, out)
+	}
+
+	_, err = run("deploy", true)
+	if err == nil || err.Error() != `container "deploy" not found in pod "test-obj", must be one of: prepare, step-build, step-push` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBuildRunLogsColorByStep(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	name := "test-obj"
+	pod := &corev1.Pod{}
+	pod.Name = name
+	pod.Namespace = metav1.NamespaceDefault
+	pod.Labels = map[string]string{v1alpha1.LabelBuildRun: name}
+	pod.Spec.Containers = []corev1.Container{{Name: "step-build"}}
+	pod.Status.Phase = corev1.PodSucceeded
+	param := params.NewParamsForTest(fake.NewSimpleClientset(pod), nil, nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := logsCmd().(*LogsCommand)
+	cmd.Cmd().ExecuteC()
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--color-by-step", "--raw"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.MatchError("--color-by-step can not be used together with --raw"))
+
+	// the colors are never written when the output is not a terminal
+	cmd.raw = false
+	cmd.name = name
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.ContainSubstring("*** Pod \"test-obj\", container \"step-build\": ***"))
+	g.Expect(out.String()).ToNot(gomega.ContainSubstring("\x1b["))
+}
+
+func TestBuildRunLogsWatchOnly(t *testing.T) {
+	name := "testpod"
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      name,
+			Labels:    map[string]string{v1alpha1.LabelBuildRun: name},
+		},
+		Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "container"}}},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+	br := &v1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name}}
+	br.Status.Conditions = v1alpha1.Conditions{{Type: v1alpha1.Succeeded, Status: corev1.ConditionFalse, Reason: "Failed"}}
+
+	cmd := logsCmd().(*LogsCommand)
+	cmd.Cmd().ExecuteC()
+	cmd.watchOnly = true
+	if err := cmd.Validate(); err == nil || err.Error() != "--watch-only can only be used together with --follow" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the pod is done already, no logs are printed but the exit status reflects the failed BuildRun
+	cmd.follow = true
+	failureDuration := 1 * time.Millisecond
+	param := params.NewParamsForTest(fake.NewSimpleClientset(pod), shpfake.NewSimpleClientset(br), genericclioptions.NewConfigFlags(true), metav1.NamespaceDefault, &failureDuration, &failureDuration)
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	if err := cmd.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Complete(param, &ioStreams, []string{name}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Run(param, &ioStreams); err == nil {
+		t.Fatal("expected an error for the failed BuildRun")
+	}
+	if strings.Contains(out.String(), "fake logs") {
+		t.Fatalf("unexpected logs printed: %q", out.String())
+	}
+}
+
+func TestBuildRunLogsSummary(t *testing.T) {
+	name := "test-obj"
+	started := metav1.NewTime(time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC))
+	terminated := func(offset time.Duration, exitCode int32) corev1.ContainerState {
+		return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			StartedAt:  metav1.NewTime(started.Add(offset)),
+			FinishedAt: metav1.NewTime(started.Add(offset + 30*time.Second)),
+			ExitCode:   exitCode,
+		}}
+	}
+
+	pod := &corev1.Pod{}
+	pod.Name = name
+	pod.Namespace = metav1.NamespaceDefault
+	pod.Labels = map[string]string{v1alpha1.LabelBuildRun: name}
+	pod.Spec.Containers = []corev1.Container{{Name: "step-source-default"}, {Name: "step-build"}, {Name: "step-push"}}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "step-push", State: terminated(time.Minute, 1)},
+		{Name: "step-source-default", State: terminated(0, 0)},
+		{Name: "step-build", State: terminated(30*time.Second, 2)},
+	}
+
+	cmd := logsCmd().(*LogsCommand)
+	cmd.Cmd().ExecuteC()
+	cmd.name = name
+	cmd.summary = true
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	param := params.NewParamsForTest(fake.NewSimpleClientset(pod), nil, nil, metav1.NamespaceDefault, nil, nil)
+	if err := cmd.Run(param, &ioStreams); err != nil {
+		t.Fatal(err)
+	}
+
+	output := out.String()
+	summaryAt := strings.Index(output, "Steps of BuildRun")
+	if !strings.Contains(output, "fake logs") || summaryAt < strings.Index(output, "fake logs") {
+		t.Fatalf("expected the summary after the logs: %q", output)
+	}
+	output = output[summaryAt:]
+	for _, expected := range []string{
+		"STEP",
+		"source-default  2022-01-01T10:00:00Z  2022-01-01T10:00:30Z  30s",
+		"build           2022-01-01T10:00:30Z  2022-01-01T10:01:00Z  30s       Failed (exit code 2)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in the output: %q", expected, output)
+		}
+	}
+	// the steps following the failed one are skipped
+	if strings.Contains(output, "push") {
+		t.Errorf("unexpected step after the failed one: %q", output)
+	}
+	if strings.Index(output, "source-default  ") > strings.Index(output, "build  ") {
+		t.Errorf("expected the steps in execution order: %q", output)
+	}
+}
+
+func TestBuildRunLogsWaitForPod(t *testing.T) {
+	name := "test-obj"
+	pod := &corev1.Pod{}
+	pod.Name = name
+	pod.Namespace = metav1.NamespaceDefault
+	pod.Labels = map[string]string{v1alpha1.LabelBuildRun: name}
+	pod.Spec.Containers = []corev1.Container{{Name: "step-build"}}
+	pod.Status.Phase = corev1.PodSucceeded
+
+	running := &v1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name}}
+	finished := running.DeepCopy()
+	finished.Status.Conditions = v1alpha1.Conditions{{
+		Type: v1alpha1.Succeeded, Status: corev1.ConditionFalse, Message: "the build failed",
+	}}
+
+	run := func(br *v1alpha1.BuildRun, podAfter int, podTimeout time.Duration) (string, string, error) {
+		clientset := fake.NewSimpleClientset(pod)
+		// the pod only shows up after the informed amount of attempts
+		attempts := 0
+		clientset.PrependReactor("list", "pods", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+			attempts++
+			if attempts <= podAfter {
+				return true, &corev1.PodList{}, nil
+			}
+			return false, nil, nil
+		})
+		objects := []kruntime.Object{}
+		if br != nil {
+			objects = append(objects, br)
+		}
+		param := params.NewParamsForTest(clientset, shpfake.NewSimpleClientset(objects...), nil, metav1.NamespaceDefault, nil, nil)
+
+		cmd := logsCmd().(*LogsCommand)
+		cmd.Cmd().ExecuteC()
+		cmd.name = name
+		cmd.podTimeout = podTimeout
+		ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), errOut.String(), err
+	}
+
+	out, errOut, err := run(running, 1, time.Minute)
+	if err != nil || !strings.Contains(out, "fake logs") {
+		t.Fatalf("expected the logs once the pod is created, err: %v, out: %q", err, out)
+	}
+	if strings.Count(errOut, "Waiting for the pod of BuildRun") != 1 {
+		t.Fatalf("expected a single waiting message: %q", errOut)
+	}
+
+	if _, _, err = run(nil, 1, time.Minute); err == nil || err.Error() != `BuildRun "test-obj" not found` {
+		t.Fatalf("expected the missing BuildRun error, got: %v", err)
+	}
+	if _, _, err = run(finished, 1, time.Minute); err == nil || !strings.Contains(err.Error(), "has finished without a builder pod") {
+		t.Fatalf("expected the finished BuildRun error, got: %v", err)
+	}
+	if _, _, err = run(running, 100, 1500*time.Millisecond); err == nil || err.Error() != `no builder pod found for BuildRun "test-obj" within 1.5s` {
+		t.Fatalf("expected the pod timeout error, got: %v", err)
+	}
+
+	// an interrupt is not reported as the pod timeout
+	cmd := logsCmd().(*LogsCommand)
+	cmd.Cmd().ExecuteC()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cmd.Cmd().SetContext(ctx)
+	cmd.name = name
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpfake.NewSimpleClientset(running), nil, metav1.NamespaceDefault, nil, nil)
+	ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	if err = cmd.Run(param, &ioStreams); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the interrupt error, got: %v", err)
+	}
+
+	for _, podTimeout := range []time.Duration{-time.Second, 0} {
+		cmd.podTimeout = podTimeout
+		if err = cmd.Validate(); err == nil || err.Error() != "--pod-timeout must be positive" {
+			t.Fatalf("expected the timeout error for %s, got: %v", podTimeout, err)
+		}
+	}
+}
+
+func TestBuildRunLogsMultiplePods(t *testing.T) {
+	name := "test-obj"
+	newPod := func(podName string, created time.Time) *corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Name = podName
+		pod.Namespace = metav1.NamespaceDefault
+		pod.CreationTimestamp = metav1.NewTime(created)
+		pod.Labels = map[string]string{v1alpha1.LabelBuildRun: name}
+		pod.Spec.Containers = []corev1.Container{{Name: "step-build"}}
+		pod.Status.Phase = corev1.PodSucceeded
+		return pod
+	}
+	now := time.Now()
+	clientset := fake.NewSimpleClientset(newPod("test-obj-b", now), newPod("test-obj-a", now.Add(-time.Minute)))
+	param := params.NewParamsForTest(clientset, nil, nil, metav1.NamespaceDefault, nil, nil)
+
+	run := func(pod string) (string, error) {
+		cmd := logsCmd().(*LogsCommand)
+		cmd.Cmd().ExecuteC()
+		cmd.name = name
+		cmd.pod = pod
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), err
+	}
+
+	// without --pod the candidates are listed, the oldest first
+	_, err := run("")
+	expected := "BuildRun \"test-obj\" has 2 pods, pick one with --pod:\n  test-obj-a (Succeeded)\n  test-obj-b (Succeeded)"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := run("test-obj-b")
+	if err != nil || !strings.Contains(out, `*** Pod "test-obj-b", container "step-build": ***`) || strings.Contains(out, "test-obj-a") {
+		t.Fatalf("unexpected result, err: %v, out: %q", err, out)
+	}
+
+	_, err = run("test-obj-c")
+	if err == nil || !strings.HasPrefix(err.Error(), `pod "test-obj-c" not found for BuildRun "test-obj", must be one of:`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBuildRunLogsFailFast(t *testing.T) {
+	name := "testpod"
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      name,
+			Labels:    map[string]string{v1alpha1.LabelBuildRun: name},
+		},
+		Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "container"}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	br := &v1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name}}
+	br.Status.Conditions = v1alpha1.Conditions{{
+		Type:    v1alpha1.Succeeded,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Failed",
+		Message: "step-build failed",
+	}}
+
+	cmd := logsCmd().(*LogsCommand)
+	cmd.Cmd().ExecuteC()
+	cmd.failFast = true
+	if err := cmd.Validate(); err == nil || err.Error() != "--fail-fast can only be used together with --follow" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the pod is still running, the BuildRun failure stops following right away
+	cmd.follow = true
+	pollInterval := 1 * time.Millisecond
+	param := params.NewParamsForTest(fake.NewSimpleClientset(pod), shpfake.NewSimpleClientset(br), genericclioptions.NewConfigFlags(true), metav1.NamespaceDefault, &pollInterval, &pollInterval)
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	if err := cmd.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Complete(param, &ioStreams, []string{name}); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.Run(param, &ioStreams)
+	if err == nil || err.Error() != `buildrun "testpod" has failed: Failed` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `BuildRun "testpod" has failed because of Failed: step-build failed, stopping the log streams`
+	if !strings.Contains(out.String(), expected) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestBuildRunLogsJoinTimeout(t *testing.T) {
+	cmd := logsCmd().(*LogsCommand)
+	if err := cmd.Cmd().ParseFlags([]string{"--join-timeout=30s"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Validate(); err == nil || err.Error() != "--join-timeout can only be used together with --follow" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cmd.follow = true
+	if err := cmd.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if cmd.joinTimeout != 30*time.Second {
+		t.Fatalf("unexpected join timeout: %s", cmd.joinTimeout)
+	}
+
+	cmd.joinTimeout = -time.Second
+	if err := cmd.Validate(); err == nil || err.Error() != "--join-timeout must not be negative" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the default does not require --follow
+	cmd = logsCmd().(*LogsCommand)
+	if err := cmd.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if cmd.joinTimeout != follower.DefaultJoinTimeout {
+		t.Fatalf("unexpected default join timeout: %s", cmd.joinTimeout)
+	}
+}
@@ -0,0 +1,255 @@
+package buildrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	buildclientv1alpha1 "github.com/shipwright-io/build/pkg/client/clientset/versioned/typed/build/v1alpha1"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/util"
+)
+
+const (
+	// resultSucceeded the BuildRun has succeeded.
+	resultSucceeded = "Succeeded"
+	// resultFailed the BuildRun has failed, or was canceled.
+	resultFailed = "Failed"
+	// resultRunning the BuildRun is still in progress.
+	resultRunning = "Running"
+
+	// exitCodeFailed exit code when the BuildRun has failed.
+	exitCodeFailed = 1
+	// exitCodeRunning exit code when the BuildRun is still in progress.
+	exitCodeRunning = 3
+
+	// statusWaitTimeout maximum age of a BuildRun without status for which the status is polled,
+	// giving the controller the chance to pick up a BuildRun which has just been created.
+	statusWaitTimeout = 10 * time.Second
+)
+
+// Status summarizes the BuildRun succeeded condition.
+type Status struct {
+	Name           string                 `json:"name"`
+	Result         string                 `json:"result"`
+	Status         corev1.ConditionStatus `json:"status"`
+	Reason         string                 `json:"reason,omitempty"`
+	Message        string                 `json:"message,omitempty"`
+	StartTime      *metav1.Time           `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time           `json:"completionTime,omitempty"`
+}
+
+// StatusCommand represents the "buildrun status" sub-command.
+type StatusCommand struct {
+	cmd *cobra.Command
+
+	name        string
+	output      *flags.OutputOptions // json prints the summary, the other formats print the BuildRun
+	waitTimeout time.Duration        // maximum age of a BuildRun for polling its status
+}
+
+// statusJSONOutput output format printing the status summary as JSON, instead of the BuildRun.
+const statusJSONOutput = "json"
+
+const buildRunStatusLongDesc = `
+Shows the current status of the BuildRun, the exit code reflects the result: zero when the
+BuildRun has succeeded, 1 when it has failed or was canceled, and 3 while it's still running.
+When the BuildRun has failed, the most recent warning events of the BuildRun and its pod are
+printed on stderr. For example:
+
+	$ shp buildrun status my-app-xyz12 && deploy
+
+The status is printed as text or as a JSON summary, or the BuildRun is printed in any of the other
+output formats, i.e. YAML, or a field using a jsonpath or go-template expression, the exit code
+still reflects the result:
+
+	$ shp buildrun status my-app-xyz12 -o jsonpath='{.status.conditions[?(@.type=="Succeeded")].status}'
+`
+
+func statusCmd() runner.SubCommand {
+	statusCommand := &StatusCommand{
+		cmd: &cobra.Command{
+			Use:   "status <name>",
+			Short: "Show the BuildRun status",
+			Long:  buildRunStatusLongDesc,
+			Args:  cobra.ExactArgs(1),
+		},
+		waitTimeout: statusWaitTimeout,
+	}
+
+	statusCommand.output = flags.OutputFlags(statusCommand.cmd.Flags())
+	// the json format prints the status summary, instead of the BuildRun
+	formats := []string{}
+	for _, format := range statusCommand.output.AllowedFormats() {
+		if format != statusJSONOutput {
+			formats = append(formats, format)
+		}
+	}
+	statusCommand.cmd.Flags().Lookup(flags.OutputFlag).Usage = fmt.Sprintf(
+		"Output format, either empty for text, json for a summary, or one of %s to print the BuildRun",
+		strings.Join(formats, ", "))
+
+	return statusCommand
+}
+
+// Cmd returns cobra command object
+func (c *StatusCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *StatusCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *StatusCommand) Validate() error {
+	if c.output.Format == statusJSONOutput {
+		return nil
+	}
+	return c.output.Validate()
+}
+
+// Run prints the BuildRun status, returning an error with the exit code matching the result.
+func (c *StatusCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	br, err := c.getBuildRun(clientset.ShipwrightV1alpha1().BuildRuns(params.Namespace()))
+	if err != nil {
+		return err
+	}
+
+	status := buildRunConditionStatus(br)
+	switch {
+	case !c.output.Enabled():
+		writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, ' ', 0)
+		fmt.Fprintf(writer, "Name:\t%s\n", status.Name)
+		fmt.Fprintf(writer, "Result:\t%s\n", status.Result)
+		fmt.Fprintf(writer, "Succeeded:\t%s\n", status.Status)
+		fmt.Fprintf(writer, "Reason:\t%s\n", status.Reason)
+		fmt.Fprintf(writer, "Message:\t%s\n", status.Message)
+		fmt.Fprintf(writer, "Start Time:\t%s\n", formatTime(status.StartTime))
+		fmt.Fprintf(writer, "Completion Time:\t%s\n", formatTime(status.CompletionTime))
+		if err = writer.Flush(); err != nil {
+			return err
+		}
+	case c.output.Format == statusJSONOutput:
+		data, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(ioStreams.Out, string(data))
+	default:
+		// the BuildRun is printed as a whole, the result still sets the exit code
+		if err = c.output.PrintObject(ioStreams.Out, br); err != nil {
+			return err
+		}
+	}
+
+	switch status.Result {
+	case resultFailed:
+		if !br.IsCanceled() {
+			c.printWarningEvents(params, ioStreams, br)
+		}
+		return &runner.ExitError{Code: exitCodeFailed}
+	case resultRunning:
+		return &runner.ExitError{Code: exitCodeRunning}
+	}
+	return nil
+}
+
+// printWarningEvents prints the most recent warning events of the failed BuildRun on stderr, which
+// surface i.e. scheduling and image pull errors the condition alone hides. Failing to list the
+// events doesn't affect the status.
+func (c *StatusCommand) printWarningEvents(
+	params *params.Params,
+	ioStreams *genericclioptions.IOStreams,
+	br *buildv1alpha1.BuildRun,
+) {
+	logger := params.Logger(ioStreams.ErrOut)
+	clientset, err := params.ClientSet()
+	if err != nil {
+		logger.Warning(fmt.Sprintf("unable to list the BuildRun events: %s", err))
+		return
+	}
+	events, err := util.BuildRunWarningEvents(c.cmd.Context(), clientset, br, util.WarningEventsLimit)
+	if err != nil {
+		logger.Warning(fmt.Sprintf("unable to list the BuildRun events: %s", err))
+		return
+	}
+	if len(events) > 0 {
+		logger.Warning(util.FormatWarningEvents(events))
+	}
+}
+
+// getBuildRun retrieves the BuildRun, and while it has neither conditions nor start time, retries
+// with backoff as long as the BuildRun is younger than the wait timeout, since the controller may
+// not have reconciled a BuildRun just created.
+func (c *StatusCommand) getBuildRun(client buildclientv1alpha1.BuildRunInterface) (*buildv1alpha1.BuildRun, error) {
+	var br *buildv1alpha1.BuildRun
+	backoff := wait.Backoff{Duration: 100 * time.Millisecond, Factor: 2, Steps: 8}
+	err := wait.ExponentialBackoffWithContext(c.cmd.Context(), backoff, func(ctx context.Context) (bool, error) {
+		var err error
+		if br, err = client.Get(ctx, c.name, metav1.GetOptions{}); err != nil {
+			return false, err
+		}
+		populated := len(br.Status.Conditions) > 0 || br.Status.StartTime != nil
+		return populated || time.Since(br.CreationTimestamp.Time) >= c.waitTimeout, nil
+	})
+	// running out of attempts, the status is evaluated as it is
+	if err != nil && !(br != nil && wait.Interrupted(err) && c.cmd.Context().Err() == nil) {
+		return nil, err
+	}
+	return br, nil
+}
+
+// buildRunConditionStatus summarizes the BuildRun succeeded condition, without the condition the
+// BuildRun is considered running.
+func buildRunConditionStatus(br *buildv1alpha1.BuildRun) *Status {
+	status := &Status{
+		Name:           br.Name,
+		Result:         resultRunning,
+		Status:         corev1.ConditionUnknown,
+		StartTime:      br.Status.StartTime,
+		CompletionTime: br.Status.CompletionTime,
+	}
+	condition := br.Status.GetCondition(buildv1alpha1.Succeeded)
+	if condition == nil {
+		return status
+	}
+
+	status.Status = condition.Status
+	status.Reason = condition.Reason
+	status.Message = condition.Message
+	switch condition.Status {
+	case corev1.ConditionTrue:
+		status.Result = resultSucceeded
+	case corev1.ConditionFalse:
+		status.Result = resultFailed
+	}
+	return status
+}
+
+// formatTime returns the time in RFC3339 format, or a placeholder when not set.
+func formatTime(t *metav1.Time) string {
+	if t == nil || t.IsZero() {
+		return "<none>"
+	}
+	return t.Format(time.RFC3339)
+}
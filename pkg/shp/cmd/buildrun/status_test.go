@@ -0,0 +1,231 @@
+package buildrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	fakekubetesting "k8s.io/client-go/testing"
+
+	"github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestBuildRunStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions v1alpha1.Conditions
+		result     string
+		exitCode   int
+	}{{
+		name: "succeeded",
+		conditions: v1alpha1.Conditions{{
+			Type:    v1alpha1.Succeeded,
+			Status:  corev1.ConditionTrue,
+			Reason:  "Succeeded",
+			Message: "All Steps have completed executing",
+		}},
+		result: resultSucceeded,
+	}, {
+		name: "failed",
+		conditions: v1alpha1.Conditions{{
+			Type:   v1alpha1.Succeeded,
+			Status: corev1.ConditionFalse,
+			Reason: "Failed",
+		}},
+		result:   resultFailed,
+		exitCode: exitCodeFailed,
+	}, {
+		name: "running",
+		conditions: v1alpha1.Conditions{{
+			Type:   v1alpha1.Succeeded,
+			Status: corev1.ConditionUnknown,
+			Reason: "Running",
+		}},
+		result:   resultRunning,
+		exitCode: exitCodeRunning,
+	}, {
+		name:     "pending",
+		result:   resultRunning,
+		exitCode: exitCodeRunning,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			br := &v1alpha1.BuildRun{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: test.name},
+				Status:     v1alpha1.BuildRunStatus{Conditions: test.conditions},
+			}
+			param := params.NewParamsForTest(fake.NewSimpleClientset(), shpfake.NewSimpleClientset(br), nil, metav1.NamespaceDefault, nil, nil)
+
+			for _, output := range []string{"", "json"} {
+				cmd := statusCmd().(*StatusCommand)
+				cmd.Cmd().SetContext(context.Background())
+				g.Expect(cmd.Cmd().Flags().Set("output", output)).To(gomega.Succeed())
+				g.Expect(cmd.Complete(param, nil, []string{test.name})).To(gomega.Succeed())
+				g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+				ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+				err := cmd.Run(param, &ioStreams)
+				if test.exitCode == 0 {
+					g.Expect(err).ToNot(gomega.HaveOccurred())
+				} else {
+					var exitErr *runner.ExitError
+					g.Expect(errors.As(err, &exitErr)).To(gomega.BeTrue())
+					g.Expect(exitErr.Code).To(gomega.Equal(test.exitCode))
+				}
+
+				if output == "json" {
+					var status Status
+					g.Expect(json.Unmarshal(out.Bytes(), &status)).To(gomega.Succeed())
+					g.Expect(status.Name).To(gomega.Equal(test.name))
+					g.Expect(status.Result).To(gomega.Equal(test.result))
+				} else {
+					g.Expect(out.String()).To(gomega.MatchRegexp(`Result:\s+` + test.result))
+				}
+			}
+		})
+	}
+
+	cmd := statusCmd().(*StatusCommand)
+	g := gomega.NewWithT(t)
+	g.Expect(cmd.Cmd().Flags().Set("output", "wide")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).ToNot(gomega.Succeed())
+}
+
+func TestBuildRunStatusWaitsForConditions(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	br := &v1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{
+		Namespace:         metav1.NamespaceDefault,
+		Name:              "br",
+		CreationTimestamp: metav1.Now(),
+	}}
+	shpclientset := shpfake.NewSimpleClientset(br)
+
+	// the controller reconciles the BuildRun only by the third attempt
+	gets := 0
+	shpclientset.PrependReactor("get", "buildruns", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+		gets++
+		if gets < 3 {
+			return true, br.DeepCopy(), nil
+		}
+		reconciled := br.DeepCopy()
+		reconciled.Status.Conditions = v1alpha1.Conditions{{Type: v1alpha1.Succeeded, Status: corev1.ConditionTrue, Reason: "Succeeded"}}
+		return true, reconciled, nil
+	})
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := statusCmd().(*StatusCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Complete(param, nil, []string{"br"})).To(gomega.Succeed())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(gets).To(gomega.Equal(3))
+	g.Expect(out.String()).To(gomega.MatchRegexp(`Result:\s+` + resultSucceeded))
+
+	// a BuildRun older than the wait timeout is evaluated right away
+	gets = 0
+	cmd.waitTimeout = 0
+	var exitErr *runner.ExitError
+	g.Expect(errors.As(cmd.Run(param, &ioStreams), &exitErr)).To(gomega.BeTrue())
+	g.Expect(exitErr.Code).To(gomega.Equal(exitCodeRunning))
+	g.Expect(gets).To(gomega.Equal(1))
+}
+
+func TestBuildRunStatusWarningEvents(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br.1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "BuildRun", Name: "br"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "FailedCreate",
+		Message:        `secrets "registry" not found`,
+	}
+	br := &v1alpha1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br"},
+		Status: v1alpha1.BuildRunStatus{Conditions: v1alpha1.Conditions{{
+			Type:   v1alpha1.Succeeded,
+			Status: corev1.ConditionFalse,
+			Reason: "Failed",
+		}}},
+	}
+	shpclientset := shpfake.NewSimpleClientset(br)
+	param := params.NewParamsForTest(fake.NewSimpleClientset(event), shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	cmd := statusCmd().(*StatusCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Complete(param, nil, []string{"br"})).To(gomega.Succeed())
+
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(param, &ioStreams)).ToNot(gomega.Succeed())
+	g.Expect(out.String()).ToNot(gomega.ContainSubstring("FailedCreate"))
+	g.Expect(errOut.String()).To(gomega.Equal(
+		"Recent warning events:\n  buildrun/br FailedCreate: secrets \"registry\" not found\n"))
+
+	// the events of a canceled BuildRun are not relevant
+	br.Spec.State = v1alpha1.BuildRunRequestedStatePtr(v1alpha1.BuildRunStateCancel)
+	_, err := shpclientset.ShipwrightV1alpha1().BuildRuns(metav1.NamespaceDefault).Update(context.Background(), br, metav1.UpdateOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	errOut.Reset()
+	g.Expect(cmd.Run(param, &ioStreams)).ToNot(gomega.Succeed())
+	g.Expect(errOut.String()).To(gomega.BeEmpty())
+}
+
+func TestBuildRunStatusTemplate(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	br := &v1alpha1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br"},
+		Status: v1alpha1.BuildRunStatus{Conditions: v1alpha1.Conditions{{
+			Type:   v1alpha1.Succeeded,
+			Status: corev1.ConditionFalse,
+			Reason: "Failed",
+		}}},
+	}
+	param := params.NewParamsForTest(fake.NewSimpleClientset(), shpfake.NewSimpleClientset(br), nil, metav1.NamespaceDefault, nil, nil)
+
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{output: `jsonpath={.status.conditions[?(@.type=="Succeeded")].status}`, expected: "False"},
+		{output: "jsonpath={.kind}/{.metadata.name}", expected: "BuildRun/br"},
+		{output: "go-template={{.status.completionTime}}", expected: "<no value>"},
+		{output: "name", expected: "buildrun.shipwright.io/br\n"},
+	}
+
+	for _, test := range tests {
+		cmd := statusCmd().(*StatusCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().Flags().Set("output", test.output)).To(gomega.Succeed())
+		g.Expect(cmd.Complete(param, nil, []string{"br"})).To(gomega.Succeed())
+		g.Expect(cmd.Validate()).To(gomega.Succeed())
+
+		// the exit code reflects the result regardless of the printed field
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		var exitErr *runner.ExitError
+		g.Expect(errors.As(cmd.Run(param, &ioStreams), &exitErr)).To(gomega.BeTrue())
+		g.Expect(exitErr.Code).To(gomega.Equal(exitCodeFailed))
+		g.Expect(out.String()).To(gomega.Equal(test.expected), test.output)
+	}
+
+	cmd := statusCmd().(*StatusCommand)
+	g.Expect(cmd.Cmd().Flags().Set("output", "jsonpath={.status")).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).ToNot(gomega.Succeed())
+}
@@ -0,0 +1,122 @@
+package buildstrategy
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// Strategy a build strategy object, either a namespaced BuildStrategy or a ClusterBuildStrategy.
+type Strategy interface {
+	metav1.Object
+	buildv1alpha1.BuilderStrategy
+}
+
+// NewStrategyParameters returns the parameters declared by the build strategy, the type defaults to
+// string when not declared.
+func NewStrategyParameters(kind buildv1alpha1.BuildStrategyKind, name string, strategy buildv1alpha1.BuilderStrategy) StrategyParameters {
+	result := StrategyParameters{Kind: kind, Name: name, Parameters: []Parameter{}}
+	for _, p := range strategy.GetParameters() {
+		param := Parameter{Name: p.Name, Type: string(p.Type), Default: p.Default, Description: p.Description}
+		if param.Type == "" {
+			param.Type = string(buildv1alpha1.ParameterTypeString)
+		}
+		if p.Defaults != nil {
+			param.Defaults = *p.Defaults
+		}
+		result.Parameters = append(result.Parameters, param)
+	}
+	return result
+}
+
+// DescribeStrategy writes the build strategy details, like "kubectl describe" does: the steps with
+// their images, the parameters with their defaults, and the volumes the builds may mount.
+func DescribeStrategy(out io.Writer, kind buildv1alpha1.BuildStrategyKind, strategy Strategy) error {
+	writer := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(writer, "Name:\t%s\n", strategy.GetName())
+	fmt.Fprintf(writer, "Kind:\t%s\n", kind)
+	if strategy.GetNamespace() != "" {
+		fmt.Fprintf(writer, "Namespace:\t%s\n", strategy.GetNamespace())
+	}
+	created := strategy.GetCreationTimestamp()
+	if created.IsZero() {
+		fmt.Fprintf(writer, "Created:\t<none>\n")
+	} else {
+		fmt.Fprintf(writer, "Created:\t%s (%s ago)\n", created.Format(time.RFC3339), duration.HumanDuration(time.Since(created.Time)))
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	steps := strategy.GetBuildSteps()
+	rows := make([]string, 0, len(steps))
+	for _, step := range steps {
+		rows = append(rows, fmt.Sprintf("%s\t%s", step.Name, step.Image))
+	}
+	if err := describeSection(out, "Steps", "Name\tImage", rows); err != nil {
+		return err
+	}
+
+	if err := DescribeParameters(out, "Parameters", strategy); err != nil {
+		return err
+	}
+
+	volumes := strategy.GetVolumes()
+	rows = make([]string, 0, len(volumes))
+	for _, v := range volumes {
+		overridable := v.Overridable != nil && *v.Overridable
+		description := ""
+		if v.Description != nil {
+			description = *v.Description
+		}
+		rows = append(rows, fmt.Sprintf("%s\t%t\t%s", v.Name, overridable, description))
+	}
+	return describeSection(out, "Volumes", "Name\tOverridable\tDescription", rows)
+}
+
+// DescribeParameters writes the parameters declared by the build strategy, with their type, default
+// and description, as a section with the given title.
+func DescribeParameters(out io.Writer, title string, strategy buildv1alpha1.BuilderStrategy) error {
+	params := NewStrategyParameters("", strategy.GetName(), strategy).Parameters
+	rows := make([]string, 0, len(params))
+	for _, p := range params {
+		rows = append(rows, fmt.Sprintf("%s\t%s\t%s\t%s", p.Name, p.Type, p.defaultValue(), p.Description))
+	}
+	return describeSection(out, title, "Name\tType\tDefault\tDescription", rows)
+}
+
+// describeSection writes a titled table, the columns are underlined, or a placeholder when there
+// are no rows.
+func describeSection(out io.Writer, title string, columns string, rows []string) error {
+	if len(rows) == 0 {
+		fmt.Fprintf(out, "%s:  <none>\n", title)
+		return nil
+	}
+	fmt.Fprintf(out, "%s:\n", title)
+	writer := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	underline := []string{}
+	for _, column := range strings.Split(columns, "\t") {
+		underline = append(underline, strings.Repeat("-", len(column)))
+	}
+	fmt.Fprintf(writer, "  %s\n", columns)
+	fmt.Fprintf(writer, "  %s\n", strings.Join(underline, "\t"))
+	for _, row := range rows {
+		fmt.Fprintf(writer, "  %s\n", row)
+	}
+	return writer.Flush()
+}
+
+// joinNames returns the names comma separated, or a placeholder when empty.
+func joinNames(names []string) string {
+	if len(names) == 0 {
+		return "<none>"
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,2 @@
+// Package buildstrategy contains types and functions for buildstrategy cobra sub-command
+package buildstrategy
@@ -0,0 +1,78 @@
+package buildstrategy
+
+import (
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// GetCommand represents the "buildstrategy get" sub-command.
+type GetCommand struct {
+	cmd *cobra.Command
+
+	name   string
+	output *flags.OutputOptions // prints the BuildStrategy object instead of the description
+}
+
+const buildStrategyGetLongDesc = `
+Shows the details of the namespaced BuildStrategy: its steps with the images they run, the
+parameters with their type and default, where the ones without default are required, and the
+volumes the builds may mount or override. For example:
+
+	$ shp buildstrategy get buildah
+
+With --output the BuildStrategy object is printed instead, i.e. as YAML:
+
+	$ shp buildstrategy get buildah -o yaml
+`
+
+func getCmd() runner.SubCommand {
+	getCommand := &GetCommand{
+		cmd: &cobra.Command{
+			Use:   "get <name>",
+			Short: "Show the details of a BuildStrategy",
+			Long:  buildStrategyGetLongDesc,
+			Args:  cobra.ExactArgs(1),
+		},
+	}
+	getCommand.output = flags.OutputFlags(getCommand.cmd.Flags())
+	return getCommand
+}
+
+// Cmd returns cobra command object
+func (c *GetCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *GetCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *GetCommand) Validate() error {
+	return c.output.Validate()
+}
+
+// Run prints the BuildStrategy details, or the BuildStrategy object in the output format informed.
+func (c *GetCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	bs, err := clientset.ShipwrightV1alpha1().BuildStrategies(params.Namespace()).Get(c.cmd.Context(), c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if c.output.Enabled() {
+		return c.output.PrintObject(ioStreams.Out, bs)
+	}
+	return DescribeStrategy(ioStreams.Out, buildv1alpha1.NamespacedBuildStrategyKind, bs)
+}
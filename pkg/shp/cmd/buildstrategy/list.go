@@ -0,0 +1,130 @@
+package buildstrategy
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// ListCommand represents the "buildstrategy list" sub-command.
+type ListCommand struct {
+	cmd *cobra.Command
+
+	noHeader bool
+	output   *flags.OutputOptions
+}
+
+const buildStrategyListLongDesc = `
+Lists the namespaced BuildStrategies in the current namespace, with the names of their steps,
+parameters and volumes. Use "shp buildstrategy get" to see the details of a BuildStrategy, and
+"shp clusterbuildstrategy list" for the ClusterBuildStrategies.
+
+For scripts, --output json or yaml prints the BuildStrategies as a BuildStrategyList:
+
+	$ shp buildstrategy list -o yaml
+`
+
+func listCmd() runner.SubCommand {
+	listCommand := &ListCommand{
+		cmd: &cobra.Command{
+			Use:   "list [flags]",
+			Short: "List BuildStrategies",
+			Long:  buildStrategyListLongDesc,
+			Args:  cobra.NoArgs,
+		},
+	}
+
+	listCommand.cmd.Flags().BoolVar(&listCommand.noHeader, "no-header", false, "Do not show columns header in list output")
+	listCommand.output = flags.OutputFlags(listCommand.cmd.Flags())
+	return listCommand
+}
+
+// Cmd returns cobra command object
+func (c *ListCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *ListCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, _ []string) error {
+	c.output.NoHeaders = c.noHeader
+	return nil
+}
+
+// Validate validates data input by user
+func (c *ListCommand) Validate() error {
+	return c.output.Validate()
+}
+
+// Run lists the BuildStrategies of the namespace, sorted by name.
+func (c *ListCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	namespace := params.Namespace()
+	list, err := clientset.ShipwrightV1alpha1().BuildStrategies(namespace).List(c.cmd.Context(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(list.Items, func(i, j int) bool {
+		return list.Items[i].Name < list.Items[j].Name
+	})
+
+	if c.output.Enabled() {
+		return c.output.PrintObject(ioStreams.Out, list)
+	}
+	if len(list.Items) == 0 {
+		fmt.Fprintf(ioStreams.Out, "No BuildStrategies found in namespace '%s'.\n", namespace)
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
+	if !c.noHeader {
+		fmt.Fprintln(writer, "NAME\tSTEPS\tPARAMETERS\tVOLUMES\tAGE")
+	}
+	for i := range list.Items {
+		bs := &list.Items[i]
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", bs.Name, StepNames(bs), ParameterNames(bs), VolumeNames(bs),
+			duration.ShortHumanDuration(time.Since(bs.CreationTimestamp.Time)))
+	}
+	return writer.Flush()
+}
+
+// StepNames returns the names of the build strategy steps.
+func StepNames(strategy buildv1alpha1.BuilderStrategy) string {
+	names := []string{}
+	for _, step := range strategy.GetBuildSteps() {
+		names = append(names, step.Name)
+	}
+	return joinNames(names)
+}
+
+// ParameterNames returns the names of the build strategy parameters.
+func ParameterNames(strategy buildv1alpha1.BuilderStrategy) string {
+	names := []string{}
+	for _, p := range strategy.GetParameters() {
+		names = append(names, p.Name)
+	}
+	return joinNames(names)
+}
+
+// VolumeNames returns the names of the build strategy volumes.
+func VolumeNames(strategy buildv1alpha1.BuilderStrategy) string {
+	names := []string{}
+	for _, v := range strategy.GetVolumes() {
+		names = append(names, v.Name)
+	}
+	return joinNames(names)
+}
@@ -0,0 +1,59 @@
+package buildstrategy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestBuildStrategyList(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	run := func(shpclientset *shpfake.Clientset, args ...string) string {
+		cmd := listCmd().(*ListCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().ParseFlags(args)).To(gomega.Succeed())
+		param := params.NewParamsForTest(nil, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.Complete(param, &ioStreams, nil)).To(gomega.Succeed())
+		g.Expect(cmd.Validate()).To(gomega.Succeed())
+		g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+		return out.String()
+	}
+
+	g.Expect(run(shpfake.NewSimpleClientset())).To(gomega.Equal("No BuildStrategies found in namespace 'default'.\n"))
+
+	shpclientset := shpfake.NewSimpleClientset(
+		&buildv1alpha1.BuildStrategy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "kaniko"},
+			Spec: buildv1alpha1.BuildStrategySpec{
+				BuildSteps: []buildv1alpha1.BuildStep{
+					{Container: corev1.Container{Name: "build"}},
+					{Container: corev1.Container{Name: "push"}},
+				},
+				Parameters: []buildv1alpha1.Parameter{{Name: "dockerfile"}},
+				Volumes:    []buildv1alpha1.BuildStrategyVolume{{Name: "cache"}},
+			},
+		},
+		&buildv1alpha1.BuildStrategy{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "buildah"}},
+		&buildv1alpha1.BuildStrategy{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "ko"}},
+		&buildv1alpha1.ClusterBuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: "buildpacks"}},
+	)
+	out := run(shpclientset)
+	g.Expect(out).To(gomega.MatchRegexp(`^NAME\s+STEPS\s+PARAMETERS\s+VOLUMES\s+AGE\n` +
+		`buildah\s+<none>\s+<none>\s+<none>\s+\S+\n` +
+		`kaniko\s+build,push\s+dockerfile\s+cache\s+\S+\n$`))
+
+	g.Expect(run(shpclientset, "--no-header")).To(gomega.HavePrefix("buildah"))
+	g.Expect(run(shpclientset, "-o", "name")).To(gomega.Equal(
+		"buildstrategy.shipwright.io/buildah\nbuildstrategy.shipwright.io/kaniko\n"))
+}
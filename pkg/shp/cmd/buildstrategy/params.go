@@ -0,0 +1,189 @@
+package buildstrategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// Parameter describes a build strategy parameter, as printed for tooling.
+type Parameter struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Default     *string  `json:"default,omitempty"`
+	Defaults    []string `json:"defaults,omitempty"`
+	Description string   `json:"description"`
+}
+
+// StrategyParameters the parameters declared by a build strategy, together with its scope.
+type StrategyParameters struct {
+	Kind       buildv1alpha1.BuildStrategyKind `json:"kind"`
+	Name       string                          `json:"name"`
+	Parameters []Parameter                     `json:"parameters"`
+}
+
+// ParamsCommand represents the "buildstrategy params" sub-command.
+type ParamsCommand struct {
+	cmd *cobra.Command
+
+	name   string
+	kind   buildv1alpha1.BuildStrategyKind // scope of the strategy, empty to look up both
+	output string                          // output format, either empty for a table, json or yaml
+}
+
+const buildStrategyParamsLongDesc = `
+Shows the parameters declared by the build strategy, with their type, default and description, i.e.
+to tell which values "--param-value" takes. For tooling, the parameters are printed as structured
+data with --output json or yaml:
+
+	$ shp buildstrategy params buildah -o json
+
+Both the namespaced BuildStrategy and the ClusterBuildStrategy are looked up, when the name exists
+in both scopes --strategy-kind picks one of them. Parameters without default are required.
+`
+
+func paramsCmd() runner.SubCommand {
+	paramsCommand := &ParamsCommand{
+		cmd: &cobra.Command{
+			Use:   "params <name>",
+			Short: "Show the build strategy parameters",
+			Long:  buildStrategyParamsLongDesc,
+			Args:  cobra.ExactArgs(1),
+		},
+	}
+
+	paramsCommand.cmd.Flags().Var(flags.NewStrategyKindValue(&paramsCommand.kind), flags.StrategyKindFlag,
+		"build-strategy kind, either BuildStrategy or ClusterBuildStrategy, by default both are looked up")
+	paramsCommand.cmd.Flags().StringVarP(&paramsCommand.output, flags.OutputFlag, "o", "", "Output format, either empty for a table, json or yaml")
+	return paramsCommand
+}
+
+// Cmd returns cobra command object
+func (c *ParamsCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *ParamsCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *ParamsCommand) Validate() error {
+	switch c.output {
+	case "", "json", "yaml":
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q, either json or yaml", c.output)
+}
+
+// Run prints the parameters of the build strategy.
+func (c *ParamsCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	strategy, err := c.buildStrategy(params)
+	if err != nil {
+		return err
+	}
+	result := NewStrategyParameters(c.kind, c.name, strategy)
+
+	switch c.output {
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(ioStreams.Out, string(data))
+		return nil
+	case "yaml":
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(ioStreams.Out, string(data))
+		return nil
+	}
+
+	if len(result.Parameters) == 0 {
+		fmt.Fprintf(ioStreams.Out, "%s %q declares no parameters\n", result.Kind, c.name)
+		return nil
+	}
+	writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tTYPE\tDEFAULT\tDESCRIPTION")
+	for _, p := range result.Parameters {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", p.Name, p.Type, p.defaultValue(), p.Description)
+	}
+	return writer.Flush()
+}
+
+// defaultValue returns the default value in human readable form, the array defaults are listed
+// comma separated, and a parameter without default is required.
+func (p *Parameter) defaultValue() string {
+	switch {
+	case p.Default != nil:
+		return *p.Default
+	case p.Defaults != nil:
+		return fmt.Sprintf("[%s]", strings.Join(p.Defaults, ","))
+	default:
+		return "<required>"
+	}
+}
+
+// buildStrategy retrieves the build strategy in the informed scope, or otherwise looks it up as
+// BuildStrategy and ClusterBuildStrategy, recording the kind found. A name found in both scopes is
+// ambiguous.
+func (c *ParamsCommand) buildStrategy(params *params.Params) (buildv1alpha1.BuilderStrategy, error) {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return nil, err
+	}
+	ctx := c.cmd.Context()
+
+	var namespaced, cluster buildv1alpha1.BuilderStrategy
+	if c.kind == "" || c.kind == buildv1alpha1.NamespacedBuildStrategyKind {
+		bs, err := clientset.ShipwrightV1alpha1().BuildStrategies(params.Namespace()).Get(ctx, c.name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			namespaced = bs
+		case !k8serrors.IsNotFound(err):
+			return nil, err
+		}
+	}
+	if c.kind == "" || c.kind == buildv1alpha1.ClusterBuildStrategyKind {
+		cbs, err := clientset.ShipwrightV1alpha1().ClusterBuildStrategies().Get(ctx, c.name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			cluster = cbs
+		case !k8serrors.IsNotFound(err):
+			return nil, err
+		}
+	}
+
+	switch {
+	case namespaced != nil && cluster != nil:
+		return nil, fmt.Errorf("%q exists both as %s and %s, use --%s to pick one", c.name,
+			buildv1alpha1.NamespacedBuildStrategyKind, buildv1alpha1.ClusterBuildStrategyKind, flags.StrategyKindFlag)
+	case namespaced != nil:
+		c.kind = buildv1alpha1.NamespacedBuildStrategyKind
+		return namespaced, nil
+	case cluster != nil:
+		c.kind = buildv1alpha1.ClusterBuildStrategyKind
+		return cluster, nil
+	case c.kind != "":
+		return nil, fmt.Errorf("%s %q not found", c.kind, c.name)
+	}
+	return nil, fmt.Errorf("build strategy %q not found, neither as %s in namespace %q nor as %s", c.name,
+		buildv1alpha1.NamespacedBuildStrategyKind, params.Namespace(), buildv1alpha1.ClusterBuildStrategyKind)
+}
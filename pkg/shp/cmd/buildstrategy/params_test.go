@@ -0,0 +1,76 @@
+package buildstrategy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/pointer"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestBuildStrategyParams(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	parameters := []buildv1alpha1.Parameter{
+		{Name: "dockerfile", Description: "path to the Dockerfile", Default: pointer.String("Dockerfile")},
+		{Name: "build-args", Description: "build arguments", Type: buildv1alpha1.ParameterTypeArray, Defaults: &[]string{}},
+		{Name: "target", Description: "target stage"},
+	}
+	shpclientset := shpfake.NewSimpleClientset(
+		&buildv1alpha1.ClusterBuildStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: "buildah"},
+			Spec:       buildv1alpha1.BuildStrategySpec{Parameters: parameters},
+		},
+		&buildv1alpha1.ClusterBuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: "kaniko"}},
+		&buildv1alpha1.BuildStrategy{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "kaniko"}},
+	)
+	param := params.NewParamsForTest(nil, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	run := func(args ...string) (string, error) {
+		cmd := paramsCmd().(*ParamsCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().ParseFlags(args[1:])).To(gomega.Succeed())
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.Complete(param, &ioStreams, args[:1])).To(gomega.Succeed())
+		if err := cmd.Validate(); err != nil {
+			return "", err
+		}
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), err
+	}
+
+	out, err := run("buildah")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.MatchRegexp(`dockerfile\s+string\s+Dockerfile\s+path to the Dockerfile`))
+	g.Expect(out).To(gomega.MatchRegexp(`build-args\s+array\s+\[\]\s+build arguments`))
+	g.Expect(out).To(gomega.MatchRegexp(`target\s+string\s+<required>\s+target stage`))
+
+	out, err = run("buildah", "-o", "json")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.ContainSubstring(`"kind": "ClusterBuildStrategy"`))
+	g.Expect(out).To(gomega.ContainSubstring(`"default": "Dockerfile"`))
+
+	_, err = run("kaniko")
+	g.Expect(err).To(gomega.MatchError(
+		`"kaniko" exists both as BuildStrategy and ClusterBuildStrategy, use --strategy-kind to pick one`))
+
+	out, err = run("kaniko", "--strategy-kind", "BuildStrategy", "-o", "yaml")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.Equal("kind: BuildStrategy\nname: kaniko\nparameters: []\n"))
+
+	_, err = run("buildah", "--strategy-kind", "BuildStrategy")
+	g.Expect(err).To(gomega.MatchError(`BuildStrategy "buildah" not found`))
+
+	_, err = run("buildpacks")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`build strategy "buildpacks" not found`)))
+
+	_, err = run("buildah", "-o", "wide")
+	g.Expect(err).To(gomega.MatchError(`unsupported output format "wide", either json or yaml`))
+}
@@ -0,0 +1,310 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/util"
+)
+
+const (
+	// exitCodeFailed exit code when a critical check has failed.
+	exitCodeFailed = 1
+
+	// controllerNamespace namespace where the Shipwright Build controller is deployed.
+	controllerNamespace = "shipwright-build"
+	// controllerName name of the Shipwright Build controller deployment.
+	controllerName = "shipwright-build-controller"
+)
+
+// checkStatus outcome of a check, as printed on the checklist.
+type checkStatus string
+
+const (
+	statusPass checkStatus = "PASS"
+	statusFail checkStatus = "FAIL"
+	statusWarn checkStatus = "WARN" // a non-critical check has failed
+	statusSkip checkStatus = "SKIP" // not run since an earlier check has failed
+)
+
+// check a single verification of the environment, returning a short description of what was found,
+// or an error describing why it has failed.
+type check struct {
+	name     string
+	critical bool // a failure makes the command exit with non-zero status
+	required bool // a failure skips the remaining checks, which depend on it
+	run      func(ctx context.Context, p *params.Params) (string, error)
+}
+
+// accessReview a permission verified with a SelfSubjectAccessReview.
+type accessReview struct {
+	verb     string
+	resource string
+}
+
+// accessReviews the permissions needed to create Builds and run them.
+var accessReviews = []accessReview{
+	{verb: "list", resource: "builds"},
+	{verb: "create", resource: "builds"},
+	{verb: "create", resource: "buildruns"},
+}
+
+// CheckCommand represents the "check" subcommand.
+type CheckCommand struct {
+	cmd *cobra.Command
+}
+
+const checkLongDesc = `
+Verifies the environment is ready to run builds, and prints a checklist with the outcome of each
+check. It is a good first step when something doesn't work as expected:
+
+	$ shp check
+
+The following is checked, in order:
+
+	kubeconfig          the kubeconfig is loaded
+	cluster             the Kubernetes API server is reachable
+	shipwright-crds     the Build and BuildRun resources are installed
+	permissions         Builds can be listed and created, and BuildRuns created, in the namespace
+	build-strategies    at least one BuildStrategy or ClusterBuildStrategy exists
+	build-controller    the Shipwright Build controller is deployed and available
+
+When the kubeconfig can't be loaded or the cluster is not reachable, the remaining checks are
+skipped. The command exits with non-zero status when a critical check fails, the build controller is
+only checked in the "shipwright-build" namespace, hence a failure is a warning.
+`
+
+// Command returns the "check" subcommand of Shipwright CLI, which verifies the environment.
+func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Command {
+	return runner.NewRunner(p, ioStreams, checkCmd()).Cmd()
+}
+
+func checkCmd() runner.SubCommand {
+	return &CheckCommand{
+		cmd: &cobra.Command{
+			Use:   "check",
+			Short: "Verify the environment is ready to run builds",
+			Long:  checkLongDesc,
+			Args:  cobra.NoArgs,
+			Annotations: map[string]string{
+				"commandType": "main",
+			},
+		},
+	}
+}
+
+// Cmd returns cobra command object
+func (c *CheckCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *CheckCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, _ []string) error {
+	return nil
+}
+
+// Validate validates data input by user
+func (c *CheckCommand) Validate() error {
+	return nil
+}
+
+// Run runs the checks in order, printing the outcome of each one, and exits with non-zero status
+// when a critical check has failed.
+func (c *CheckCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	ctx := c.cmd.Context()
+	color := params.Color(ioStreams.Out)
+
+	failed := 0
+	skip := false
+	for _, chk := range checks() {
+		status, message := statusSkip, "an earlier check has failed"
+		if !skip {
+			var err error
+			if message, err = chk.run(ctx, params); err != nil {
+				status, message = statusWarn, err.Error()
+				if chk.critical {
+					status = statusFail
+					failed++
+				}
+				skip = chk.required
+			} else {
+				status = statusPass
+			}
+		}
+		fmt.Fprintf(ioStreams.Out, "[%s] %s: %s\n", colorStatus(status, color), chk.name, message)
+	}
+
+	if failed > 0 {
+		params.Logger(ioStreams.ErrOut).Warning(fmt.Sprintf("%d critical check(s) failed", failed))
+		return &runner.ExitError{Code: exitCodeFailed}
+	}
+	return nil
+}
+
+// colorStatus colors the status, green when passed and red when failed.
+func colorStatus(status checkStatus, enabled bool) string {
+	conditionStatus := corev1.ConditionUnknown
+	switch status {
+	case statusPass:
+		conditionStatus = corev1.ConditionTrue
+	case statusFail:
+		conditionStatus = corev1.ConditionFalse
+	}
+	return util.ColorStatus(string(status), conditionStatus, enabled)
+}
+
+// checks returns the checks in the order they run.
+func checks() []check {
+	return []check{
+		{name: "kubeconfig", critical: true, required: true, run: checkKubeconfig},
+		{name: "cluster", critical: true, required: true, run: checkCluster},
+		{name: "shipwright-crds", critical: true, run: checkCRDs},
+		{name: "permissions", critical: true, run: checkPermissions},
+		{name: "build-strategies", critical: true, run: checkBuildStrategies},
+		{name: "build-controller", run: checkBuildController},
+	}
+}
+
+// checkKubeconfig verifies the kubeconfig is loaded, and tells the namespace in use.
+func checkKubeconfig(_ context.Context, p *params.Params) (string, error) {
+	if _, err := p.ClientSet(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("loaded, using namespace %q", p.Namespace()), nil
+}
+
+// checkCluster verifies the API server is reachable, and tells its version.
+func checkCluster(_ context.Context, p *params.Params) (string, error) {
+	clientset, err := p.ClientSet()
+	if err != nil {
+		return "", err
+	}
+	info, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("unable to reach the API server: %w", err)
+	}
+	return fmt.Sprintf("reachable, Kubernetes %s", info.GitVersion), nil
+}
+
+// checkCRDs verifies the Build and BuildRun resources are served by the API server.
+func checkCRDs(_ context.Context, p *params.Params) (string, error) {
+	clientset, err := p.ClientSet()
+	if err != nil {
+		return "", err
+	}
+	groupVersion := buildv1alpha1.SchemeGroupVersion.String()
+	resources, err := clientset.Discovery().ServerResourcesForGroupVersion(groupVersion)
+	if k8serrors.IsNotFound(err) {
+		return "", fmt.Errorf("%s is not installed", groupVersion)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	found := sets.NewString()
+	for _, resource := range resources.APIResources {
+		found.Insert(resource.Name)
+	}
+	if missing := sets.NewString("builds", "buildruns").Difference(found); missing.Len() > 0 {
+		return "", fmt.Errorf("%s does not serve %s", groupVersion, strings.Join(missing.List(), ", "))
+	}
+	return fmt.Sprintf("builds and buildruns installed as %s", groupVersion), nil
+}
+
+// checkPermissions verifies the current user is allowed to manage Builds and BuildRuns in the
+// namespace.
+func checkPermissions(ctx context.Context, p *params.Params) (string, error) {
+	clientset, err := p.ClientSet()
+	if err != nil {
+		return "", err
+	}
+	allowed, denied := []string{}, []string{}
+	for _, review := range accessReviews {
+		ssar := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: p.Namespace(),
+					Verb:      review.verb,
+					Group:     buildv1alpha1.SchemeGroupVersion.Group,
+					Resource:  review.resource,
+				},
+			},
+		}
+		result, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, ssar, metav1.CreateOptions{})
+		if err != nil {
+			return "", fmt.Errorf("unable to review the access to %s: %w", review.resource, err)
+		}
+		permission := fmt.Sprintf("%s %s", review.verb, review.resource)
+		if result.Status.Allowed {
+			allowed = append(allowed, permission)
+		} else {
+			denied = append(denied, permission)
+		}
+	}
+	if len(denied) > 0 {
+		return "", fmt.Errorf("not allowed to %s in namespace %q", strings.Join(denied, ", "), p.Namespace())
+	}
+	return fmt.Sprintf("allowed to %s in namespace %q", strings.Join(allowed, ", "), p.Namespace()), nil
+}
+
+// checkBuildStrategies verifies there is at least one build strategy to use, either namespaced or
+// cluster scoped. Not being allowed to list one of the scopes is fine, as long as the other has
+// strategies.
+func checkBuildStrategies(ctx context.Context, p *params.Params) (string, error) {
+	clientset, err := p.ShipwrightClientSet()
+	if err != nil {
+		return "", err
+	}
+	namespaced, cluster := 0, 0
+	errs := []string{}
+	bsList, err := clientset.ShipwrightV1alpha1().BuildStrategies(p.Namespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		namespaced = len(bsList.Items)
+	}
+	cbsList, err := clientset.ShipwrightV1alpha1().ClusterBuildStrategies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		cluster = len(cbsList.Items)
+	}
+
+	if namespaced+cluster == 0 {
+		if len(errs) > 0 {
+			return "", errors.New(strings.Join(errs, "; "))
+		}
+		return "", fmt.Errorf("neither BuildStrategy in namespace %q nor ClusterBuildStrategy found", p.Namespace())
+	}
+	return fmt.Sprintf("%d BuildStrategy in namespace %q, %d ClusterBuildStrategy", namespaced, p.Namespace(), cluster), nil
+}
+
+// checkBuildController verifies the Shipwright Build controller deployment has available replicas.
+func checkBuildController(ctx context.Context, p *params.Params) (string, error) {
+	clientset, err := p.ClientSet()
+	if err != nil {
+		return "", err
+	}
+	deployment, err := clientset.AppsV1().Deployments(controllerNamespace).Get(ctx, controllerName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to find deployment %s/%s: %w", controllerNamespace, controllerName, err)
+	}
+	if deployment.Status.AvailableReplicas == 0 {
+		return "", fmt.Errorf("deployment %s/%s has no available replicas", controllerNamespace, controllerName)
+	}
+	return fmt.Sprintf("deployment %s/%s available", controllerNamespace, controllerName), nil
+}
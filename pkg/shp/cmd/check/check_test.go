@@ -0,0 +1,126 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	fakekubetesting "k8s.io/client-go/testing"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestCheckCommand(t *testing.T) {
+	controller := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: controllerNamespace, Name: controllerName},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+	}
+	strategy := &buildv1alpha1.ClusterBuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: "buildah"}}
+
+	tests := []struct {
+		name        string
+		unreachable bool
+		crds        bool
+		denied      string // resource the user is not allowed to create
+		objects     []runtime.Object
+		strategies  []runtime.Object
+		expected    []string
+		failed      bool
+	}{{
+		name:       "ready",
+		crds:       true,
+		objects:    []runtime.Object{controller},
+		strategies: []runtime.Object{strategy},
+		expected: []string{
+			"[PASS] kubeconfig: loaded, using namespace \"default\"\n",
+			"[PASS] cluster: reachable, Kubernetes v1.27.3\n",
+			"[PASS] shipwright-crds: builds and buildruns installed as shipwright.io/v1alpha1\n",
+			"[PASS] permissions: allowed to list builds, create builds, create buildruns in namespace \"default\"\n",
+			"[PASS] build-strategies: 0 BuildStrategy in namespace \"default\", 1 ClusterBuildStrategy\n",
+			"[PASS] build-controller: deployment shipwright-build/shipwright-build-controller available\n",
+		},
+	}, {
+		name:       "controller not found is a warning",
+		crds:       true,
+		strategies: []runtime.Object{strategy},
+		expected: []string{
+			"[WARN] build-controller: unable to find deployment shipwright-build/shipwright-build-controller",
+		},
+	}, {
+		name:   "not installed",
+		denied: "buildruns",
+		expected: []string{
+			"[FAIL] shipwright-crds: shipwright.io/v1alpha1 is not installed\n",
+			"[FAIL] permissions: not allowed to create buildruns in namespace \"default\"\n",
+			"[FAIL] build-strategies: neither BuildStrategy in namespace \"default\" nor ClusterBuildStrategy found\n",
+		},
+		failed: true,
+	}, {
+		name:        "cluster unreachable",
+		unreachable: true,
+		expected: []string{
+			"[FAIL] cluster: unable to reach the API server: connection refused\n",
+			"[SKIP] shipwright-crds: an earlier check has failed\n",
+			"[SKIP] build-controller: an earlier check has failed\n",
+		},
+		failed: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			clientset := fake.NewSimpleClientset(test.objects...)
+			discovery := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+			discovery.FakedServerVersion = &version.Info{GitVersion: "v1.27.3"}
+			if test.crds {
+				discovery.Resources = []*metav1.APIResourceList{{
+					GroupVersion: buildv1alpha1.SchemeGroupVersion.String(),
+					APIResources: []metav1.APIResource{{Name: "builds"}, {Name: "buildruns"}},
+				}}
+			}
+			if test.unreachable {
+				clientset.PrependReactor("get", "version", func(_ fakekubetesting.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("connection refused")
+				})
+			}
+			clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action fakekubetesting.Action) (bool, runtime.Object, error) {
+				ssar := action.(fakekubetesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				ssar.Status.Allowed = ssar.Spec.ResourceAttributes.Resource != test.denied
+				return true, ssar, nil
+			})
+
+			cmd := checkCmd().(*CheckCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+
+			p := params.NewParamsForTest(clientset, shpfake.NewSimpleClientset(test.strategies...), nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+			err := cmd.Run(p, &ioStreams)
+			for _, expected := range test.expected {
+				g.Expect(out.String()).To(o.ContainSubstring(expected))
+			}
+			if test.failed {
+				var exitErr *runner.ExitError
+				g.Expect(errors.As(err, &exitErr)).To(o.BeTrue())
+				g.Expect(exitErr.Code).To(o.Equal(exitCodeFailed))
+				g.Expect(errOut.String()).To(o.ContainSubstring("critical check(s) failed"))
+			} else {
+				g.Expect(err).ToNot(o.HaveOccurred())
+			}
+		})
+	}
+}
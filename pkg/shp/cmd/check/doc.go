@@ -0,0 +1,2 @@
+// Package check contains types and functions for check cobra sub-command
+package check
@@ -0,0 +1,29 @@
+package clusterbuildstrategy
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// Command represents "shp clusterbuildstrategy" sub-command.
+func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "clusterbuildstrategy",
+		Aliases: []string{"cbs"},
+		Short:   "Manage ClusterBuildStrategies",
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+	}
+
+	command.AddCommand(
+		runner.NewRunner(p, ioStreams, listCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, getCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, deleteCmd()).Cmd(),
+	)
+	return command
+}
@@ -0,0 +1,94 @@
+package clusterbuildstrategy
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/util"
+)
+
+// DeleteCommand represents the "clusterbuildstrategy delete" sub-command.
+type DeleteCommand struct {
+	cmd *cobra.Command
+
+	names []string
+	yes   bool // skips the confirmation prompt
+}
+
+const clusterBuildStrategyDeleteLongDesc = `
+Deletes the ClusterBuildStrategies informed by name. They are shared by the Builds of every
+namespace, the Builds referencing a deleted ClusterBuildStrategy fail to run afterwards. The
+deletion is confirmed interactively, unless --yes is informed or stdin is not a terminal.
+`
+
+func deleteCmd() runner.SubCommand {
+	deleteCommand := &DeleteCommand{
+		cmd: &cobra.Command{
+			Use:   "delete <name> [<name>...] [flags]",
+			Short: "Delete ClusterBuildStrategies",
+			Long:  clusterBuildStrategyDeleteLongDesc,
+			Args:  cobra.MinimumNArgs(1),
+		},
+	}
+	flags.YesFlags(deleteCommand.cmd.Flags(), &deleteCommand.yes)
+	return deleteCommand
+}
+
+// Cmd returns cobra command object
+func (c *DeleteCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *DeleteCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.names = args
+	return nil
+}
+
+// Validate validates data input by user
+func (c *DeleteCommand) Validate() error {
+	return nil
+}
+
+// Run deletes every informed ClusterBuildStrategy even when deleting others fail, the errors are
+// returned together at the end.
+func (c *DeleteCommand) Run(params *params.Params, io *genericclioptions.IOStreams) error {
+	// the strategies are cluster wide, even a single deletion is confirmed
+	if !c.yes && params.Interactive(io.In) {
+		question := fmt.Sprintf("About to delete %d ClusterBuildStrategies, continue?", len(c.names))
+		if len(c.names) == 1 {
+			question = fmt.Sprintf("About to delete ClusterBuildStrategy %q, continue?", c.names[0])
+		}
+		confirmed, err := util.Confirm(io.In, io.ErrOut, question)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(io.Out, "Deletion canceled, no ClusterBuildStrategies deleted")
+			return nil
+		}
+	}
+
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	errs := []error{}
+	for _, name := range c.names {
+		if err := clientset.ShipwrightV1alpha1().ClusterBuildStrategies().Delete(c.cmd.Context(), name, metav1.DeleteOptions{}); err != nil {
+			params.Logger(io.ErrOut).Warning(fmt.Sprintf("Error deleting ClusterBuildStrategy %q: %v", name, err))
+			errs = append(errs, fmt.Errorf("failed to delete ClusterBuildStrategy %q: %w", name, err))
+			continue
+		}
+		fmt.Fprintf(io.Out, "ClusterBuildStrategy deleted %q\n", name)
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,47 @@
+package clusterbuildstrategy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestDeleteClusterBuildStrategies(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	strategy := func(name string) *buildv1alpha1.ClusterBuildStrategy {
+		return &buildv1alpha1.ClusterBuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	shpclientset := shpfake.NewSimpleClientset(strategy("buildah"), strategy("kaniko"), strategy("ko"))
+	param := params.NewParamsForTest(nil, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	// cluster scoped, the namespace is not verified
+	_, mutating := deleteCmd().(runner.MutatingCommand)
+	g.Expect(mutating).To(gomega.BeFalse())
+
+	cmd := deleteCmd().(*DeleteCommand)
+	cmd.Cmd().SetContext(context.Background())
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, []string{"buildah", "buildpacks", "kaniko"})).To(gomega.Succeed())
+
+	// the missing strategy does not prevent deleting the others
+	err := cmd.Run(param, &ioStreams)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`failed to delete ClusterBuildStrategy "buildpacks"`)))
+	g.Expect(out.String()).To(gomega.Equal("ClusterBuildStrategy deleted \"buildah\"\nClusterBuildStrategy deleted \"kaniko\"\n"))
+	g.Expect(errOut.String()).To(gomega.ContainSubstring(`Error deleting ClusterBuildStrategy "buildpacks"`))
+
+	strategies, err := shpclientset.ShipwrightV1alpha1().ClusterBuildStrategies().List(context.Background(), metav1.ListOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(strategies.Items).To(gomega.HaveLen(1))
+	g.Expect(strategies.Items[0].Name).To(gomega.Equal("ko"))
+}
@@ -0,0 +1,2 @@
+// Package clusterbuildstrategy contains types and functions for clusterbuildstrategy cobra sub-command
+package clusterbuildstrategy
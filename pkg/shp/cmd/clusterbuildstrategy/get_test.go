@@ -0,0 +1,58 @@
+package clusterbuildstrategy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestClusterBuildStrategyGet(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	shpclientset := shpfake.NewSimpleClientset(&buildv1alpha1.ClusterBuildStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: "buildah"},
+		Spec: buildv1alpha1.BuildStrategySpec{
+			BuildSteps: []buildv1alpha1.BuildStep{
+				{Container: corev1.Container{Name: "build-and-push", Image: "quay.io/containers/buildah"}},
+			},
+			Parameters: []buildv1alpha1.Parameter{{Name: "target", Description: "target stage"}},
+		},
+	})
+	param := params.NewParamsForTest(nil, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+
+	run := func(args ...string) (string, error) {
+		cmd := getCmd().(*GetCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().ParseFlags(args[1:])).To(gomega.Succeed())
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.Complete(param, &ioStreams, args[:1])).To(gomega.Succeed())
+		if err := cmd.Validate(); err != nil {
+			return "", err
+		}
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), err
+	}
+
+	out, err := run("buildah")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.MatchRegexp(`^Name:\s+buildah\nKind:\s+ClusterBuildStrategy\nCreated:`))
+	g.Expect(out).To(gomega.MatchRegexp(`build-and-push\s+quay.io/containers/buildah\n`))
+	g.Expect(out).To(gomega.MatchRegexp(`target\s+string\s+<required>\s+target stage\n`))
+	g.Expect(out).To(gomega.HaveSuffix("Volumes:  <none>\n"))
+
+	out, err = run("buildah", "-o", "yaml")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.ContainSubstring("kind: ClusterBuildStrategy\n"))
+
+	_, err = run("kaniko")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`"kaniko" not found`)))
+}
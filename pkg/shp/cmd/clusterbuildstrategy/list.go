@@ -0,0 +1,129 @@
+package clusterbuildstrategy
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/buildstrategy"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// showParamsFlag command-line flag, renders the parameters of each ClusterBuildStrategy.
+const showParamsFlag = "show-params"
+
+// ListCommand represents the "clusterbuildstrategy list" sub-command.
+type ListCommand struct {
+	cmd *cobra.Command
+
+	noHeader   bool
+	showParams bool // prints the parameters of each strategy instead of the table
+	output     *flags.OutputOptions
+}
+
+const clusterBuildStrategyListLongDesc = `
+Lists the ClusterBuildStrategies, with the names of their steps, parameters and volumes. Use
+"shp clusterbuildstrategy get" to see the details of a ClusterBuildStrategy.
+
+With --show-params the parameters of each ClusterBuildStrategy are shown instead, with their type,
+default and description, to tell which "--param-value" keys the Builds using it accept. The ones
+without default are required:
+
+	$ shp clusterbuildstrategy list --show-params
+
+For scripts, --output json or yaml prints the ClusterBuildStrategies as a ClusterBuildStrategyList:
+
+	$ shp clusterbuildstrategy list -o yaml
+`
+
+func listCmd() runner.SubCommand {
+	listCommand := &ListCommand{
+		cmd: &cobra.Command{
+			Use:   "list [flags]",
+			Short: "List ClusterBuildStrategies",
+			Long:  clusterBuildStrategyListLongDesc,
+			Args:  cobra.NoArgs,
+		},
+	}
+
+	listCommand.cmd.Flags().BoolVar(&listCommand.noHeader, "no-header", false, "Do not show columns header in list output")
+	listCommand.cmd.Flags().BoolVar(&listCommand.showParams, showParamsFlag, false, "Show the parameters of each ClusterBuildStrategy, with their type, default and description")
+	listCommand.output = flags.OutputFlags(listCommand.cmd.Flags())
+	return listCommand
+}
+
+// Cmd returns cobra command object
+func (c *ListCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *ListCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, _ []string) error {
+	c.output.NoHeaders = c.noHeader
+	// the output format taken from SHP_OUTPUT does not apply to the parameters
+	if c.showParams && !c.cmd.Flags().Changed(flags.OutputFlag) {
+		c.output.Format = ""
+	}
+	return nil
+}
+
+// Validate validates data input by user
+func (c *ListCommand) Validate() error {
+	if c.showParams && c.output.Enabled() {
+		return fmt.Errorf("--%s can't be used together with --%s", showParamsFlag, flags.OutputFlag)
+	}
+	return c.output.Validate()
+}
+
+// Run lists the ClusterBuildStrategies, sorted by name.
+func (c *ListCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	list, err := clientset.ShipwrightV1alpha1().ClusterBuildStrategies().List(c.cmd.Context(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(list.Items, func(i, j int) bool {
+		return list.Items[i].Name < list.Items[j].Name
+	})
+
+	if c.output.Enabled() {
+		return c.output.PrintObject(ioStreams.Out, list)
+	}
+	if len(list.Items) == 0 {
+		fmt.Fprintln(ioStreams.Out, "No ClusterBuildStrategies found.")
+		return nil
+	}
+
+	if c.showParams {
+		for i := range list.Items {
+			if err = buildstrategy.DescribeParameters(ioStreams.Out, list.Items[i].Name, &list.Items[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
+	if !c.noHeader {
+		fmt.Fprintln(writer, "NAME\tSTEPS\tPARAMETERS\tVOLUMES\tAGE")
+	}
+	for i := range list.Items {
+		cbs := &list.Items[i]
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", cbs.Name, buildstrategy.StepNames(cbs),
+			buildstrategy.ParameterNames(cbs), buildstrategy.VolumeNames(cbs),
+			duration.ShortHumanDuration(time.Since(cbs.CreationTimestamp.Time)))
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,88 @@
+package clusterbuildstrategy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/pointer"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestClusterBuildStrategyList(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	run := func(shpclientset *shpfake.Clientset, args ...string) (string, error) {
+		cmd := listCmd().(*ListCommand)
+		cmd.Cmd().SetContext(context.Background())
+		g.Expect(cmd.Cmd().ParseFlags(args)).To(gomega.Succeed())
+		param := params.NewParamsForTest(nil, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+		ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+		g.Expect(cmd.Complete(param, &ioStreams, nil)).To(gomega.Succeed())
+		if err := cmd.Validate(); err != nil {
+			return "", err
+		}
+		err := cmd.Run(param, &ioStreams)
+		return out.String(), err
+	}
+
+	out, err := run(shpfake.NewSimpleClientset())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.Equal("No ClusterBuildStrategies found.\n"))
+
+	shpclientset := shpfake.NewSimpleClientset(
+		&buildv1alpha1.ClusterBuildStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: "buildah"},
+			Spec: buildv1alpha1.BuildStrategySpec{
+				BuildSteps: []buildv1alpha1.BuildStep{{Container: corev1.Container{Name: "build-and-push"}}},
+				Parameters: []buildv1alpha1.Parameter{
+					{Name: "dockerfile", Description: "path to the Dockerfile", Default: pointer.String("Dockerfile")},
+					{Name: "build-args", Description: "build arguments", Type: buildv1alpha1.ParameterTypeArray, Defaults: &[]string{}},
+					{Name: "target", Description: "target stage"},
+				},
+			},
+		},
+		&buildv1alpha1.ClusterBuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: "ko"}},
+		&buildv1alpha1.BuildStrategy{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "kaniko"}},
+	)
+
+	out, err = run(shpclientset)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.MatchRegexp(`^NAME\s+STEPS\s+PARAMETERS\s+VOLUMES\s+AGE\n` +
+		`buildah\s+build-and-push\s+dockerfile,build-args,target\s+<none>\s+\S+\n` +
+		`ko\s+<none>\s+<none>\s+<none>\s+\S+\n$`))
+
+	out, err = run(shpclientset, "--show-params")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.HavePrefix("buildah:\n"))
+	g.Expect(out).To(gomega.MatchRegexp(`dockerfile\s+string\s+Dockerfile\s+path to the Dockerfile`))
+	g.Expect(out).To(gomega.MatchRegexp(`build-args\s+array\s+\[\]\s+build arguments`))
+	g.Expect(out).To(gomega.MatchRegexp(`target\s+string\s+<required>\s+target stage`))
+	g.Expect(out).To(gomega.HaveSuffix("\nko:  <none>\n"))
+
+	out, err = run(shpclientset, "-o", "name")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(out).To(gomega.Equal("clusterbuildstrategy.shipwright.io/buildah\nclusterbuildstrategy.shipwright.io/ko\n"))
+
+	_, err = run(shpclientset, "--show-params", "-o", "yaml")
+	g.Expect(err).To(gomega.MatchError("--show-params can't be used together with --output"))
+
+	// the output format taken from the environment gives way to --show-params
+	cmd := listCmd().(*ListCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--show-params"})).To(gomega.Succeed())
+	g.Expect(cmd.Cmd().Flags().Lookup("output").Value.Set("json")).To(gomega.Succeed())
+	param := params.NewParamsForTest(nil, shpclientset, nil, metav1.NamespaceDefault, nil, nil)
+	ioStreams, _, paramsOut, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Complete(param, &ioStreams, nil)).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).To(gomega.Succeed())
+	g.Expect(cmd.Run(param, &ioStreams)).To(gomega.Succeed())
+	g.Expect(paramsOut.String()).To(gomega.HavePrefix("buildah:\n"))
+}
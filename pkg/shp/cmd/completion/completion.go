@@ -0,0 +1,95 @@
+package completion
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+const completionLongDesc = `
+Generates the completion script for the informed shell, to be loaded in the current session:
+
+	$ source <(shp completion bash)
+	$ source <(shp completion zsh)
+	$ shp completion fish | source
+	PS> shp completion powershell | Out-String | Invoke-Expression
+
+When shp is installed as the kubectl plugin "kubectl-shp", kubectl delegates the completion of
+"kubectl shp" to an executable named "kubectl_complete-shp" on the PATH, with the contents:
+
+	#!/usr/bin/env sh
+	kubectl shp __complete "$@"
+`
+
+// shells supported shell names.
+var shells = []string{"bash", "zsh", "fish", "powershell"}
+
+// CompletionCommand represents the "completion" subcommand.
+type CompletionCommand struct {
+	cmd *cobra.Command
+
+	shell string
+}
+
+// Command returns the completion subcommand of Shipwright CLI, which generates the shell
+// completion scripts.
+func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Command {
+	return runner.NewRunner(p, ioStreams, completionCmd()).Cmd()
+}
+
+func completionCmd() runner.SubCommand {
+	return &CompletionCommand{
+		cmd: &cobra.Command{
+			Use:                   "completion [bash|zsh|fish|powershell]",
+			Short:                 "Generate the shell completion script",
+			Long:                  completionLongDesc,
+			Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+			ValidArgs:             shells,
+			DisableFlagsInUseLine: true,
+			Annotations: map[string]string{
+				"commandType": "main",
+			},
+		},
+	}
+}
+
+// Cmd returns cobra command object
+func (c *CompletionCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *CompletionCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.shell = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *CompletionCommand) Validate() error {
+	for _, shell := range shells {
+		if c.shell == shell {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported shell %q, expected one of %v", c.shell, shells)
+}
+
+// Run writes the completion script for the root command on the output stream.
+func (c *CompletionCommand) Run(_ *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	root := c.cmd.Root()
+	switch c.shell {
+	case "bash":
+		return root.GenBashCompletionV2(ioStreams.Out, true)
+	case "zsh":
+		return root.GenZshCompletion(ioStreams.Out)
+	case "fish":
+		return root.GenFishCompletion(ioStreams.Out, true)
+	default:
+		return root.GenPowerShellCompletionWithDesc(ioStreams.Out)
+	}
+}
@@ -0,0 +1,44 @@
+package completion
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	tests := []struct {
+		shell    string
+		expected string
+	}{
+		{shell: "bash", expected: "# bash completion V2 for shp"},
+		{shell: "zsh", expected: "#compdef shp"},
+		{shell: "fish", expected: "# fish completion for shp"},
+		{shell: "powershell", expected: "# powershell completion for shp"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.shell, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			root := &cobra.Command{Use: "shp"}
+			cmd := completionCmd().(*CompletionCommand)
+			root.AddCommand(cmd.Cmd())
+
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			g.Expect(cmd.Complete(nil, &ioStreams, []string{test.shell})).To(gomega.Succeed())
+			g.Expect(cmd.Validate()).To(gomega.Succeed())
+			g.Expect(cmd.Run(nil, &ioStreams)).To(gomega.Succeed())
+			g.Expect(out.String()).To(gomega.HavePrefix(test.expected))
+		})
+	}
+
+	cmd := completionCmd().(*CompletionCommand)
+	g := gomega.NewWithT(t)
+	g.Expect(cmd.Cmd().Args(cmd.Cmd(), []string{"tcsh"})).ToNot(gomega.Succeed())
+	g.Expect(cmd.Complete(nil, nil, []string{"tcsh"})).To(gomega.Succeed())
+	g.Expect(cmd.Validate()).ToNot(gomega.Succeed())
+}
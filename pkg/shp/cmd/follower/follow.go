@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +25,10 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+// DefaultJoinTimeout default maximum time waiting for the log streams to finish once the pod is
+// done, i.e. for sidecars writing their last lines after the build steps.
+const DefaultJoinTimeout = 5 * time.Second
+
 // Follower encapsulate the function of tailing the logs for Pods derived from BuildRuns
 type Follower struct {
 	ctx            context.Context              // global context instance
@@ -35,12 +40,37 @@ type Follower struct {
 
 	logTail         *tail.Tail      // follow container logs
 	tailLogsStarted map[string]bool // controls tail instance per container
+	pod             string          // only this pod is followed, when informed
+	replacedPods    map[string]bool // failed pods replaced by a new pod of the same BuildRun
 
-	logLock             sync.Mutex // avoiding race condition to print logs
-	enteredRunningState bool       // target pod is running
+	logLock             sync.Mutex   // avoiding race condition to print logs
+	logger              *util.Logger // follower messages, on stdout unless formatted as JSON
+	progressLogger      *util.Logger // progress status lines, on stderr
+	enteredRunningState bool         // target pod is running
 
 	failPollInterval time.Duration // for use in the PollInterval call when processing failed pods
 	failPollTimeout  time.Duration // for use in the PollInterval call when processing failed pods
+	joinTimeout      time.Duration // maximum time waiting for log streams to finish on stop
+
+	logFormat string // format of the follower messages
+	raw       bool   // log streams written as is, the follower messages are moved to stderr
+	container string // only follow the logs of this container, when informed
+	watchOnly bool   // only stream the logs written from now on
+
+	colorByStep bool            // colors the container name of the log lines, one color per step
+	stepColors  util.StepColors // colors assigned to the pod steps, once the pod is known
+
+	quiet            bool           // suppress the progress status lines
+	progressInterval time.Duration  // interval between the progress status lines
+	progress         string         // latest pod status, guarded by logLock
+	progressDone     chan struct{}  // closed when the progress reporting must stop
+	progressOnce     sync.Once      // closing the progress channel only once
+	progressWg       sync.WaitGroup // waiting for the progress reporting to finish
+
+	failFast     bool          // stop as soon as the BuildRun fails, without draining the log streams
+	failFastErr  error         // the BuildRun failure which stopped the follower, guarded by logLock
+	failFastDone chan struct{} // closed when the BuildRun failure watch must stop
+	failFastOnce sync.Once     // closing the failure watch channel only once
 }
 
 // NewFollower returns a Follower instance.
@@ -63,17 +93,42 @@ func NewFollower(
 		logTail:          tail.NewTail(ctx, clientset),
 		logLock:          sync.Mutex{},
 		tailLogsStarted:  map[string]bool{},
+		replacedPods:     map[string]bool{},
 		failPollInterval: 1 * time.Second,
 		failPollTimeout:  15 * time.Second,
+		joinTimeout:      DefaultJoinTimeout,
+		progressInterval: 5 * time.Second,
+		progressDone:     make(chan struct{}),
+		failFastDone:     make(chan struct{}),
 	}
+	f.SetLogFormat(util.LogFormatText)
+
+	// the log lines are written holding the same lock as the follower messages, so concurrent
+	// writes are never interleaved
+	f.logTail.SetStdout(&logWriter{lock: &f.logLock, w: ioStreams.Out})
+	f.logTail.SetStderr(&logWriter{lock: &f.logLock, w: ioStreams.ErrOut})
 
 	f.pw.WithOnPodModifiedFn(f.OnEvent)
 	f.pw.WithTimeoutPodFn(f.OnTimeout)
 	f.pw.WithNoPodEventsYetFn(f.OnNoPodEventsYet)
+	f.pw.WithSchedulerTimeoutFn(f.OnSchedulerTimeout)
 
 	return f
 }
 
+// logWriter serializes the writes on the informed writer using a shared lock.
+type logWriter struct {
+	lock *sync.Mutex
+	w    io.Writer
+}
+
+// Write writes the data holding the lock.
+func (l *logWriter) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.w.Write(p)
+}
+
 // SetBuildRunName allows for setting of the BuildRun name after to call to NewFollower.  This help service
 // auto generation of the BuildRun name from the Build.  NOTE, if the BuildRun name
 // is not set prior to the call to WaitForCompletion, the Follower will not function fully once events arrive.
@@ -91,6 +146,113 @@ func (f *Follower) SetFailPollTimeout(t time.Duration) {
 	f.failPollTimeout = t
 }
 
+// SetJoinTimeout sets the maximum time waiting for the log streams to finish once the pod is done,
+// including the containers yet to start, the remaining log lines are discarded when it expires.
+func (f *Follower) SetJoinTimeout(t time.Duration) {
+	f.joinTimeout = t
+}
+
+// SetQuiet suppresses the progress status lines printed while waiting for the pod to start.
+func (f *Follower) SetQuiet(quiet bool) {
+	f.quiet = quiet
+}
+
+// SetLogFormat sets the format of the follower's own messages, the JSON lines are written on stderr
+// so the build logs are the only output on stdout.
+func (f *Follower) SetLogFormat(format string) {
+	f.logFormat = format
+	f.setLoggers()
+}
+
+// SetRaw writes the container logs exactly as received, without the container name prefix and the
+// headers, moving the follower's own messages to stderr.
+func (f *Follower) SetRaw(raw bool) {
+	f.raw = raw
+	f.logTail.SetRaw(raw)
+	f.setLoggers()
+}
+
+// SetContainer restricts the logs followed to the informed container, empty means all containers.
+func (f *Follower) SetContainer(container string) {
+	f.container = container
+}
+
+// SetPod restricts the pod followed to the informed one, for BuildRuns with several pods, empty
+// means any pod of the BuildRun.
+func (f *Follower) SetPod(pod string) {
+	if pod == "" {
+		return
+	}
+	f.pod = pod
+	f.pw.WithSkipPodFn(func(p *corev1.Pod) bool {
+		return p.Name != pod
+	})
+}
+
+// SetColorByStep colors the container name prefix of the log lines with a color per step, stable
+// across runs for the same steps.
+func (f *Follower) SetColorByStep(colorByStep bool) {
+	f.colorByStep = colorByStep
+}
+
+// SetFailFast watches the BuildRun while the logs are streamed, stopping right away once it has
+// failed instead of waiting for the log streams to drain.
+func (f *Follower) SetFailFast(failFast bool) {
+	f.failFast = failFast
+}
+
+// assignStepColors assigns the step colors out of the pod containers, only once so the colors
+// don't change while the logs are streamed.
+func (f *Follower) assignStepColors(pod *corev1.Pod) {
+	if !f.colorByStep || f.stepColors != nil {
+		return
+	}
+	names := []string{}
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		names = append(names, c.Name)
+	}
+	f.stepColors = util.NewStepColors(names)
+	f.logTail.SetStepColors(f.stepColors)
+}
+
+// SetWatchOnly streams only the logs written after the follower attaches to the pod, skipping the
+// logs written before, a pod which already succeeded has no logs printed.
+func (f *Follower) SetWatchOnly(watchOnly bool) {
+	f.watchOnly = watchOnly
+	f.logTail.SetWatchOnly(watchOnly)
+}
+
+// setLoggers instantiates the loggers for the follower messages and the progress status lines, the
+// messages are kept away from stdout when formatted as JSON or when the logs are written raw.
+func (f *Follower) setLoggers() {
+	if f.logFormat == util.LogFormatJSON {
+		f.logger = util.NewLogger(f.ioStreams.ErrOut, f.logFormat)
+		f.progressLogger = f.logger
+		return
+	}
+	if f.raw {
+		f.logger = util.NewLogger(f.ioStreams.ErrOut, util.LogFormatText)
+	} else {
+		f.logger = util.NewLogger(f.ioStreams.Out, util.LogFormatText)
+	}
+	f.progressLogger = util.NewLogger(f.ioStreams.ErrOut, util.LogFormatText)
+}
+
+// SetReconnectTail sets the amount of log lines repeated when a broken log stream is reconnected.
+func (f *Follower) SetReconnectTail(lines int64) {
+	f.logTail.SetReconnectTail(lines)
+}
+
+// SetSchedulerTimeout sets the maximum time for the BuildRun pod to start running, zero disables it.
+func (f *Follower) SetSchedulerTimeout(t time.Duration) {
+	f.pw.WithSchedulerTimeout(t)
+}
+
+// SetProgressInterval overrides the default interval between the progress status lines.
+func (f *Follower) SetProgressInterval(t time.Duration) {
+	f.progressInterval = t
+}
+
 // GetLogLock returns the mutex used for coordinating access to log buffers.
 func (f *Follower) GetLogLock() *sync.Mutex {
 	return &f.logLock
@@ -101,30 +263,103 @@ func (f *Follower) Log(msg string) {
 	// concurrent fmt.Fprintf(r.ioStream.Out...) calls need locking to avoid data races, as we 'write' to the stream
 	f.logLock.Lock()
 	defer f.logLock.Unlock()
-	fmt.Fprint(f.ioStreams.Out, msg)
+	f.logger.Info(msg)
 }
 
 // tailLogs start tailing logs for each container name in init-containers and containers, if not
 // started already.
 func (f *Follower) tailLogs(pod *corev1.Pod) {
+	f.assignStepColors(pod)
 	containers := append(pod.Spec.InitContainers, pod.Spec.Containers...)
 	for _, container := range containers {
 		if _, exists := f.tailLogsStarted[container.Name]; exists {
 			continue
 		}
+		if f.container != "" && container.Name != f.container {
+			continue
+		}
 		f.tailLogsStarted[container.Name] = true
 		f.logTail.Start(pod.GetNamespace(), pod.GetName(), container.Name)
 	}
 }
 
-// Stop stop log tail instance.
+// setProgress records the pod status to be reported on the next progress status line.
+func (f *Follower) setProgress(pod *corev1.Pod) {
+	f.logLock.Lock()
+	defer f.logLock.Unlock()
+	f.progress = fmt.Sprintf("Pod %q %s", pod.GetName(), podProgress(pod))
+}
+
+// reportProgress periodically prints the latest pod status on the error stream, until the logs
+// start streaming, the follower stops or the context is done.
+func (f *Follower) reportProgress() {
+	defer f.progressWg.Done()
+
+	ticker := time.NewTicker(f.progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-f.progressDone:
+			return
+		case <-ticker.C:
+			f.logLock.Lock()
+			progress := f.progress
+			if progress == "" {
+				progress = fmt.Sprintf("Waiting for BuildRun %q pod to be created", f.buildRun.Name)
+			}
+			f.progressLogger.Info(progress + "...")
+			f.logLock.Unlock()
+		}
+	}
+}
+
+// stopProgress stops the progress reporting, waiting for it to finish.
+func (f *Follower) stopProgress() {
+	f.progressOnce.Do(func() { close(f.progressDone) })
+	f.progressWg.Wait()
+}
+
+// podProgress describes the pod status out of its phase and the state of the containers waiting
+// to start, i.e. "Pending: ContainerCreating" or "ImagePullBackOff: Back-off pulling image".
+func podProgress(pod *corev1.Pod) string {
+	statuses := append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		waiting := status.State.Waiting
+		if waiting == nil || waiting.Reason == "" || waiting.Reason == "PodInitializing" {
+			continue
+		}
+		if waiting.Message != "" {
+			return fmt.Sprintf("%s: %s", waiting.Reason, waiting.Message)
+		}
+		return fmt.Sprintf("%s: %s", pod.Status.Phase, waiting.Reason)
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason != "" {
+			if c.Message != "" {
+				return fmt.Sprintf("%s: %s: %s", pod.Status.Phase, c.Reason, c.Message)
+			}
+			return fmt.Sprintf("%s: %s", pod.Status.Phase, c.Reason)
+		}
+	}
+	return string(pod.Status.Phase)
+}
+
+// Stop stop log tail instance, giving the log streams the chance to be completely written first.
 func (f *Follower) Stop() {
+	f.stopProgress()
+	f.logTail.Wait(f.joinTimeout)
 	f.logTail.Stop()
 	f.pw.Stop()
 }
 
 // OnEvent reacts on pod state changes, to start and stop tailing container logs.
 func (f *Follower) OnEvent(pod *corev1.Pod) error {
+	// the events of the pods already replaced are not relevant anymore
+	if f.replacedPods[pod.GetName()] {
+		return nil
+	}
 	switch pod.Status.Phase {
 	case corev1.PodRunning:
 		if !f.enteredRunningState {
@@ -136,12 +371,16 @@ func (f *Follower) OnEvent(pod *corev1.Pod) error {
 				}
 			}
 			if f.enteredRunningState {
+				f.stopProgress()
 				f.tailLogs(pod)
+			} else {
+				f.setProgress(pod)
 			}
 		}
 	case corev1.PodFailed:
 		msg := ""
 		var br *buildv1alpha1.BuildRun
+		var replacement *corev1.Pod
 		err := wait.PollUntilContextTimeout(f.ctx, f.failPollInterval, f.failPollTimeout, true, func(ctx context.Context) (done bool, err error) {
 			brClient := f.buildClientset.ShipwrightV1alpha1().BuildRuns(pod.Namespace)
 			br, err = brClient.Get(ctx, f.buildRun.Name, metav1.GetOptions{})
@@ -155,14 +394,19 @@ func (f *Follower) OnEvent(pod *corev1.Pod) error {
 			if br.IsDone() {
 				return true, nil
 			}
-			return false, nil
+			// the BuildRun goes on when the pod is replaced, i.e. evicted and rescheduled
+			replacement = f.replacementPod(ctx, pod)
+			return replacement != nil, nil
 		})
+		if err == nil && replacement != nil {
+			return f.reattach(pod, replacement)
+		}
 		if err != nil {
 			f.Log(fmt.Sprintf("gave up trying to get a buildrun %q in a terminal state for pod %q, proceeding with pod failure processing\n", f.buildRun.Name, pod.GetName()))
 		}
 		switch {
 		case br == nil:
-			msg = fmt.Sprintf("BuildRun %q has been deleted.\n", br.Name)
+			msg = fmt.Sprintf("BuildRun %q has been deleted.\n", f.buildRun.Name)
 		case err == nil && br.IsCanceled():
 			msg = fmt.Sprintf("BuildRun %q has been canceled.\n", br.Name)
 		case (err == nil && br.DeletionTimestamp != nil) || (err != nil && kerrors.IsNotFound(err)):
@@ -175,29 +419,44 @@ func (f *Follower) OnEvent(pod *corev1.Pod) error {
 		}
 		// see if because of deletion or cancelation
 		f.Log(msg)
+		if err != nil {
+			f.logWarningEvents(br)
+		}
 		f.Stop()
 		return err
 	case corev1.PodSucceeded:
 		// encountered scenarios where the build run quickly enough that the pod effectively skips the running state,
 		// or the events come in reverse order, and we never enter the tail
-		if !f.enteredRunningState {
+		if !f.enteredRunningState && !f.watchOnly {
 			f.Log(fmt.Sprintf("succeeded event for pod %q arrived before or in place of running event so dumping logs now\n", pod.GetName()))
+			f.assignStepColors(pod)
 			var b strings.Builder
 			for _, c := range pod.Spec.Containers {
+				if f.container != "" && c.Name != f.container {
+					continue
+				}
 				logs, err := util.GetPodLogs(f.ctx, f.clientset, *pod, c.Name)
 				if err != nil {
 					f.Log(fmt.Sprintf("could not get logs for container %q: %s\n", c.Name, err.Error()))
 					continue
 				}
-				fmt.Fprintf(&b, "*** Pod %q, container %q: ***\n\n", pod.Name, c.Name)
+				if f.raw {
+					b.WriteString(logs)
+					continue
+				}
+				fmt.Fprintf(&b, "%s\n\n", f.stepColors.Color(c.Name, fmt.Sprintf("*** Pod %q, container %q: ***", pod.Name, c.Name)))
 				fmt.Fprintln(&b, logs)
 			}
-			f.Log(b.String())
+			// the build logs are written as is, regardless of the log format
+			f.logLock.Lock()
+			fmt.Fprint(f.ioStreams.Out, b.String())
+			f.logLock.Unlock()
 		}
 		f.Log(fmt.Sprintf("Pod %q has succeeded!\n", pod.GetName()))
 		f.Stop()
 	default:
 		f.Log(fmt.Sprintf("Pod %q is in state %q...\n", pod.GetName(), string(pod.Status.Phase)))
+		f.setProgress(pod)
 		// handle any issues with pulling images that may fail
 		for _, c := range pod.Status.Conditions {
 			if c.Type == corev1.PodInitialized || c.Type == corev1.ContainersReady {
@@ -211,11 +470,94 @@ func (f *Follower) OnEvent(pod *corev1.Pod) error {
 
 }
 
+// replacementPod returns the pod replacing the failed one for the same BuildRun, the most recent
+// pod neither failed nor replaced already, or nil when there is none yet. When a single pod is
+// followed there is no replacement.
+func (f *Follower) replacementPod(ctx context.Context, failed *corev1.Pod) *corev1.Pod {
+	if f.pod != "" {
+		return nil
+	}
+	pods, err := f.clientset.CoreV1().Pods(failed.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", buildv1alpha1.LabelBuildRun, f.buildRun.Name),
+	})
+	if err != nil {
+		return nil
+	}
+	var replacement *corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.GetName() == failed.GetName() || f.replacedPods[pod.GetName()] ||
+			pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if replacement == nil || replacement.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			replacement = pod
+		}
+	}
+	return replacement
+}
+
+// reattach moves on following the pod replacing the failed one, the log streams are started again
+// for its containers once it's running, while the events of the failed pod are ignored from now on.
+func (f *Follower) reattach(failed *corev1.Pod, replacement *corev1.Pod) error {
+	reason := failed.Status.Reason
+	if reason == "" {
+		reason = string(corev1.PodFailed)
+	}
+	f.Log(fmt.Sprintf("Pod %q has failed (%s), BuildRun %q goes on with pod %q, reattaching the log streams\n",
+		failed.GetName(), reason, f.buildRun.Name, replacement.GetName()))
+	f.replacedPods[failed.GetName()] = true
+	f.enteredRunningState = false
+	f.tailLogsStarted = map[string]bool{}
+	// the replacement events may have been observed while waiting, it's evaluated right away
+	return f.OnEvent(replacement)
+}
+
 // OnTimeout reacts to either the context or request timeout causing the pod watcher to exit
 func (f *Follower) OnTimeout(msg string) {
 	f.Log(fmt.Sprintf("BuildRun %q log following has stopped because: %q\n", f.buildRun.Name, msg))
 }
 
+// OnSchedulerTimeout reacts to the pod not running within the scheduler timeout, printing the
+// reason the pod is pending and its warning events, i.e. FailedScheduling, to help diagnose.
+func (f *Follower) OnSchedulerTimeout(pod *corev1.Pod) {
+	f.stopProgress()
+	if pod == nil {
+		f.Log(fmt.Sprintf("BuildRun %q pod has not been created within the scheduler timeout\n", f.buildRun.Name))
+		return
+	}
+	f.Log(fmt.Sprintf("Pod %q has not started running within the scheduler timeout, %s\n", pod.GetName(), podProgress(pod)))
+
+	events, err := f.clientset.CoreV1().Events(pod.GetNamespace()).List(f.ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.name=%s", pod.GetName()),
+	})
+	if err != nil {
+		f.Log(fmt.Sprintf("error listing events for pod %q: %s\n", pod.GetName(), err.Error()))
+		return
+	}
+	for _, event := range events.Items {
+		if event.Type == corev1.EventTypeWarning {
+			f.Log(fmt.Sprintf("  %s: %s\n", event.Reason, event.Message))
+		}
+	}
+}
+
+// logWarningEvents logs the most recent warning events of the failed BuildRun and its pod, which
+// surface i.e. scheduling and image pull errors the BuildRun condition alone hides.
+func (f *Follower) logWarningEvents(br *buildv1alpha1.BuildRun) {
+	if br == nil || br.IsCanceled() {
+		return
+	}
+	events, err := util.BuildRunWarningEvents(f.ctx, f.clientset, br, util.WarningEventsLimit)
+	if err != nil {
+		f.Log(fmt.Sprintf("error listing events for BuildRun %q: %s\n", br.Name, err.Error()))
+		return
+	}
+	if len(events) > 0 {
+		f.Log(util.FormatWarningEvents(events))
+	}
+}
+
 // OnNoPodEventsYet reacts to the pod watcher telling us it has not received any pod events for our build run
 func (f *Follower) OnNoPodEventsYet(podList *corev1.PodList) {
 	f.Log(fmt.Sprintf("BuildRun %q log following has not observed any pod events yet.\n", f.buildRun.Name))
@@ -234,6 +576,7 @@ func (f *Follower) OnNoPodEventsYet(podList *corev1.PodList) {
 
 	c := br.Status.GetCondition(buildv1alpha1.Succeeded)
 	giveUp := false
+	failed := false
 	msg := ""
 	switch {
 	case c != nil && c.Status == corev1.ConditionTrue:
@@ -242,6 +585,7 @@ func (f *Follower) OnNoPodEventsYet(podList *corev1.PodList) {
 	case c != nil && c.Status == corev1.ConditionFalse:
 		giveUp = true
 		msg = fmt.Sprintf("BuildRun %q has been marked as failed.\n", br.Name)
+		failed = true
 	case br.IsCanceled():
 		giveUp = true
 		msg = fmt.Sprintf("BuildRun %q has been canceled.\n", br.Name)
@@ -253,18 +597,90 @@ func (f *Follower) OnNoPodEventsYet(podList *corev1.PodList) {
 	}
 	if giveUp {
 		f.Log(msg)
+		if failed {
+			f.logWarningEvents(br)
+		}
 		f.Log(fmt.Sprintf("exiting 'shp build run --follow' for BuildRun %q\n", br.Name))
 		f.Stop()
 	}
 }
 
+// Connect establishes the pod watch, and unless quiet, starts reporting the pod status
+// periodically until the logs start streaming.
 func (f *Follower) Connect(lo metav1.ListOptions) error {
-	return f.pw.Connect(lo)
+	if err := f.pw.Connect(lo); err != nil {
+		return err
+	}
+	if !f.quiet {
+		f.progressWg.Add(1)
+		go f.reportProgress()
+	}
+	if f.failFast {
+		go f.watchBuildRunFailure()
+	}
+	return nil
+}
+
+// watchBuildRunFailure polls the BuildRun until it fails, the follower then stops right away, the
+// log lines received so far are written and the remaining ones are discarded.
+func (f *Follower) watchBuildRunFailure() {
+	ticker := time.NewTicker(f.failPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-f.failFastDone:
+			return
+		case <-ticker.C:
+		}
+
+		brClient := f.buildClientset.ShipwrightV1alpha1().BuildRuns(f.buildRun.Namespace)
+		br, err := brClient.Get(f.ctx, f.buildRun.Name, metav1.GetOptions{})
+		if err != nil || br.IsCanceled() {
+			continue
+		}
+		c := br.Status.GetCondition(buildv1alpha1.Succeeded)
+		if c == nil || c.Status != corev1.ConditionFalse {
+			continue
+		}
+
+		f.stopProgress()
+		f.logTail.Stop()
+		msg := fmt.Sprintf("BuildRun %q has failed", br.Name)
+		if c.Reason != "" {
+			msg = fmt.Sprintf("%s because of %s", msg, c.Reason)
+		}
+		if message := strings.TrimSpace(c.Message); message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, message)
+		}
+		f.logLock.Lock()
+		f.failFastErr = fmt.Errorf("buildrun %q has failed: %s", br.Name, c.Reason)
+		f.logLock.Unlock()
+		f.Log(msg + ", stopping the log streams\n")
+		f.logWarningEvents(br)
+		f.pw.Stop()
+		return
+	}
+}
+
+// stopFailFast stops the BuildRun failure watch.
+func (f *Follower) stopFailFast() {
+	f.failFastOnce.Do(func() { close(f.failFastDone) })
 }
 
-// WaitForCompletion initiates the log following for the referenced BuildRun's Pod
+// WaitForCompletion initiates the log following for the referenced BuildRun's Pod. With fail fast,
+// the BuildRun failure is returned when it stopped the follower.
 func (f *Follower) WaitForCompletion() (*corev1.Pod, error) {
-	return f.pw.WaitForCompletion()
+	defer f.stopProgress()
+	defer f.stopFailFast()
+	pod, err := f.pw.WaitForCompletion()
+	if err == nil {
+		f.logLock.Lock()
+		err = f.failFastErr
+		f.logLock.Unlock()
+	}
+	return pod, err
 }
 
 // Start is a convenience method for capturing the use of both Connect and WaitForCompletion
@@ -276,6 +692,44 @@ func (f *Follower) Start(listOpts metav1.ListOptions) (*corev1.Pod, error) {
 	return f.WaitForCompletion()
 }
 
+// BuildRun waits for the BuildRun to reach a terminal state, or to be deleted, and returns it. A
+// BuildRun no longer found is returned as nil.
+func (f *Follower) BuildRun() (*buildv1alpha1.BuildRun, error) {
+	var br *buildv1alpha1.BuildRun
+	err := wait.PollUntilContextTimeout(f.ctx, f.failPollInterval, f.failPollTimeout, true, func(ctx context.Context) (done bool, err error) {
+		brClient := f.buildClientset.ShipwrightV1alpha1().BuildRuns(f.buildRun.Namespace)
+		br, err = brClient.Get(ctx, f.buildRun.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				br = nil
+				return true, nil
+			}
+			return false, nil
+		}
+		return br.IsDone() || br.DeletionTimestamp != nil, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine the final status of BuildRun %q: %w", f.buildRun.Name, err)
+	}
+	return br, nil
+}
+
+// BuildRunError waits for the BuildRun to reach a terminal state, and returns an error when it has
+// failed. Canceled or deleted BuildRuns are not considered a failure.
+func (f *Follower) BuildRunError() error {
+	br, err := f.BuildRun()
+	switch {
+	case err != nil:
+		return err
+	case br == nil, br.DeletionTimestamp != nil, br.IsCanceled():
+		return nil
+	}
+	if c := br.Status.GetCondition(buildv1alpha1.Succeeded); c != nil && c.Status == corev1.ConditionFalse {
+		return fmt.Errorf("buildrun %q has failed: %s", br.Name, c.Reason)
+	}
+	return nil
+}
+
 func buildErrorMessage(br *buildv1alpha1.BuildRun, pod *corev1.Pod) string {
 	failureDetails := br.Status.FailureDetails
 	if failureDetails == nil {
@@ -0,0 +1,288 @@
+package follower
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/reactor"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPodProgress(t *testing.T) {
+	waiting := func(reason, message string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		status   corev1.PodStatus
+		expected string
+	}{{
+		name:     "no container status",
+		status:   corev1.PodStatus{Phase: corev1.PodPending},
+		expected: "Pending",
+	}, {
+		name: "unschedulable",
+		status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  "Unschedulable",
+				Message: "0/1 nodes are available",
+			}},
+		},
+		expected: "Pending: Unschedulable: 0/1 nodes are available",
+	}, {
+		name: "container creating",
+		status: corev1.PodStatus{
+			Phase:                 corev1.PodPending,
+			InitContainerStatuses: []corev1.ContainerStatus{waiting("PodInitializing", "")},
+			ContainerStatuses:     []corev1.ContainerStatus{waiting("ContainerCreating", "")},
+		},
+		expected: "Pending: ContainerCreating",
+	}, {
+		name: "image pull back-off",
+		status: corev1.PodStatus{
+			Phase:             corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{waiting("ImagePullBackOff", "Back-off pulling image")},
+		},
+		expected: "ImagePullBackOff: Back-off pulling image",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(podProgress(&corev1.Pod{Status: test.status})).To(gomega.Equal(test.expected))
+		})
+	}
+}
+
+func TestFollowerProgress(t *testing.T) {
+	newFollower := func(quiet bool) (*Follower, *bytes.Buffer) {
+		g := gomega.NewWithT(t)
+		clientset := fake.NewSimpleClientset()
+		pw, err := reactor.NewPodWatcher(context.Background(), time.Minute, clientset, metav1.NamespaceDefault)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		ioStreams, _, _, errOut := genericclioptions.NewTestIOStreams()
+		f := NewFollower(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "br"}, &ioStreams, pw, clientset, shpfake.NewSimpleClientset())
+		f.SetQuiet(quiet)
+		f.SetProgressInterval(10 * time.Millisecond)
+		g.Expect(f.Connect(metav1.ListOptions{})).To(gomega.Succeed())
+		return f, errOut
+	}
+
+	t.Run("reports the pod status until stopped", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		f, errOut := newFollower(false)
+
+		time.Sleep(50 * time.Millisecond)
+		g.Expect(f.OnEvent(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "br-pod"},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{{State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"},
+				}}},
+			},
+		})).To(gomega.Succeed())
+		time.Sleep(50 * time.Millisecond)
+		f.stopProgress()
+
+		progress := errOut.String()
+		g.Expect(progress).To(gomega.ContainSubstring("Waiting for BuildRun \"br\" pod to be created...\n"))
+		g.Expect(progress).To(gomega.ContainSubstring("Pod \"br-pod\" Pending: ContainerCreating...\n"))
+
+		// no more status lines after stopping
+		time.Sleep(50 * time.Millisecond)
+		g.Expect(errOut.String()).To(gomega.Equal(progress))
+	})
+
+	t.Run("quiet suppresses the pod status", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		f, errOut := newFollower(true)
+
+		time.Sleep(50 * time.Millisecond)
+		f.stopProgress()
+		g.Expect(errOut.String()).To(gomega.BeEmpty())
+	})
+}
+
+func TestFollowerSchedulerTimeout(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br-pod"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:   corev1.PodScheduled,
+				Status: corev1.ConditionFalse,
+				Reason: "Unschedulable",
+			}},
+		},
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br-pod.1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "br-pod"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "FailedScheduling",
+		Message:        "0/3 nodes are available: 3 Insufficient cpu.",
+	}
+	clientset := fake.NewSimpleClientset(event)
+	pw, err := reactor.NewPodWatcher(context.Background(), time.Minute, clientset, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	f := NewFollower(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "br"}, &ioStreams, pw, clientset, shpfake.NewSimpleClientset())
+	f.SetQuiet(true)
+	f.SetSchedulerTimeout(50 * time.Millisecond)
+	g.Expect(f.Connect(metav1.ListOptions{})).To(gomega.Succeed())
+
+	_, err = clientset.CoreV1().Pods(metav1.NamespaceDefault).Create(context.Background(), pod, metav1.CreateOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	_, err = f.WaitForCompletion()
+	g.Expect(err).To(gomega.MatchError("pod has not started running within the scheduler timeout of 50ms"))
+	g.Expect(out.String()).To(gomega.ContainSubstring(
+		"Pod \"br-pod\" has not started running within the scheduler timeout, Pending: Unschedulable\n"))
+	g.Expect(out.String()).To(gomega.ContainSubstring("  FailedScheduling: 0/3 nodes are available: 3 Insufficient cpu.\n"))
+}
+
+func TestFollowerLogFormat(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clientset := fake.NewSimpleClientset()
+	pw, err := reactor.NewPodWatcher(context.Background(), time.Minute, clientset, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	f := NewFollower(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "br"}, &ioStreams, pw, clientset, shpfake.NewSimpleClientset())
+
+	f.Log("Pod \"br-pod\" is in state \"Pending\"...\n")
+	g.Expect(out.String()).To(gomega.Equal("Pod \"br-pod\" is in state \"Pending\"...\n"))
+	g.Expect(errOut.String()).To(gomega.BeEmpty())
+
+	// the follower messages move to stderr as JSON lines, leaving stdout to the build logs
+	out.Reset()
+	f.SetLogFormat("json")
+	f.Log("Pod \"br-pod\" is in state \"Pending\"...\n")
+	g.Expect(out.String()).To(gomega.BeEmpty())
+	g.Expect(errOut.String()).To(gomega.MatchRegexp(
+		`^\{"level":"info","msg":"Pod \\"br-pod\\" is in state \\"Pending\\"...","time":"[^"]+"\}\n$`))
+
+	// with raw logs the text messages are written to stderr as well
+	errOut.Reset()
+	f.SetLogFormat("text")
+	f.SetRaw(true)
+	f.Log("Pod \"br-pod\" is in state \"Pending\"...\n")
+	g.Expect(out.String()).To(gomega.BeEmpty())
+	g.Expect(errOut.String()).To(gomega.Equal("Pod \"br-pod\" is in state \"Pending\"...\n"))
+}
+
+func TestFollowerFailedBuildRunWarningEvents(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br.1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "BuildRun", Name: "br"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "FailedCreate",
+		Message:        `serviceaccounts "pipeline" is forbidden`,
+	}
+	br := &buildv1alpha1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br"},
+		Status: buildv1alpha1.BuildRunStatus{Conditions: buildv1alpha1.Conditions{{
+			Type:   buildv1alpha1.Succeeded,
+			Status: corev1.ConditionFalse,
+			Reason: "Failed",
+		}}},
+	}
+	clientset := fake.NewSimpleClientset(event)
+	pw, err := reactor.NewPodWatcher(context.Background(), time.Minute, clientset, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	f := NewFollower(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "br"}, &ioStreams, pw, clientset, shpfake.NewSimpleClientset(br))
+
+	// the BuildRun failed before its pod was ever created
+	f.OnNoPodEventsYet(nil)
+	g.Expect(out.String()).To(gomega.ContainSubstring("BuildRun \"br\" has been marked as failed.\n" +
+		"Recent warning events:\n  buildrun/br FailedCreate: serviceaccounts \"pipeline\" is forbidden\n"))
+}
+
+func TestFollowerReattachesToReplacementPod(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	labels := map[string]string{buildv1alpha1.LabelBuildRun: "br"}
+	failed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br-pod-1", Labels: labels},
+		Status:     corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"},
+	}
+	replacement := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br-pod-2", Labels: labels},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	br := &buildv1alpha1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br"},
+		Status: buildv1alpha1.BuildRunStatus{Conditions: buildv1alpha1.Conditions{{
+			Type:   buildv1alpha1.Succeeded,
+			Status: corev1.ConditionUnknown,
+			Reason: "Running",
+		}}},
+	}
+	clientset := fake.NewSimpleClientset(failed, replacement)
+	pw, err := reactor.NewPodWatcher(context.Background(), time.Minute, clientset, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	f := NewFollower(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "br"}, &ioStreams, pw, clientset, shpfake.NewSimpleClientset(br))
+	f.SetFailPollInterval(time.Millisecond)
+	f.SetFailPollTimeout(time.Second)
+
+	// the BuildRun goes on with the replacement pod, the follower is not stopped
+	g.Expect(f.OnEvent(failed)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.ContainSubstring(
+		"Pod \"br-pod-1\" has failed (Evicted), BuildRun \"br\" goes on with pod \"br-pod-2\", reattaching the log streams"))
+	g.Expect(out.String()).To(gomega.ContainSubstring("Pod \"br-pod-2\" is in state \"Pending\"..."))
+
+	// the events of the replaced pod are ignored from now on
+	out.Reset()
+	g.Expect(f.OnEvent(failed)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.BeEmpty())
+}
+
+func TestFollowerDeletedBuildRun(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br-pod"},
+		Status:     corev1.PodStatus{Phase: corev1.PodFailed},
+	}
+	clientset := fake.NewSimpleClientset(pod)
+	pw, err := reactor.NewPodWatcher(context.Background(), time.Minute, clientset, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	f := NewFollower(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "br"}, &ioStreams, pw, clientset, shpfake.NewSimpleClientset())
+	f.SetFailPollInterval(time.Millisecond)
+	f.SetFailPollTimeout(time.Second)
+
+	// the BuildRun is no longer found once its pod has failed
+	g.Expect(f.OnEvent(pod)).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.ContainSubstring("BuildRun \"br\" has been deleted.\n"))
+}
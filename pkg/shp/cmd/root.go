@@ -1,37 +1,143 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/build"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/buildrun"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/buildstrategy"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/check"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/clusterbuildstrategy"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/completion"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/version"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
 	"github.com/shipwright-io/cli/pkg/shp/suggestion"
+	"github.com/shipwright-io/cli/pkg/shp/util"
 )
 
-var rootCmd = &cobra.Command{
-	Use:           "shp [command] [resource] [flags]",
-	Short:         "Command-line client for Shipwright's Build API.",
-	SilenceUsage:  true,
-	SilenceErrors: true,
+const rootLongDesc = `
+Command-line client for Shipwright's Build API.
+
+The following environment variables are used as defaults for the flags not informed on the
+command-line, the flag takes precedence over the environment, which takes precedence over the
+kubeconfig and the flag's own default:
+
+	SHP_NAMESPACE   --namespace
+	SHP_TIMEOUT     --timeout, on the commands supporting it
+	SHP_OUTPUT      --output, on the commands printing objects, i.e. list, get and describe
+`
+
+// kubectlPluginPrefix executable name prefix of kubectl plugins, i.e. "kubectl-shp".
+const kubectlPluginPrefix = "kubectl-"
+
+// envDefault environment variable providing the default value for a flag, when neither the flag nor
+// its aliases are informed.
+type envDefault struct {
+	env       string
+	flags     []string // flag name followed by its aliases
+	annotated bool     // only the flags annotated with flags.EnvDefaultAnnotation
+}
+
+// envDefaults flags which take their default from the environment.
+var envDefaults = []envDefault{
+	{env: "SHP_NAMESPACE", flags: []string{"namespace"}},
+	{env: "SHP_TIMEOUT", flags: []string{flags.TimeoutFlag, flags.BuildTimeoutFlag}},
+	{env: "SHP_OUTPUT", flags: []string{flags.OutputFlag}, annotated: true},
 }
 
 // NewCmdSHP create a new SHP root command, linking together all sub-commands organized by groups.
 func NewCmdSHP(ioStreams *genericclioptions.IOStreams) *cobra.Command {
 	p := params.NewParams()
+	rootCmd := &cobra.Command{
+		Use:           "shp [command] [resource] [flags]",
+		Short:         "Command-line client for Shipwright's Build API.",
+		Long:          rootLongDesc,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := applyEnvDefaults(cmd.Flags()); err != nil {
+				return err
+			}
+			if err := p.ValidateNamespace(); err != nil {
+				return err
+			}
+			return util.ValidateLogFormat(p.LogFormat())
+		},
+		// the completion subcommand is registered explicitly, replacing cobra's default
+		CompletionOptions: cobra.CompletionOptions{DisableDefaultCmd: true},
+	}
+
 	p.AddFlags(rootCmd.PersistentFlags())
-	rootCmd.AddCommand(version.Command())
+	rootCmd.AddCommand(version.Command(p, ioStreams))
 	rootCmd.AddCommand(build.Command(p, ioStreams))
 	rootCmd.AddCommand(buildrun.Command(p, ioStreams))
+	rootCmd.AddCommand(buildstrategy.Command(p, ioStreams))
+	rootCmd.AddCommand(clusterbuildstrategy.Command(p, ioStreams))
+	rootCmd.AddCommand(check.Command(p, ioStreams))
+	rootCmd.AddCommand(completion.Command(p, ioStreams))
 
 	visitCommands(rootCmd, reconfigureCommandWithSubcommand)
 
 	return rootCmd
 }
 
+// applyEnvDefaults sets the flags which are not informed on the command-line, nor via aliases, using
+// the respective environment variable, when present.
+func applyEnvDefaults(fs *pflag.FlagSet) error {
+	errs := []error{}
+	for _, d := range envDefaults {
+		value, ok := os.LookupEnv(d.env)
+		if !ok || value == "" {
+			continue
+		}
+		flag := fs.Lookup(d.flags[0])
+		if flag == nil {
+			continue
+		}
+		if _, ok := flag.Annotations[flags.EnvDefaultAnnotation]; d.annotated && !ok {
+			continue
+		}
+		informed := false
+		for _, name := range d.flags {
+			if f := fs.Lookup(name); f != nil && f.Changed {
+				informed = true
+			}
+		}
+		if informed {
+			continue
+		}
+		if err := flag.Value.Set(value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %q on %s for --%s: %w", value, d.env, flag.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// ConfigureDisplayName renders the root command as "kubectl shp" in usage and help messages when the
+// executable is invoked as the "kubectl-shp" plugin, following kubectl's plugin naming, where
+// underscores in the executable name stand for dashes.
+func ConfigureDisplayName(rootCmd *cobra.Command, executable string) {
+	name := strings.TrimSuffix(filepath.Base(executable), ".exe")
+	if !strings.HasPrefix(name, kubectlPluginPrefix) {
+		return
+	}
+	plugin := strings.ReplaceAll(strings.TrimPrefix(name, kubectlPluginPrefix), "_", "-")
+	if rootCmd.Annotations == nil {
+		rootCmd.Annotations = map[string]string{}
+	}
+	rootCmd.Annotations[cobra.CommandDisplayNameAnnotation] = fmt.Sprintf("kubectl %s", plugin)
+}
+
 func reconfigureCommandWithSubcommand(cmd *cobra.Command) {
 	if len(cmd.Commands()) == 0 {
 		return
@@ -3,10 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/onsi/gomega"
 	"github.com/shipwright-io/cli/test/stub"
+	"github.com/spf13/cobra"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
@@ -27,3 +29,139 @@ func TestCMD_NewCmdSHP(t *testing.T) {
 
 	g.Expect(err.Error()).To(gomega.Equal(expected))
 }
+
+func TestCMD_Aliases(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	genericOpts := &genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+	cmd := NewCmdSHP(genericOpts)
+
+	for alias, expected := range map[string]string{
+		"br list": "shp buildrun list",
+		"b run":   "shp build run",
+		"bd list": "shp build list",
+	} {
+		found, _, err := cmd.Find(strings.Fields(alias))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(found.CommandPath()).To(gomega.Equal(expected))
+	}
+
+	// names and aliases must be unique among sibling commands
+	visitCommands(cmd, func(c *cobra.Command) {
+		seen := map[string]string{}
+		for _, child := range c.Commands() {
+			for _, name := range append([]string{child.Name()}, child.Aliases...) {
+				other, exists := seen[name]
+				g.Expect(exists).To(gomega.BeFalse(), "%q of %q collides with %q", name, child.CommandPath(), other)
+				seen[name] = child.CommandPath()
+			}
+		}
+	})
+}
+
+func TestCMD_EnvDefaults(t *testing.T) {
+	t.Setenv("SHP_NAMESPACE", "from-env")
+	t.Setenv("SHP_TIMEOUT", "10m")
+	t.Setenv("SHP_OUTPUT", "json")
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected map[string]string
+	}{
+		{
+			name:     "flags taken from the environment",
+			args:     []string{"buildrun", "create", "br"},
+			expected: map[string]string{"namespace": "from-env", "timeout": "10m0s"},
+		},
+		{
+			name:     "flags take precedence over the environment",
+			args:     []string{"buildrun", "create", "br", "--namespace=from-flag", "--timeout=5m"},
+			expected: map[string]string{"namespace": "from-flag", "timeout": "5m0s"},
+		},
+		{
+			name:     "alias takes precedence over the environment",
+			args:     []string{"build", "create", "b", "--build-timeout=1m"},
+			expected: map[string]string{"timeout": "1m0s", "build-timeout": "1m0s"},
+		},
+		{
+			name:     "output taken from the environment",
+			args:     []string{"build", "list"},
+			expected: map[string]string{"output": "json"},
+		},
+		{
+			name:     "output not taken from the environment by the other flags named output",
+			args:     []string{"version"},
+			expected: map[string]string{"output": ""},
+		},
+		{
+			name:     "output not taken from the environment by build create",
+			args:     []string{"build", "create", "b"},
+			expected: map[string]string{"output": ""},
+		},
+		{
+			name:     "output not taken from the environment by buildrun logs",
+			args:     []string{"buildrun", "logs", "br"},
+			expected: map[string]string{"output": ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			genericOpts := &genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+			cmd, args, err := NewCmdSHP(genericOpts).Find(test.args)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(cmd.ParseFlags(args)).To(gomega.Succeed())
+			g.Expect(applyEnvDefaults(cmd.Flags())).To(gomega.Succeed())
+
+			for name, value := range test.expected {
+				g.Expect(cmd.Flags().Lookup(name).Value.String()).To(gomega.Equal(value), "flag --%s", name)
+			}
+		})
+	}
+
+	g := gomega.NewWithT(t)
+	t.Setenv("SHP_TIMEOUT", "soon")
+	cmd, args, err := NewCmdSHP(&genericclioptions.IOStreams{}).Find([]string{"buildrun", "create", "br"})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(cmd.ParseFlags(args)).To(gomega.Succeed())
+	g.Expect(applyEnvDefaults(cmd.Flags())).To(gomega.MatchError(gomega.ContainSubstring("SHP_TIMEOUT")))
+}
+
+func TestCMD_PluginDisplayName(t *testing.T) {
+	tests := []struct {
+		executable string
+		expected   string
+	}{
+		{executable: "/usr/local/bin/shp", expected: "shp build list [flags]"},
+		{executable: "/usr/local/bin/kubectl-shp", expected: "kubectl shp build list [flags]"},
+		{executable: "kubectl-shp.exe", expected: "kubectl shp build list [flags]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.executable, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			cmd := NewCmdSHP(&genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+			ConfigureDisplayName(cmd, test.executable)
+
+			out, err := stub.ExecuteCommand(cmd, "build", "list", "--help")
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(out).To(gomega.ContainSubstring("Usage:\n  " + test.expected))
+		})
+	}
+}
+
+func TestCMD_LogFormat(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	cmd := NewCmdSHP(&genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	_, err := stub.ExecuteCommand(cmd, "version", "--short", "--log-format", "yaml")
+	g.Expect(err).To(gomega.MatchError(`invalid --log-format "yaml", must be either "text" or "json"`))
+
+	cmd = NewCmdSHP(&genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	_, err = stub.ExecuteCommand(cmd, "version", "--short", "--log-format", "json")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+}
@@ -0,0 +1,25 @@
+package runner
+
+import (
+	"fmt"
+)
+
+// ExitError allows sub-commands to control the process exit code. When the wrapped error is nil
+// the command already reported the outcome, and nothing else is printed.
+type ExitError struct {
+	Code int   // process exit code
+	Err  error // optional error to print
+}
+
+// Error returns the wrapped error message, or a generic message based on the exit code.
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
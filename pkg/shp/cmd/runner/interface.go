@@ -19,3 +19,10 @@ type SubCommand interface {
 	// Run execute the primary sub-command logic.
 	Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error
 }
+
+// MutatingCommand is implemented by the sub-commands creating or changing resources in the namespace,
+// which is verified to exist before running them.
+type MutatingCommand interface {
+	// Mutating tells whether the sub-command, as informed, changes resources in the namespace.
+	Mutating() bool
+}
@@ -32,6 +32,11 @@ func (r *Runner) RunE(_ *cobra.Command, args []string) error {
 	if err := r.subCmd.Validate(); err != nil {
 		return err
 	}
+	if m, ok := r.subCmd.(MutatingCommand); ok && m.Mutating() {
+		if err := r.p.VerifyNamespace(r.subCmd.Cmd().Context()); err != nil {
+			return err
+		}
+	}
 	return r.subCmd.Run(r.p, r.ioStreams)
 }
 
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// ExitCodeInterrupted exit code employed when the command is interrupted, following the shell
+// convention of 128 plus the signal number (SIGINT).
+const ExitCodeInterrupted = 130
+
+// exit terminates the process, as a variable to allow testing.
+var exit = os.Exit
+
+// NotifyContext returns a copy of the parent context which is canceled when the process receives
+// SIGINT or SIGTERM, giving the commands the chance to stop watches and log streams cleanly. A
+// second signal terminates the process right away. The returned function releases the resources.
+func NotifyContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-done:
+			return
+		}
+		select {
+		case <-signals:
+			exit(ExitCodeInterrupted)
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+			cancel()
+		})
+	}
+}
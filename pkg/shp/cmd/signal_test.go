@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestNotifyContext(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	exitCodes := make(chan int, 1)
+	exit = func(code int) { exitCodes <- code }
+	defer func() { exit = os.Exit }()
+
+	ctx, stop := NotifyContext(context.Background())
+	defer stop()
+	g.Expect(ctx.Err()).To(gomega.BeNil())
+
+	// the first signal cancels the context
+	g.Expect(syscall.Kill(os.Getpid(), syscall.SIGINT)).To(gomega.Succeed())
+	g.Eventually(ctx.Done(), 5*time.Second).Should(gomega.BeClosed())
+	g.Consistently(exitCodes, 100*time.Millisecond).ShouldNot(gomega.Receive())
+
+	// the second signal forces the exit
+	g.Expect(syscall.Kill(os.Getpid(), syscall.SIGTERM)).To(gomega.Succeed())
+	g.Eventually(exitCodes, 5*time.Second).Should(gomega.Receive(gomega.Equal(ExitCodeInterrupted)))
+}
@@ -1,30 +1,159 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// version and commit are injected during the build via ldflags.
+var (
+	version string
+	commit  string
 )
 
-var version string
+const (
+	// unknown placeholder for the information which could not be discovered.
+	unknown = "unknown"
+
+	// controllerNamespace namespace where the Shipwright Build controller is deployed.
+	controllerNamespace = "shipwright-build"
+	// controllerName name of the Shipwright Build controller deployment.
+	controllerName = "shipwright-build-controller"
+	// versionLabel well-known label carrying the version of the deployed application.
+	versionLabel = "app.kubernetes.io/version"
+)
+
+// Info holds the client and server versions.
+type Info struct {
+	ClientVersion string `json:"clientVersion"`
+	ClientCommit  string `json:"clientCommit"`
+	ServerVersion string `json:"serverVersion"`
+}
+
+// VersionCommand represents the "version" subcommand.
+type VersionCommand struct {
+	cmd *cobra.Command
+
+	output string
+	short  bool // print only the client version
+}
 
 // Command returns Version subcommand of Shipwright CLI
 // for retrieving the shp version
-func Command() *cobra.Command {
-	command := &cobra.Command{
-		Use:     "version",
-		Aliases: []string{"v"},
-		Short:   "version",
-		Annotations: map[string]string{
-			"commandType": "main",
-		},
-		Run: func(_ *cobra.Command, _ []string) {
-			if version == "" {
-				version = "development"
-			}
+func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Command {
+	return runner.NewRunner(p, ioStreams, versionCmd()).Cmd()
+}
 
-			fmt.Printf("version: %s\n", version)
+func versionCmd() runner.SubCommand {
+	versionCommand := &VersionCommand{
+		cmd: &cobra.Command{
+			Use:     "version",
+			Aliases: []string{"v"},
+			Short:   "Print the client and the Shipwright Build controller versions",
+			Args:    cobra.NoArgs,
+			Annotations: map[string]string{
+				"commandType": "main",
+			},
 		},
 	}
-	return command
+
+	versionCommand.cmd.Flags().StringVarP(&versionCommand.output, "output", "o", "", "Output format, either empty for text or json")
+	versionCommand.cmd.Flags().BoolVar(&versionCommand.short, "short", false, "Print only the client version, without the server version")
+
+	return versionCommand
+}
+
+// Cmd returns cobra command object
+func (c *VersionCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *VersionCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, _ []string) error {
+	return nil
+}
+
+// Validate validates data input by user
+func (c *VersionCommand) Validate() error {
+	// the output informed via environment is not considered a conflict, --short takes precedence
+	if c.short && c.cmd.Flags().Changed("output") {
+		return fmt.Errorf("--short and --output are mutually exclusive")
+	}
+	if c.output != "" && c.output != "json" {
+		return fmt.Errorf("unsupported output format %q, only json is supported", c.output)
+	}
+	return nil
+}
+
+// Run prints the client version, and the server version when it can be discovered.
+func (c *VersionCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	if c.short {
+		fmt.Fprintln(ioStreams.Out, clientVersion())
+		return nil
+	}
+
+	info := Info{
+		ClientVersion: clientVersion(),
+		ClientCommit:  commit,
+		ServerVersion: c.serverVersion(params),
+	}
+	if info.ClientCommit == "" {
+		info.ClientCommit = unknown
+	}
+
+	if c.output == "json" {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(ioStreams.Out, string(data))
+		return nil
+	}
+
+	fmt.Fprintf(ioStreams.Out, "Client Version: %s\n", info.ClientVersion)
+	fmt.Fprintf(ioStreams.Out, "Client Commit: %s\n", info.ClientCommit)
+	fmt.Fprintf(ioStreams.Out, "Server Version: %s\n", info.ServerVersion)
+	return nil
+}
+
+// clientVersion returns the version injected during the build, or development otherwise.
+func clientVersion() string {
+	if version == "" {
+		return "development"
+	}
+	return version
+}
+
+// serverVersion inspects the Shipwright Build controller deployment to find out the version
+// installed in the cluster, using the version label or otherwise the controller image tag. Any
+// error on the way means the version is unknown.
+func (c *VersionCommand) serverVersion(params *params.Params) string {
+	clientset, err := params.ClientSet()
+	if err != nil {
+		return unknown
+	}
+	deployment, err := clientset.AppsV1().Deployments(controllerNamespace).Get(c.cmd.Context(), controllerName, metav1.GetOptions{})
+	if err != nil {
+		return unknown
+	}
+
+	if v, ok := deployment.GetLabels()[versionLabel]; ok && v != "" {
+		return v
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		image := strings.SplitN(container.Image, "@", 2)[0]
+		if i := strings.LastIndex(image, ":"); i > 0 && !strings.Contains(image[i:], "/") {
+			return image[i+1:]
+		}
+	}
+	return unknown
 }
@@ -0,0 +1,91 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	o "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+func TestVersionCommand(t *testing.T) {
+	g := o.NewWithT(t)
+
+	deployment := func(labels map[string]string, image string) *appsv1.Deployment {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: controllerNamespace,
+				Name:      controllerName,
+				Labels:    labels,
+			},
+		}
+		d.Spec.Template.Spec.Containers = []corev1.Container{{Name: "controller", Image: image}}
+		return d
+	}
+
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		expected string
+	}{{
+		name:     "controller not found",
+		expected: unknown,
+	}, {
+		name:     "version label",
+		objects:  []runtime.Object{deployment(map[string]string{versionLabel: "v0.13.0"}, "")},
+		expected: "v0.13.0",
+	}, {
+		name:     "image tag",
+		objects:  []runtime.Object{deployment(nil, "registry:5000/shipwright-io/build/controller:v0.12.0")},
+		expected: "v0.12.0",
+	}, {
+		name:     "image tag and digest",
+		objects:  []runtime.Object{deployment(nil, "ghcr.io/shipwright-io/build/controller:v0.11.0@sha256:abc")},
+		expected: "v0.11.0",
+	}, {
+		name:     "image without tag",
+		objects:  []runtime.Object{deployment(nil, "registry:5000/shipwright-io/build/controller")},
+		expected: unknown,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(_ *testing.T) {
+			cmd := versionCmd().(*VersionCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Cmd().Flags().Set("output", "json")).To(o.Succeed())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+
+			p := params.NewParamsForTest(fake.NewSimpleClientset(test.objects...), nil, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+			var info Info
+			g.Expect(json.Unmarshal(out.Bytes(), &info)).To(o.Succeed())
+			g.Expect(info.ClientVersion).To(o.Equal("development"))
+			g.Expect(info.ServerVersion).To(o.Equal(test.expected))
+		})
+	}
+
+	cmd := versionCmd().(*VersionCommand)
+	g.Expect(cmd.Cmd().Flags().Set("output", "yaml")).To(o.Succeed())
+	g.Expect(cmd.Validate()).ToNot(o.Succeed())
+
+	cmd = versionCmd().(*VersionCommand)
+	g.Expect(cmd.Cmd().Flags().Set("short", "true")).To(o.Succeed())
+	g.Expect(cmd.Validate()).To(o.Succeed())
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	g.Expect(cmd.Run(nil, &ioStreams)).To(o.Succeed())
+	g.Expect(out.String()).To(o.Equal("development\n"))
+
+	g.Expect(cmd.Cmd().Flags().Set("output", "json")).To(o.Succeed())
+	g.Expect(cmd.Validate()).To(o.MatchError("--short and --output are mutually exclusive"))
+}
@@ -0,0 +1,32 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// AnnotationValue implements pflag.Value interface, in order to store Kubernetes annotations as
+// key-value pairs. Only the key syntax is validated, the value may contain arbitrary text.
+type AnnotationValue struct {
+	MapValue
+}
+
+// Set receives a key-value entry separated by equal sign ("="), validating the annotation key.
+func (a *AnnotationValue) Set(value string) error {
+	k, v, err := splitKeyValue(value)
+	if err != nil {
+		return err
+	}
+	if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+		return fmt.Errorf("invalid annotation key %q: %s", k, strings.Join(errs, "; "))
+	}
+	a.kvMap[k] = v
+	return nil
+}
+
+// NewAnnotationValue instantiate an AnnotationValue sharing the map.
+func NewAnnotationValue(m map[string]string) *AnnotationValue {
+	return &AnnotationValue{MapValue: MapValue{kvMap: m}}
+}
@@ -0,0 +1,31 @@
+package flags
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestNewAnnotationValue(t *testing.T) {
+	g := o.NewWithT(t)
+
+	annotations := map[string]string{}
+	a := NewAnnotationValue(annotations)
+
+	// expect error when key-value is not split by equal sign
+	g.Expect(a.Set("a")).NotTo(o.Succeed())
+
+	// values are taken as informed, including spaces and special characters
+	url := "https://ci.example.com/job/my-app/42?view=console#end"
+	g.Expect(a.Set("example.com/build-url=" + url)).To(o.Succeed())
+	g.Expect(a.Set("description=built from main, by CI")).To(o.Succeed())
+	g.Expect(annotations).To(o.Equal(map[string]string{
+		"example.com/build-url": url,
+		"description":           "built from main, by CI",
+	}))
+
+	// invalid keys are rejected
+	g.Expect(a.Set("-a=b")).NotTo(o.Succeed())
+	g.Expect(a.Set("a b=c")).NotTo(o.Succeed())
+	g.Expect(annotations).To(o.HaveLen(2))
+}
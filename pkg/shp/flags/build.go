@@ -53,9 +53,10 @@ func BuildSpecFromFlags(flags *pflag.FlagSet) *buildv1alpha1.BuildSpec {
 	dockerfileFlags(flags, spec.Dockerfile)
 	imageFlags(flags, "builder", spec.Builder)
 	imageFlags(flags, "output", &spec.Output)
-	timeoutFlags(flags, spec.Timeout)
+	timeoutFlags(flags, spec.Timeout, "build process timeout, a BuildRun timeout takes precedence when informed")
 	envFlags(flags, &spec.Env)
 	paramValueFlag(flags, &spec.ParamValues)
+	dockerfileParamFlags(flags, &spec.ParamValues)
 	imageLabelsFlags(flags, spec.Output.Labels)
 	imageAnnotationsFlags(flags, spec.Output.Annotations)
 	buildRetentionFlags(flags, spec.Retention)
@@ -0,0 +1,117 @@
+package flags
+
+import (
+	"fmt"
+	"regexp"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+const (
+	// BuildArgsParam name of the Dockerfile strategies parameter receiving the build arguments.
+	BuildArgsParam = "build-args"
+	// TargetParam name of the Dockerfile strategies parameter receiving the target stage.
+	TargetParam = "target"
+)
+
+// buildArgNameRegexp build argument names accepted, the same as environment variable names.
+var buildArgNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// BuildArgArrayValue implements pflag.Value interface, in order to store the "docker build" style
+// build arguments as items of the build-args strategy parameter.
+type BuildArgArrayValue struct {
+	params *[]buildv1alpha1.ParamValue // pointer to the slice of ParamValue
+}
+
+// String prints out the build arguments informed so far.
+func (b *BuildArgArrayValue) String() string {
+	slice := []string{}
+	if param := findParamValue(*b.params, BuildArgsParam); param != nil {
+		for _, v := range param.Values {
+			slice = append(slice, *v.Value)
+		}
+	}
+	csv, _ := writeAsCSV(slice)
+	return fmt.Sprintf("[%s]", csv)
+}
+
+// Set receives a build argument in "KEY=VALUE" format, appended to the build-args parameter.
+func (b *BuildArgArrayValue) Set(value string) error {
+	k, _, err := splitKeyValue(value)
+	if err != nil {
+		return err
+	}
+	if !buildArgNameRegexp.MatchString(k) {
+		return fmt.Errorf("invalid build argument name '%s', must consist of letters, digits and underscores", k)
+	}
+
+	param := findParamValue(*b.params, BuildArgsParam)
+	if param == nil {
+		*b.params = append(*b.params, buildv1alpha1.ParamValue{Name: BuildArgsParam})
+		param = &(*b.params)[len(*b.params)-1]
+	}
+	if param.SingleValue != nil {
+		return fmt.Errorf("parameter '%s' is already set with --%s", BuildArgsParam, ParamValueFlag)
+	}
+	param.Values = append(param.Values, buildv1alpha1.SingleValue{Value: &value})
+	return nil
+}
+
+// Type analogous to the pflag "stringArray" type, each flag entry is a single build argument.
+func (b *BuildArgArrayValue) Type() string {
+	return "stringArray"
+}
+
+// NewBuildArgArrayValue instantiate a BuildArgArrayValue sharing the ParamValue slice pointer.
+func NewBuildArgArrayValue(params *[]buildv1alpha1.ParamValue) *BuildArgArrayValue {
+	return &BuildArgArrayValue{params: params}
+}
+
+// TargetValue implements pflag.Value interface, in order to store the Dockerfile target stage as
+// the target strategy parameter.
+type TargetValue struct {
+	params *[]buildv1alpha1.ParamValue // pointer to the slice of ParamValue
+}
+
+// String shows the target stage informed, if any.
+func (t *TargetValue) String() string {
+	if param := findParamValue(*t.params, TargetParam); param != nil && param.SingleValue != nil {
+		return *param.Value
+	}
+	return ""
+}
+
+// Set stores the target stage as the target parameter, which must not be set already.
+func (t *TargetValue) Set(value string) error {
+	if value == "" {
+		return fmt.Errorf("the target stage must not be empty")
+	}
+	if findParamValue(*t.params, TargetParam) != nil {
+		return fmt.Errorf("parameter '%s' is already set", TargetParam)
+	}
+	*t.params = append(*t.params, buildv1alpha1.ParamValue{
+		Name:        TargetParam,
+		SingleValue: &buildv1alpha1.SingleValue{Value: &value},
+	})
+	return nil
+}
+
+// Type analogous to the pflag "string".
+func (t *TargetValue) Type() string {
+	return "string"
+}
+
+// NewTargetValue instantiate a TargetValue sharing the ParamValue slice pointer.
+func NewTargetValue(params *[]buildv1alpha1.ParamValue) *TargetValue {
+	return &TargetValue{params: params}
+}
+
+// findParamValue returns the parameter with the informed name, or nil when not found.
+func findParamValue(params []buildv1alpha1.ParamValue, name string) *buildv1alpha1.ParamValue {
+	for i := range params {
+		if params[i].Name == name {
+			return &params[i]
+		}
+	}
+	return nil
+}
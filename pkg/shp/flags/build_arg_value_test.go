@@ -0,0 +1,50 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+func TestBuildArgArrayValue(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	params := []buildv1alpha1.ParamValue{}
+	v := NewBuildArgArrayValue(&params)
+
+	g.Expect(v.Set("VERSION=1.0")).To(gomega.Succeed())
+	g.Expect(v.Set("GOFLAGS=-mod=vendor")).To(gomega.Succeed())
+	g.Expect(params).To(gomega.HaveLen(1))
+	g.Expect(params[0].Name).To(gomega.Equal(BuildArgsParam))
+	g.Expect(params[0].SingleValue).To(gomega.BeNil())
+	g.Expect(params[0].Values).To(gomega.HaveLen(2))
+	g.Expect(*params[0].Values[1].Value).To(gomega.Equal("GOFLAGS=-mod=vendor"))
+	g.Expect(v.String()).To(gomega.Equal("[VERSION=1.0,GOFLAGS=-mod=vendor]"))
+
+	g.Expect(v.Set("VERSION")).To(gomega.MatchError("informed value 'VERSION' is not in key=value format"))
+	g.Expect(v.Set("MY-ARG=1")).To(gomega.MatchError(
+		"invalid build argument name 'MY-ARG', must consist of letters, digits and underscores"))
+
+	// the build-args parameter informed as a single value is not extended
+	params = []buildv1alpha1.ParamValue{}
+	g.Expect(NewParamArrayValue(&params).Set("build-args=VERSION=1.0")).To(gomega.Succeed())
+	g.Expect(v.Set("VERSION=2.0")).To(gomega.MatchError("parameter 'build-args' is already set with --param-value"))
+}
+
+func TestTargetValue(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	params := []buildv1alpha1.ParamValue{}
+	v := NewTargetValue(&params)
+	g.Expect(v.String()).To(gomega.BeEmpty())
+
+	g.Expect(v.Set("")).To(gomega.MatchError("the target stage must not be empty"))
+	g.Expect(v.Set("runtime")).To(gomega.Succeed())
+	g.Expect(params).To(gomega.HaveLen(1))
+	g.Expect(params[0].Name).To(gomega.Equal(TargetParam))
+	g.Expect(*params[0].Value).To(gomega.Equal("runtime"))
+	g.Expect(v.String()).To(gomega.Equal("runtime"))
+
+	g.Expect(v.Set("builder")).To(gomega.MatchError("parameter 'target' is already set"))
+}
@@ -82,6 +82,19 @@ func TestBuildSpecFromFlags(t *testing.T) {
 		g.Expect(expected.Source).To(o.Equal(spec.Source), "spec.source")
 	})
 
+	t.Run(".spec.source.revision alias", func(_ *testing.T) {
+		err := flags.Set(GitRevisionFlag, "other-rev")
+		g.Expect(err).To(o.BeNil())
+		g.Expect(*spec.Source.Revision).To(o.Equal("other-rev"))
+
+		err = flags.Set(GitRevisionFlag, "other rev")
+		g.Expect(err).NotTo(o.BeNil())
+
+		err = flags.Set(SourceRevisionFlag, *expected.Source.Revision)
+		g.Expect(err).To(o.BeNil())
+		g.Expect(expected.Source).To(o.Equal(spec.Source), "spec.source")
+	})
+
 	t.Run(".spec.strategy", func(_ *testing.T) {
 		err := flags.Set(StrategyKindFlag, string(buildv1alpha1.ClusterBuildStrategyKind))
 		g.Expect(err).To(o.BeNil())
@@ -130,6 +143,19 @@ func TestBuildSpecFromFlags(t *testing.T) {
 		g.Expect(*expected.Timeout).To(o.Equal(*spec.Timeout), "spec.timeout")
 	})
 
+	t.Run(".spec.timeout alias", func(_ *testing.T) {
+		err := flags.Set(BuildTimeoutFlag, "1h")
+		g.Expect(err).To(o.BeNil())
+		g.Expect(spec.Timeout.Duration).To(o.Equal(time.Hour))
+
+		err = flags.Set(BuildTimeoutFlag, "-1h")
+		g.Expect(err).NotTo(o.BeNil())
+
+		err = flags.Set(TimeoutFlag, expected.Timeout.Duration.String())
+		g.Expect(err).To(o.BeNil())
+		g.Expect(*expected.Timeout).To(o.Equal(*spec.Timeout), "spec.timeout")
+	})
+
 	t.Run(".spec.retention.failedLimit", func(_ *testing.T) {
 		err := flags.Set(RetentionFailedLimitFlag, strconv.FormatUint(uint64(*expected.Retention.FailedLimit), 10))
 		g.Expect(err).To(o.BeNil())
@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"fmt"
+
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/spf13/pflag"
 
@@ -35,10 +37,11 @@ func BuildRunSpecFromFlags(flags *pflag.FlagSet) *buildv1alpha1.BuildRunSpec {
 
 	buildRefFlags(flags, spec.BuildRef)
 	serviceAccountFlags(flags, spec.ServiceAccount)
-	timeoutFlags(flags, spec.Timeout)
+	timeoutFlags(flags, spec.Timeout, "build process timeout, takes precedence over the Build timeout")
 	imageFlags(flags, "output", spec.Output)
 	envFlags(flags, &spec.Env)
 	paramValueFlag(flags, &spec.ParamValues)
+	dockerfileParamFlags(flags, &spec.ParamValues)
 	imageLabelsFlags(flags, spec.Output.Labels)
 	imageAnnotationsFlags(flags, spec.Output.Annotations)
 	buildRunRetentionFlags(flags, spec.Retention)
@@ -46,6 +49,112 @@ func BuildRunSpecFromFlags(flags *pflag.FlagSet) *buildv1alpha1.BuildRunSpec {
 	return spec
 }
 
+// InlineBuildSpecFromFlags creates a BuildSpec to be embedded on the BuildRun, instead of referencing
+// a Build, based on command-line flags. The output image is taken from the BuildRun's own flags.
+func InlineBuildSpecFromFlags(flags *pflag.FlagSet) *buildv1alpha1.BuildSpec {
+	clusterBuildStrategyKind := buildv1alpha1.ClusterBuildStrategyKind
+	spec := &buildv1alpha1.BuildSpec{
+		Source: buildv1alpha1.Source{
+			URL:        pointer.String(""),
+			Revision:   pointer.String(""),
+			ContextDir: pointer.String(""),
+		},
+		Strategy: buildv1alpha1.Strategy{
+			Kind: &clusterBuildStrategyKind,
+		},
+	}
+
+	flags.StringVar(
+		spec.Source.URL,
+		SourceURLFlag,
+		"",
+		fmt.Sprintf("git repository source URL of the inline Build, instead of --%s", BuildrefNameFlag),
+	)
+	flags.Var(
+		NewRevisionValue(spec.Source.Revision),
+		SourceRevisionFlag,
+		"git repository source revision of the inline Build, either a branch, tag or commit SHA",
+	)
+	flags.Var(
+		NewRevisionValue(spec.Source.Revision),
+		GitRevisionFlag,
+		fmt.Sprintf("alias for --%s", SourceRevisionFlag),
+	)
+	flags.StringVar(
+		spec.Source.ContextDir,
+		SourceContextDirFlag,
+		"",
+		"use a inner directory as context directory of the inline Build",
+	)
+	flags.StringVar(
+		&spec.Strategy.Name,
+		StrategyNameFlag,
+		"",
+		"build-strategy name of the inline Build",
+	)
+	flags.Var(
+		NewStrategyKindValue(spec.Strategy.Kind),
+		StrategyKindFlag,
+		"build-strategy kind of the inline Build, either ClusterBuildStrategy (default) or the namespaced BuildStrategy",
+	)
+
+	return spec
+}
+
+// SanitizeInlineBuildSpec checks for empty inline BuildSpec attributes and replaces them with nil,
+// returns true when the inline BuildSpec is informed.
+func SanitizeInlineBuildSpec(b *buildv1alpha1.BuildSpec) bool {
+	if b == nil {
+		return false
+	}
+	if b.Source.URL != nil && *b.Source.URL == "" {
+		b.Source.URL = nil
+	}
+	if b.Source.Revision != nil && *b.Source.Revision == "" {
+		b.Source.Revision = nil
+	}
+	if b.Source.ContextDir != nil && *b.Source.ContextDir == "" {
+		b.Source.ContextDir = nil
+	}
+	return b.Source.URL != nil || b.Source.Revision != nil || b.Source.ContextDir != nil || b.Strategy.Name != ""
+}
+
+// SourceOverrideFromFlags creates a Source instance based on command-line flags, meant to override
+// the source of the referenced Build on a single BuildRun.
+func SourceOverrideFromFlags(flags *pflag.FlagSet) *buildv1alpha1.Source {
+	source := &buildv1alpha1.Source{
+		URL:             pointer.String(""),
+		Revision:        pointer.String(""),
+		Credentials:     &corev1.LocalObjectReference{},
+		BundleContainer: &buildv1alpha1.BundleContainer{},
+	}
+
+	sourceOverrideFlags(flags, source)
+
+	return source
+}
+
+// SanitizeSourceOverride checks for empty source override attributes and replaces them with nil,
+// returns true when there's at least one attribute left to override.
+func SanitizeSourceOverride(source *buildv1alpha1.Source) bool {
+	if source == nil {
+		return false
+	}
+	if source.URL != nil && *source.URL == "" {
+		source.URL = nil
+	}
+	if source.Revision != nil && *source.Revision == "" {
+		source.Revision = nil
+	}
+	if source.Credentials != nil && source.Credentials.Name == "" {
+		source.Credentials = nil
+	}
+	if source.BundleContainer != nil && source.BundleContainer.Image == "" {
+		source.BundleContainer = nil
+	}
+	return source.URL != nil || source.Revision != nil || source.Credentials != nil || source.BundleContainer != nil
+}
+
 // SanitizeBuildRunSpec checks for empty inner data structures and replaces them with nil.
 func SanitizeBuildRunSpec(br *buildv1alpha1.BuildRunSpec) {
 	if br == nil {
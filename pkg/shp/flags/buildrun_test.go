@@ -84,6 +84,20 @@ func TestBuildRunSpecFromFlags(t *testing.T) {
 		g.Expect(*expected.Output).To(o.Equal(*spec.Output), "spec.output")
 	})
 
+	t.Run(".spec.output.labels and annotations", func(_ *testing.T) {
+		g.Expect(flags.Set(OutputImageLabelsFlag, "maintainer=team")).To(o.Succeed())
+		g.Expect(flags.Set(OutputLabelFlag, "org.opencontainers.image.revision=abc123")).To(o.Succeed())
+		g.Expect(flags.Set(OutputAnnotationFlag, "example.com/build-url=https://ci/job/1")).To(o.Succeed())
+
+		g.Expect(spec.Output.Labels).To(o.Equal(map[string]string{
+			"maintainer":                        "team",
+			"org.opencontainers.image.revision": "abc123",
+		}), "spec.output.labels")
+		g.Expect(spec.Output.Annotations).To(o.Equal(map[string]string{
+			"example.com/build-url": "https://ci/job/1",
+		}), "spec.output.annotations")
+	})
+
 	t.Run(".spec.retention.ttlAfterFailed", func(_ *testing.T) {
 		err := flags.Set(RetentionTTLAfterFailedFlag, expected.Retention.TTLAfterFailed.Duration.String())
 		g.Expect(err).To(o.BeNil())
@@ -153,3 +167,53 @@ func TestSanitizeBuildRunSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestSourceOverrideFromFlags(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cmd := &cobra.Command{}
+	flags := cmd.PersistentFlags()
+	source := SourceOverrideFromFlags(flags)
+
+	g.Expect(SanitizeSourceOverride(source.DeepCopy())).To(o.BeFalse())
+
+	g.Expect(flags.Set(SourceURLFlag, "https://github.com/fork/repo")).To(o.Succeed())
+	g.Expect(flags.Set(GitRevisionFlag, "feature")).To(o.Succeed())
+	g.Expect(flags.Set(SourceGitCloneSecretFlag, "fork-credentials")).To(o.Succeed())
+	g.Expect(flags.Set(SourceRevisionFlag, "not valid")).NotTo(o.Succeed())
+
+	g.Expect(SanitizeSourceOverride(source)).To(o.BeTrue())
+	g.Expect(*source).To(o.Equal(buildv1alpha1.Source{
+		URL:         pointer.String("https://github.com/fork/repo"),
+		Revision:    pointer.String("feature"),
+		Credentials: &corev1.LocalObjectReference{Name: "fork-credentials"},
+	}))
+
+	source = SourceOverrideFromFlags(cmd.Flags())
+	g.Expect(cmd.Flags().Set(SourceBundleImageFlag, "ghcr.io/org/app-source:abc123")).To(o.Succeed())
+	g.Expect(SanitizeSourceOverride(source)).To(o.BeTrue())
+	g.Expect(*source).To(o.Equal(buildv1alpha1.Source{
+		BundleContainer: &buildv1alpha1.BundleContainer{Image: "ghcr.io/org/app-source:abc123"},
+	}))
+}
+
+func TestSanitizeInlineBuildSpec(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cmd := &cobra.Command{}
+	spec := InlineBuildSpecFromFlags(cmd.Flags())
+	g.Expect(SanitizeInlineBuildSpec(spec)).To(o.BeFalse())
+	g.Expect(spec.Source.URL).To(o.BeNil())
+	g.Expect(spec.Source.Revision).To(o.BeNil())
+	g.Expect(spec.Source.ContextDir).To(o.BeNil())
+
+	cmd = &cobra.Command{}
+	spec = InlineBuildSpecFromFlags(cmd.Flags())
+	g.Expect(cmd.Flags().Set(GitRevisionFlag, "main")).To(o.Succeed())
+	g.Expect(cmd.Flags().Set(StrategyKindFlag, string(buildv1alpha1.NamespacedBuildStrategyKind))).To(o.Succeed())
+	g.Expect(SanitizeInlineBuildSpec(spec)).To(o.BeTrue())
+	g.Expect(*spec.Source.Revision).To(o.Equal("main"))
+	g.Expect(*spec.Strategy.Kind).To(o.Equal(buildv1alpha1.NamespacedBuildStrategyKind))
+
+	g.Expect(SanitizeInlineBuildSpec(nil)).To(o.BeFalse())
+}
@@ -0,0 +1,20 @@
+package flags
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// YesFlag command-line flag.
+const YesFlag = "yes"
+
+// YesFlags register the yes flag, which skips the confirmation prompt of destructive operations,
+// recording the value on the informed boolean pointer.
+func YesFlags(flags *pflag.FlagSet, yes *bool) {
+	flags.BoolVarP(
+		yes,
+		YesFlag,
+		"y",
+		false,
+		"Do not ask for confirmation, the prompt is also skipped when stdin is not a terminal",
+	)
+}
@@ -2,14 +2,17 @@ package flags
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 )
 
 // CoreEnvVarArrayValue implements pflag.Value interface, in order to store corev1.EnvVar key-value
 // pairs used on Shipwright's BuildSpec.
 type CoreEnvVarArrayValue struct {
-	envs *[]corev1.EnvVar // pointer to the slice of EnvVar
+	envs    *[]corev1.EnvVar // pointer to the slice of EnvVar
+	removed *[]string        // names of the environment variables to remove, when supported
 }
 
 // String prints out the string representation of the slice of EnvVar objects.
@@ -22,8 +25,18 @@ func (c *CoreEnvVarArrayValue) String() string {
 	return fmt.Sprintf("[%s]", csv)
 }
 
-// Set receives a key-value entry separated by equal sign ("=").
+// Set receives a key-value entry separated by equal sign ("="), or when removal is enabled, the
+// variable name followed by dash ("NAME-") to remove it.
 func (c *CoreEnvVarArrayValue) Set(value string) error {
+	if c.removed != nil && !strings.Contains(value, "=") && strings.HasSuffix(value, "-") {
+		name := strings.TrimSuffix(value, "-")
+		if name == "" {
+			return fmt.Errorf("informed value '%s' is not in key- format", value)
+		}
+		*c.removed = append(*c.removed, name)
+		return nil
+	}
+
 	k, v, err := splitKeyValue(value)
 	if err != nil {
 		return err
@@ -48,3 +61,45 @@ func (c *CoreEnvVarArrayValue) Type() string {
 func NewCoreEnvVarArrayValue(envs *[]corev1.EnvVar) *CoreEnvVarArrayValue {
 	return &CoreEnvVarArrayValue{envs: envs}
 }
+
+// EnableEnvRemoval allows the env flag, already registered on the flag-set, to remove environment
+// variables using the "NAME-" syntax, recording the names on the informed slice.
+func EnableEnvRemoval(flags *pflag.FlagSet, removed *[]string) {
+	if f := flags.Lookup(EnvFlag); f != nil {
+		if v, ok := f.Value.(*CoreEnvVarArrayValue); ok {
+			v.removed = removed
+		}
+	}
+}
+
+// EffectiveEnv applies the command-line environment variables on top of the declared ones, the
+// removed names are left out, and the overrides replace the declared variables in place or are
+// appended otherwise.
+func EffectiveEnv(declared, overrides []corev1.EnvVar, removed []string) []corev1.EnvVar {
+	skip := map[string]bool{}
+	for _, name := range removed {
+		skip[name] = true
+	}
+	override := map[string]corev1.EnvVar{}
+	for _, e := range overrides {
+		override[e.Name] = e
+	}
+
+	envs := []corev1.EnvVar{}
+	for _, e := range declared {
+		if o, ok := override[e.Name]; ok {
+			envs = append(envs, o)
+			delete(override, e.Name)
+			continue
+		}
+		if !skip[e.Name] {
+			envs = append(envs, e)
+		}
+	}
+	for _, e := range overrides {
+		if _, ok := override[e.Name]; ok {
+			envs = append(envs, e)
+		}
+	}
+	return envs
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 
 	o "github.com/onsi/gomega"
@@ -48,3 +49,35 @@ func TestCoreEnvVarSliceValue(t *testing.T) {
 	s := c.String()
 	g.Expect(s).To(o.Equal("[a=b,\"b=c,d,e=f\",c=d e]"))
 }
+
+func TestCoreEnvVarArrayValueRemoval(t *testing.T) {
+	g := o.NewWithT(t)
+
+	envs := []corev1.EnvVar{}
+	c := NewCoreEnvVarArrayValue(&envs)
+	g.Expect(c.Set("a-")).ToNot(o.Succeed())
+
+	cmd := &cobra.Command{}
+	envFlags(cmd.Flags(), &envs)
+	removed := []string{}
+	EnableEnvRemoval(cmd.Flags(), &removed)
+	g.Expect(cmd.Flags().Set(EnvFlag, "a-")).To(o.Succeed())
+	g.Expect(cmd.Flags().Set(EnvFlag, "b=c-")).To(o.Succeed())
+	g.Expect(cmd.Flags().Set(EnvFlag, "-")).ToNot(o.Succeed())
+	g.Expect(removed).To(o.Equal([]string{"a"}))
+	g.Expect(envs).To(o.Equal([]corev1.EnvVar{{Name: "b", Value: "c-"}}))
+}
+
+func TestEffectiveEnv(t *testing.T) {
+	g := o.NewWithT(t)
+
+	declared := []corev1.EnvVar{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}, {Name: "c", Value: "3"}}
+	overrides := []corev1.EnvVar{{Name: "d", Value: "4"}, {Name: "b", Value: "5"}}
+
+	g.Expect(EffectiveEnv(declared, overrides, []string{"a", "x"})).To(o.Equal([]corev1.EnvVar{
+		{Name: "b", Value: "5"},
+		{Name: "c", Value: "3"},
+		{Name: "d", Value: "4"},
+	}))
+	g.Expect(EffectiveEnv(nil, nil, []string{"a"})).To(o.BeEmpty())
+}
@@ -0,0 +1,92 @@
+package flags
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReadEnvFile reads the environment variables from the dotenv file informed on --env-from-file, or
+// from stdin when the file is "-". Each line holds a "NAME=value" entry, optionally prefixed by
+// "export", where blank lines and lines starting with "#" are skipped. Values in double quotes are
+// unquoted, escape sequences included, and values in single quotes are taken literally. The
+// variables are kept in the order of the file, and declaring the same name twice is an error.
+func ReadEnvFile(file string, stdin io.Reader) ([]corev1.EnvVar, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		// #nosec G304 the file is informed by the user on purpose
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read --%s: %w", EnvFromFileFlag, err)
+	}
+
+	envs := []corev1.EnvVar{}
+	seen := map[string]int{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		name, value, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid --%s line %d, expecting a \"NAME=value\" entry", EnvFromFileFlag, n)
+		}
+		if previous, exists := seen[name]; exists {
+			return nil, fmt.Errorf("invalid --%s line %d, environment variable '%s' is already set on line %d",
+				EnvFromFileFlag, n, name, previous)
+		}
+		if value, err = unquoteEnvValue(strings.TrimSpace(value)); err != nil {
+			return nil, fmt.Errorf("invalid --%s line %d, unable to unquote the value of '%s': %w",
+				EnvFromFileFlag, n, name, err)
+		}
+		seen[name] = n
+		envs = append(envs, corev1.EnvVar{Name: name, Value: value})
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read --%s: %w", EnvFromFileFlag, err)
+	}
+	return envs, nil
+}
+
+// unquoteEnvValue removes the quotes around the value, if any.
+func unquoteEnvValue(value string) (string, error) {
+	if len(value) < 2 {
+		return value, nil
+	}
+	switch {
+	case value[0] == '"' && value[len(value)-1] == '"':
+		return strconv.Unquote(value)
+	case value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1], nil
+	}
+	return value, nil
+}
+
+// MergeEnv appends the environment variables which are not set yet, the ones already set, i.e. with
+// --env, take precedence.
+func MergeEnv(envs []corev1.EnvVar, from []corev1.EnvVar) []corev1.EnvVar {
+	set := map[string]bool{}
+	for _, e := range envs {
+		set[e.Name] = true
+	}
+	for _, e := range from {
+		if !set[e.Name] {
+			envs = append(envs, e)
+		}
+	}
+	return envs
+}
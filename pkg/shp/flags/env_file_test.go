@@ -0,0 +1,53 @@
+package flags
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestReadEnvFile(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	envs, err := ReadEnvFile("-", strings.NewReader(`
+# build settings
+LOG_LEVEL=debug
+export PROXY = http://proxy:3128
+GREETING="hello\tworld"
+PATTERN='$HOME/*'
+EMPTY=
+EQUALS=a=b
+`))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(envs).To(gomega.Equal([]corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "PROXY", Value: "http://proxy:3128"},
+		{Name: "GREETING", Value: "hello\tworld"},
+		{Name: "PATTERN", Value: "$HOME/*"},
+		{Name: "EMPTY", Value: ""},
+		{Name: "EQUALS", Value: "a=b"},
+	}))
+
+	file := filepath.Join(t.TempDir(), "build.env")
+	g.Expect(os.WriteFile(file, []byte("A=1\n"), 0600)).To(gomega.Succeed())
+	envs, err = ReadEnvFile(file, nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(envs).To(gomega.Equal([]corev1.EnvVar{{Name: "A", Value: "1"}}))
+
+	_, err = ReadEnvFile(filepath.Join(t.TempDir(), "missing.env"), nil)
+	g.Expect(err).To(gomega.MatchError(gomega.HavePrefix("unable to read --env-from-file")))
+	_, err = ReadEnvFile("-", strings.NewReader("A=1\nB\n"))
+	g.Expect(err).To(gomega.MatchError(`invalid --env-from-file line 2, expecting a "NAME=value" entry`))
+	_, err = ReadEnvFile("-", strings.NewReader("A=1\n\nA=2\n"))
+	g.Expect(err).To(gomega.MatchError("invalid --env-from-file line 3, environment variable 'A' is already set on line 1"))
+	_, err = ReadEnvFile("-", strings.NewReader(`A="\q"`))
+	g.Expect(err).To(gomega.MatchError(gomega.HavePrefix("invalid --env-from-file line 1, unable to unquote the value of 'A'")))
+
+	// the variables already set take precedence
+	merged := MergeEnv([]corev1.EnvVar{{Name: "A", Value: "1"}}, []corev1.EnvVar{{Name: "A", Value: "2"}, {Name: "B", Value: "3"}})
+	g.Expect(merged).To(gomega.Equal([]corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "3"}}))
+}
@@ -0,0 +1,46 @@
+package flags
+
+// Feature a command-line flag setting a field of a Shipwright resource, which older Shipwright
+// Build releases may not declare, and therefore silently drop or reject.
+type Feature struct {
+	Flag     string   // command-line flag setting the field
+	Resource string   // Shipwright resource, plural, i.e. "buildruns"
+	Path     []string // field path on the resource, i.e. "spec", "retention", "ttlAfterFailed"
+}
+
+// BuildFeatures the Build flags depending on fields added along the Shipwright Build releases.
+var BuildFeatures = []Feature{
+	{Flag: EnvFlag, Resource: "builds", Path: []string{"spec", "env"}},
+	{Flag: ParamValueFlag, Resource: "builds", Path: []string{"spec", "paramValues"}},
+	{Flag: BuildArgFlag, Resource: "builds", Path: []string{"spec", "paramValues"}},
+	{Flag: TargetFlag, Resource: "builds", Path: []string{"spec", "paramValues"}},
+	{Flag: SourceBundleImageFlag, Resource: "builds", Path: []string{"spec", "source", "bundleContainer"}},
+	{Flag: SourceBundlePruneFlag, Resource: "builds", Path: []string{"spec", "source", "bundleContainer", "prune"}},
+	{Flag: OutputImageLabelsFlag, Resource: "builds", Path: []string{"spec", "output", "labels"}},
+	{Flag: OutputLabelFlag, Resource: "builds", Path: []string{"spec", "output", "labels"}},
+	{Flag: OutputImageAnnotationsFlag, Resource: "builds", Path: []string{"spec", "output", "annotations"}},
+	{Flag: OutputAnnotationFlag, Resource: "builds", Path: []string{"spec", "output", "annotations"}},
+	{Flag: RetentionFailedLimitFlag, Resource: "builds", Path: []string{"spec", "retention", "failedLimit"}},
+	{Flag: RetentionSucceededLimitFlag, Resource: "builds", Path: []string{"spec", "retention", "succeededLimit"}},
+	{Flag: RetentionTTLAfterFailedFlag, Resource: "builds", Path: []string{"spec", "retention", "ttlAfterFailed"}},
+	{Flag: RetentionTTLAfterSucceededFlag, Resource: "builds", Path: []string{"spec", "retention", "ttlAfterSucceeded"}},
+}
+
+// BuildRunFeatures the BuildRun flags depending on fields added along the Shipwright Build
+// releases. The source flags rely on the BuildSpec embedded on the BuildRun.
+var BuildRunFeatures = []Feature{
+	{Flag: EnvFlag, Resource: "buildruns", Path: []string{"spec", "env"}},
+	{Flag: EnvFromFileFlag, Resource: "buildruns", Path: []string{"spec", "env"}},
+	{Flag: ParamValueFlag, Resource: "buildruns", Path: []string{"spec", "paramValues"}},
+	{Flag: ParamFileFlag, Resource: "buildruns", Path: []string{"spec", "paramValues"}},
+	{Flag: BuildArgFlag, Resource: "buildruns", Path: []string{"spec", "paramValues"}},
+	{Flag: TargetFlag, Resource: "buildruns", Path: []string{"spec", "paramValues"}},
+	{Flag: SourceURLFlag, Resource: "buildruns", Path: []string{"spec", "buildSpec"}},
+	{Flag: SourceBundleImageFlag, Resource: "buildruns", Path: []string{"spec", "buildSpec"}},
+	{Flag: OutputImageLabelsFlag, Resource: "buildruns", Path: []string{"spec", "output", "labels"}},
+	{Flag: OutputLabelFlag, Resource: "buildruns", Path: []string{"spec", "output", "labels"}},
+	{Flag: OutputImageAnnotationsFlag, Resource: "buildruns", Path: []string{"spec", "output", "annotations"}},
+	{Flag: OutputAnnotationFlag, Resource: "buildruns", Path: []string{"spec", "output", "annotations"}},
+	{Flag: RetentionTTLAfterFailedFlag, Resource: "buildruns", Path: []string{"spec", "retention", "ttlAfterFailed"}},
+	{Flag: RetentionTTLAfterSucceededFlag, Resource: "buildruns", Path: []string{"spec", "retention", "ttlAfterSucceeded"}},
+}
@@ -22,14 +22,20 @@ const (
 	DockerfileFlag = "dockerfile"
 	// EnvFlag command-line flag.
 	EnvFlag = "env"
+	// EnvFromFileFlag command-line flag, dotenv file with environment variables, "-" for stdin.
+	EnvFromFileFlag = "env-from-file"
 	// SourceURLFlag command-line flag.
 	SourceURLFlag = "source-url"
 	// SourceRevisionFlag command-line flag.
 	SourceRevisionFlag = "source-revision"
+	// GitRevisionFlag command-line flag, alias for SourceRevisionFlag.
+	GitRevisionFlag = "git-revision"
 	// SourceContextDirFlag command-line flag.
 	SourceContextDirFlag = "source-context-dir"
 	// SourceCredentialsSecretFlag command-line flag.
 	SourceCredentialsSecretFlag = "source-credentials-secret" // #nosec G101
+	// SourceGitCloneSecretFlag command-line flag.
+	SourceGitCloneSecretFlag = "source-git-clone-secret" // #nosec G101
 	// SourceBundleImageFlag command-line flag
 	SourceBundleImageFlag = "source-bundle-image"
 	// SourceBundlePruneFlag command-line flag
@@ -48,16 +54,32 @@ const (
 	OutputCredentialsSecretFlag = "output-credentials-secret" // #nosec G101
 	// ParameterValueFlag command-line flag.
 	ParamValueFlag = "param-value"
+	// ParamFileFlag command-line flag, file with the parameter values, "-" for stdin.
+	ParamFileFlag = "param-file"
+	// BuildArgFlag command-line flag.
+	BuildArgFlag = "build-arg"
+	// TargetFlag command-line flag.
+	TargetFlag = "target"
 	// ServiceAccountNameFlag command-line flag.
 	ServiceAccountNameFlag = "sa-name"
 	// ServiceAccountGenerateFlag command-line flag.
 	ServiceAccountGenerateFlag = "sa-generate"
+	// ServiceAccountFlag command-line flag, alias for ServiceAccountNameFlag.
+	ServiceAccountFlag = "service-account"
+	// GenerateServiceAccountFlag command-line flag, alias for ServiceAccountGenerateFlag.
+	GenerateServiceAccountFlag = "service-account-generate"
 	// TimeoutFlag command-line flag.
 	TimeoutFlag = "timeout"
+	// BuildTimeoutFlag command-line flag, alias for TimeoutFlag.
+	BuildTimeoutFlag = "build-timeout"
 	// OutputImageLabelsFlag command-line flag.
 	OutputImageLabelsFlag = "output-image-label"
 	// OutputImageAnnotationsFlag command-line flag.
 	OutputImageAnnotationsFlag = "output-image-annotation"
+	// OutputLabelFlag command-line flag, alias for OutputImageLabelsFlag.
+	OutputLabelFlag = "output-label"
+	// OutputAnnotationFlag command-line flag, alias for OutputImageAnnotationsFlag.
+	OutputAnnotationFlag = "output-annotation"
 	// RetentionFailedLimitFlag command-line flag.
 	RetentionFailedLimitFlag = "retention-failed-limit"
 	// RetentionSucceededLimitFlag command-line flag.
@@ -76,11 +98,15 @@ func sourceFlags(flags *pflag.FlagSet, source *buildv1alpha1.Source) {
 		"",
 		"git repository source URL",
 	)
-	flags.StringVar(
-		source.Revision,
+	flags.Var(
+		NewRevisionValue(source.Revision),
 		SourceRevisionFlag,
-		"",
-		"git repository source revision",
+		"git repository source revision, either a branch, tag or commit SHA",
+	)
+	flags.Var(
+		NewRevisionValue(source.Revision),
+		GitRevisionFlag,
+		fmt.Sprintf("alias for --%s", SourceRevisionFlag),
 	)
 	flags.StringVar(
 		source.ContextDir,
@@ -107,6 +133,38 @@ func sourceFlags(flags *pflag.FlagSet, source *buildv1alpha1.Source) {
 	)
 }
 
+// sourceOverrideFlags flags for overriding a Build's ".spec.source" on a single BuildRun.
+func sourceOverrideFlags(flags *pflag.FlagSet, source *buildv1alpha1.Source) {
+	flags.StringVar(
+		source.URL,
+		SourceURLFlag,
+		"",
+		"override the git repository source URL of the Build",
+	)
+	flags.Var(
+		NewRevisionValue(source.Revision),
+		SourceRevisionFlag,
+		"override the git repository source revision of the Build, either a branch, tag or commit SHA",
+	)
+	flags.Var(
+		NewRevisionValue(source.Revision),
+		GitRevisionFlag,
+		fmt.Sprintf("alias for --%s", SourceRevisionFlag),
+	)
+	flags.StringVar(
+		&source.Credentials.Name,
+		SourceGitCloneSecretFlag,
+		"",
+		"override the name of the secret with credentials to clone the git repository",
+	)
+	flags.StringVar(
+		&source.BundleContainer.Image,
+		SourceBundleImageFlag,
+		"",
+		"run the Build against a source bundle image pushed beforehand, instead of its git repository",
+	)
+}
+
 // strategyFlags flags for ".spec.strategy".
 func strategyFlags(flags *pflag.FlagSet, strategy *buildv1alpha1.Strategy) {
 	flags.StringVar(
@@ -118,7 +176,8 @@ func strategyFlags(flags *pflag.FlagSet, strategy *buildv1alpha1.Strategy) {
 	flags.Var(
 		NewStrategyKindValue(strategy.Kind),
 		StrategyKindFlag,
-		"build-strategy kind",
+		"build-strategy kind, either ClusterBuildStrategy (default) or the namespaced BuildStrategy, "+
+			"for when the same name exists in both scopes",
 	)
 	flags.StringVar(
 		&strategy.Name,
@@ -147,7 +206,7 @@ func imageFlags(flags *pflag.FlagSet, prefix string, image *buildv1alpha1.Image)
 			image.Insecure,
 			fmt.Sprintf("%s-insecure", prefix),
 			false,
-			"flag to indicate an insecure container registry",
+			"the output container registry is insecure, the build skips its TLS verification when pushing the image",
 		)
 	}
 }
@@ -162,14 +221,10 @@ func dockerfileFlags(flags *pflag.FlagSet, dockerfile *string) {
 	)
 }
 
-// timeoutFlags register a timeout flag as time.Duration instance.
-func timeoutFlags(flags *pflag.FlagSet, timeout *metav1.Duration) {
-	flags.DurationVar(
-		&timeout.Duration,
-		TimeoutFlag,
-		time.Duration(0),
-		"build process timeout",
-	)
+// timeoutFlags register the timeout flag, and its alias, as time.Duration instance.
+func timeoutFlags(flags *pflag.FlagSet, timeout *metav1.Duration, usage string) {
+	flags.Var(NewTimeoutValue(&timeout.Duration), TimeoutFlag, usage)
+	flags.Var(NewTimeoutValue(&timeout.Duration), BuildTimeoutFlag, fmt.Sprintf("alias for --%s", TimeoutFlag))
 }
 
 // buildRefFlags register flags for BuildRun's spec.buildRef attribute.
@@ -188,7 +243,7 @@ func buildRefFlags(flags *pflag.FlagSet, buildRef *buildv1alpha1.BuildRef) {
 	)
 }
 
-// serviceAccountFlags register flags for BuildRun's spec.serviceAccount attribute.
+// serviceAccountFlags register flags for BuildRun's spec.serviceAccount attribute, and their aliases.
 func serviceAccountFlags(flags *pflag.FlagSet, sa *buildv1alpha1.ServiceAccount) {
 	flags.StringVar(
 		sa.Name,
@@ -202,13 +257,25 @@ func serviceAccountFlags(flags *pflag.FlagSet, sa *buildv1alpha1.ServiceAccount)
 		false,
 		"generate a Kubernetes service-account for the build",
 	)
+	flags.StringVar(
+		sa.Name,
+		ServiceAccountFlag,
+		"",
+		fmt.Sprintf("alias for --%s", ServiceAccountNameFlag),
+	)
+	flags.BoolVar(
+		sa.Generate,
+		GenerateServiceAccountFlag,
+		false,
+		fmt.Sprintf("alias for --%s", ServiceAccountGenerateFlag),
+	)
 }
 
 // envFlags registers flags for adding corev1.EnvVars.
 func envFlags(flags *pflag.FlagSet, envs *[]corev1.EnvVar) {
 	flags.VarP(
 		NewCoreEnvVarArrayValue(envs),
-		"env",
+		EnvFlag,
 		"e",
 		"specify a key-value pair for an environment variable to set for the build container",
 	)
@@ -224,24 +291,41 @@ func paramValueFlag(flags *pflag.FlagSet, paramValue *[]buildv1alpha1.ParamValue
 	)
 }
 
-// imageLabelsFlags registers flags for output image labels.
+// dockerfileParamFlags registers the "docker build" style flags, setting the build-args and target
+// parameters of the Dockerfile strategies.
+func dockerfileParamFlags(flags *pflag.FlagSet, paramValues *[]buildv1alpha1.ParamValue) {
+	flags.Var(
+		NewBuildArgArrayValue(paramValues),
+		BuildArgFlag,
+		fmt.Sprintf("build argument in KEY=VALUE format, passed on the %q parameter, only honored by the strategies defining it", BuildArgsParam),
+	)
+	flags.Var(
+		NewTargetValue(paramValues),
+		TargetFlag,
+		fmt.Sprintf("Dockerfile stage to build, passed on the %q parameter, only honored by the strategies defining it", TargetParam),
+	)
+}
+
+// imageLabelsFlags registers flags for output image labels, and its alias.
 func imageLabelsFlags(flags *pflag.FlagSet, labels map[string]string) {
 	flags.VarP(
 		NewMapValue(labels),
 		OutputImageLabelsFlag,
 		"",
-		"specify a set of key-value pairs that correspond to labels to set on the output image",
+		"specify a set of key-value pairs that correspond to labels to set on the output image, honored by the build strategies supporting it",
 	)
+	flags.Var(NewMapValue(labels), OutputLabelFlag, fmt.Sprintf("alias for --%s", OutputImageLabelsFlag))
 }
 
-// imageLabelsFlags registers flags for output image annotations.
+// imageAnnotationsFlags registers flags for output image annotations, and its alias.
 func imageAnnotationsFlags(flags *pflag.FlagSet, annotations map[string]string) {
 	flags.VarP(
 		NewMapValue(annotations),
 		OutputImageAnnotationsFlag,
 		"",
-		"specify a set of key-value pairs that correspond to annotations to set on the output image",
+		"specify a set of key-value pairs that correspond to annotations to set on the output image, honored by the build strategies supporting it",
 	)
+	flags.Var(NewMapValue(annotations), OutputAnnotationFlag, fmt.Sprintf("alias for --%s", OutputImageAnnotationsFlag))
 }
 
 func buildRetentionFlags(flags *pflag.FlagSet, buildRetention *buildv1alpha1.BuildRetention) {
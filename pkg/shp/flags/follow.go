@@ -1,9 +1,16 @@
 package flags
 
 import (
+	"fmt"
+
 	"github.com/spf13/pflag"
+
+	"github.com/shipwright-io/cli/pkg/shp/tail"
 )
 
+// reconnectTailFlag command-line flag.
+const reconnectTailFlag = "reconnect-tail"
+
 // FollowFlag register the (log) follow flag, recording the value on the informed boolean pointer.
 func FollowFlag(flags *pflag.FlagSet, follow *bool) {
 	flags.BoolVarP(
@@ -14,3 +21,22 @@ func FollowFlag(flags *pflag.FlagSet, follow *bool) {
 		"Start a build and watch its log until it completes or fails.",
 	)
 }
+
+// ReconnectTailFlag register the flag for the amount of log lines repeated when a broken log stream is
+// reconnected, recording the value on the informed pointer.
+func ReconnectTailFlag(flags *pflag.FlagSet, lines *int64) {
+	flags.Int64Var(
+		lines,
+		reconnectTailFlag,
+		tail.DefaultReconnectTail,
+		"Together with --follow, amount of log lines repeated when reconnecting a broken log stream, avoiding gaps.",
+	)
+}
+
+// ValidateReconnectTail makes sure the amount of log lines repeated on reconnection is not negative.
+func ValidateReconnectTail(lines int64) error {
+	if lines < 0 {
+		return fmt.Errorf("--%s must not be negative", reconnectTailFlag)
+	}
+	return nil
+}
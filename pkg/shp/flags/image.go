@@ -0,0 +1,99 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateOutputImage checks the output image informed is a valid image reference, i.e.
+// "registry/namespace/app:tag" or "registry/namespace/app@sha256:...". An empty image is not
+// validated, the commands requiring it check it on their own.
+func ValidateOutputImage(image string) error {
+	if image == "" {
+		return nil
+	}
+	if _, err := name.ParseReference(image); err != nil {
+		return fmt.Errorf("invalid --%s %q: %w", OutputImageFlag, image, err)
+	}
+	return nil
+}
+
+// ValidateSourceBundleImage checks the source bundle image informed is a valid image reference. An
+// empty image is not validated, as the source bundle is optional.
+func ValidateSourceBundleImage(image string) error {
+	if image == "" {
+		return nil
+	}
+	if _, err := name.ParseReference(image); err != nil {
+		return fmt.Errorf("invalid --%s %q: %w", SourceBundleImageFlag, image, err)
+	}
+	return nil
+}
+
+// OutputImageWarning returns a warning when the output image informed has neither a tag nor a
+// digest, in which case the "latest" tag is employed. Returns empty otherwise.
+func OutputImageWarning(image string) string {
+	if image == "" {
+		return ""
+	}
+	// the strict validation rejects references without an explicit tag or digest
+	if _, err := name.ParseReference(image, name.StrictValidation); err != nil {
+		return fmt.Sprintf("--%s %q has no tag or digest, the %q tag is used",
+			OutputImageFlag, image, name.DefaultTag)
+	}
+	return ""
+}
+
+// ValidateOutputMetadata checks the output image label and annotation keys are qualified names,
+// i.e. "org.opencontainers.image.revision" or "example.com/build-url".
+func ValidateOutputMetadata(output *buildv1alpha1.Image) error {
+	if output == nil {
+		return nil
+	}
+	for _, metadata := range []struct {
+		flag string
+		kv   map[string]string
+	}{
+		{OutputImageLabelsFlag, output.Labels},
+		{OutputImageAnnotationsFlag, output.Annotations},
+	} {
+		for key := range metadata.kv {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return fmt.Errorf("invalid --%s key %q: %s", metadata.flag, key, strings.Join(errs, "; "))
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateBuildRunOutput checks the BuildRun output flags are consistent. The BuildRun output
+// replaces the Build's output as a whole, so --output-insecure, the image labels and annotations
+// require --output-image, otherwise they would be silently dropped.
+func ValidateBuildRunOutput(output *buildv1alpha1.Image) error {
+	if output == nil {
+		return nil
+	}
+	if err := ValidateOutputMetadata(output); err != nil {
+		return err
+	}
+	if output.Image != "" {
+		return nil
+	}
+	var flag string
+	switch {
+	case output.Insecure != nil && *output.Insecure:
+		flag = OutputInsecureFlag
+	case len(output.Labels) > 0:
+		flag = OutputImageLabelsFlag
+	case len(output.Annotations) > 0:
+		flag = OutputImageAnnotationsFlag
+	default:
+		return nil
+	}
+	return fmt.Errorf("--%s requires --%s, the BuildRun output replaces the Build output", flag, OutputImageFlag)
+}
@@ -0,0 +1,92 @@
+package flags
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"k8s.io/utils/pointer"
+)
+
+func TestValidateOutputImage(t *testing.T) {
+	g := o.NewWithT(t)
+
+	tests := []struct {
+		image   string
+		valid   bool
+		warning bool
+	}{
+		{image: "", valid: true},
+		{image: "registry:5000/namespace/app:v1", valid: true},
+		{image: "ghcr.io/namespace/app@sha256:" + sha256Hex, valid: true},
+		{image: "registry/namespace/app", valid: true, warning: true},
+		{image: "registry/namespace/App:v1"},
+		{image: "registry/namespace/app:v 1"},
+		{image: "registry/namespace/app@sha256:abc"},
+	}
+
+	for _, test := range tests {
+		err := ValidateOutputImage(test.image)
+		if !test.valid {
+			g.Expect(err).To(o.HaveOccurred(), test.image)
+			g.Expect(err.Error()).To(o.ContainSubstring(test.image))
+			continue
+		}
+		g.Expect(err).ToNot(o.HaveOccurred(), test.image)
+		if test.warning {
+			g.Expect(OutputImageWarning(test.image)).To(o.ContainSubstring(`the "latest" tag is used`))
+		} else {
+			g.Expect(OutputImageWarning(test.image)).To(o.BeEmpty(), test.image)
+		}
+	}
+}
+
+func TestValidateSourceBundleImage(t *testing.T) {
+	g := o.NewWithT(t)
+
+	g.Expect(ValidateSourceBundleImage("")).To(o.Succeed())
+	g.Expect(ValidateSourceBundleImage("ghcr.io/org/app-source:abc123")).To(o.Succeed())
+	g.Expect(ValidateSourceBundleImage("ghcr.io/org/app-source@sha256:" + sha256Hex)).To(o.Succeed())
+	g.Expect(ValidateSourceBundleImage("ghcr.io/org/App-source")).To(o.MatchError(
+		o.ContainSubstring(`invalid --source-bundle-image "ghcr.io/org/App-source"`)))
+}
+
+func TestValidateBuildRunOutput(t *testing.T) {
+	g := o.NewWithT(t)
+
+	g.Expect(ValidateBuildRunOutput(nil)).To(o.Succeed())
+	g.Expect(ValidateBuildRunOutput(&buildv1alpha1.Image{Insecure: pointer.Bool(false)})).To(o.Succeed())
+	g.Expect(ValidateBuildRunOutput(&buildv1alpha1.Image{Image: "registry/app:v1", Insecure: pointer.Bool(true)})).To(o.Succeed())
+	g.Expect(ValidateBuildRunOutput(&buildv1alpha1.Image{Insecure: pointer.Bool(true)})).
+		To(o.MatchError("--output-insecure requires --output-image, the BuildRun output replaces the Build output"))
+
+	g.Expect(ValidateBuildRunOutput(&buildv1alpha1.Image{Labels: map[string]string{"revision": "abc123"}})).
+		To(o.MatchError("--output-image-label requires --output-image, the BuildRun output replaces the Build output"))
+	g.Expect(ValidateBuildRunOutput(&buildv1alpha1.Image{Annotations: map[string]string{"url": "https://ci"}})).
+		To(o.MatchError("--output-image-annotation requires --output-image, the BuildRun output replaces the Build output"))
+	g.Expect(ValidateBuildRunOutput(&buildv1alpha1.Image{
+		Image:       "registry/app:v1",
+		Labels:      map[string]string{"org.opencontainers.image.revision": "abc123"},
+		Annotations: map[string]string{"example.com/build-url": "https://ci/job/1"},
+	})).To(o.Succeed())
+	g.Expect(ValidateBuildRunOutput(&buildv1alpha1.Image{
+		Image:  "registry/app:v1",
+		Labels: map[string]string{"build url": "https://ci/job/1"},
+	})).To(o.MatchError(o.ContainSubstring(`invalid --output-image-label key "build url"`)))
+}
+
+func TestValidateOutputMetadata(t *testing.T) {
+	g := o.NewWithT(t)
+
+	g.Expect(ValidateOutputMetadata(nil)).To(o.Succeed())
+	g.Expect(ValidateOutputMetadata(&buildv1alpha1.Image{
+		Labels:      map[string]string{"maintainer": "team", "org.opencontainers.image.source": "https://github.com/org/app"},
+		Annotations: map[string]string{"example.com/build-url": "https://ci/job/1"},
+	})).To(o.Succeed())
+	g.Expect(ValidateOutputMetadata(&buildv1alpha1.Image{Annotations: map[string]string{"Example.com/url": "https://ci"}})).
+		To(o.MatchError(o.ContainSubstring(`invalid --output-image-annotation key "Example.com/url"`)))
+	g.Expect(ValidateOutputMetadata(&buildv1alpha1.Image{Labels: map[string]string{"-revision": "abc123"}})).
+		To(o.MatchError(o.ContainSubstring(`invalid --output-image-label key "-revision"`)))
+}
+
+const sha256Hex = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
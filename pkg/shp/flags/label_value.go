@@ -0,0 +1,35 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// LabelValue implements pflag.Value interface, in order to store Kubernetes labels as key-value
+// pairs, rejecting keys and values which are not valid label syntax.
+type LabelValue struct {
+	MapValue
+}
+
+// Set receives a key-value entry separated by equal sign ("="), validating the label key and value.
+func (l *LabelValue) Set(value string) error {
+	k, v, err := splitKeyValue(value)
+	if err != nil {
+		return err
+	}
+	if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+		return fmt.Errorf("invalid label key %q: %s", k, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+		return fmt.Errorf("invalid label value %q: %s", v, strings.Join(errs, "; "))
+	}
+	l.kvMap[k] = v
+	return nil
+}
+
+// NewLabelValue instantiate a LabelValue sharing the map.
+func NewLabelValue(m map[string]string) *LabelValue {
+	return &LabelValue{MapValue: MapValue{kvMap: m}}
+}
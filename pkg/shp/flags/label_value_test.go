@@ -0,0 +1,36 @@
+package flags
+
+import (
+	"strings"
+	"testing"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestNewLabelValue(t *testing.T) {
+	g := o.NewWithT(t)
+
+	labels := map[string]string{}
+	l := NewLabelValue(labels)
+
+	// expect error when key-value is not split by equal sign
+	g.Expect(l.Set("a")).NotTo(o.Succeed())
+
+	// setting simple and prefixed label keys
+	g.Expect(l.Set("a=b")).To(o.Succeed())
+	g.Expect(l.Set("example.com/team=build-infra")).To(o.Succeed())
+	g.Expect(l.Set("empty=")).To(o.Succeed())
+	g.Expect(labels).To(o.Equal(map[string]string{
+		"a":                "b",
+		"example.com/team": "build-infra",
+		"empty":            "",
+	}))
+
+	// invalid keys and values are rejected, leaving the map untouched
+	g.Expect(l.Set("-a=b")).NotTo(o.Succeed())
+	g.Expect(l.Set("a/b/c=d")).NotTo(o.Succeed())
+	g.Expect(l.Set("a=b c")).NotTo(o.Succeed())
+	g.Expect(l.Set("a=" + strings.Repeat("b", 64))).NotTo(o.Succeed())
+	g.Expect(labels).To(o.HaveLen(3))
+	g.Expect(labels["a"]).To(o.Equal("b"))
+}
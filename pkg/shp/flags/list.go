@@ -0,0 +1,189 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AllNamespacesFlag command-line flag.
+	AllNamespacesFlag = "all-namespaces"
+	// ContinueFlag command-line flag.
+	ContinueFlag = "continue"
+	// FieldSelectorFlag command-line flag.
+	FieldSelectorFlag = "field-selector"
+	// LimitFlag command-line flag.
+	LimitFlag = "limit"
+	// SortByFlag command-line flag.
+	SortByFlag = "sort-by"
+	// SortOrderFlag command-line flag.
+	SortOrderFlag = "sort-order"
+
+	// SortOrderAsc ascending sort order.
+	SortOrderAsc = "asc"
+	// SortOrderDesc descending sort order.
+	SortOrderDesc = "desc"
+)
+
+// FieldSelectorFlags register the field-selector flag, recording the value on the informed string
+// pointer, which is passed along to the API server when listing resources.
+func FieldSelectorFlags(flags *pflag.FlagSet, fieldSelector *string) {
+	flags.StringVar(
+		fieldSelector,
+		FieldSelectorFlag,
+		"",
+		"Selector (field query) to filter on, supports '=', '==', and '!=', e.g. --field-selector metadata.name=my-app",
+	)
+}
+
+// AllNamespacesFlags register the all-namespaces flag, recording the value on the informed boolean
+// pointer, to list resources across all namespaces.
+func AllNamespacesFlags(flags *pflag.FlagSet, allNamespaces *bool) {
+	flags.BoolVarP(
+		allNamespaces,
+		AllNamespacesFlag,
+		"A",
+		false,
+		"List the resources across all namespaces",
+	)
+}
+
+// ValidateAllNamespaces checks the all-namespaces flag is not informed together with the namespace
+// flag, the namespace informed via environment is not considered a conflict.
+func ValidateAllNamespaces(flags *pflag.FlagSet, allNamespaces bool) error {
+	if ns := flags.Lookup("namespace"); allNamespaces && ns != nil && ns.Changed {
+		return fmt.Errorf("--%s and --namespace are mutually exclusive", AllNamespacesFlag)
+	}
+	return nil
+}
+
+// SortOptions holds the sorting informed on command-line, applied on the client side after listing
+// the resources.
+type SortOptions struct {
+	By    string // sort key, empty keeps the server order
+	Order string // either ascending or descending
+
+	keys []string // supported sort keys
+}
+
+// SortFlags register the sort-by and sort-order flags, the informed keys are the only ones accepted
+// by the sort-by flag.
+func SortFlags(flags *pflag.FlagSet, keys ...string) *SortOptions {
+	s := &SortOptions{keys: keys}
+	flags.StringVar(
+		&s.By,
+		SortByFlag,
+		"",
+		fmt.Sprintf("Sort the list by one of: %s, by default the server order is kept", strings.Join(keys, ", ")),
+	)
+	flags.StringVar(
+		&s.Order,
+		SortOrderFlag,
+		SortOrderAsc,
+		fmt.Sprintf("Sort order, either %q or %q", SortOrderAsc, SortOrderDesc),
+	)
+	return s
+}
+
+// Validate checks the sort key and order are supported.
+func (s *SortOptions) Validate() error {
+	if s.Order != SortOrderAsc && s.Order != SortOrderDesc {
+		return fmt.Errorf("invalid --%s %q, must be either %q or %q", SortOrderFlag, s.Order, SortOrderAsc, SortOrderDesc)
+	}
+	if s.By == "" {
+		return nil
+	}
+	for _, key := range s.keys {
+		if s.By == key {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid --%s %q, must be one of: %s", SortByFlag, s.By, strings.Join(s.keys, ", "))
+}
+
+// Less returns the less function to sort by, taking the sort order into account, based on the
+// informed less functions per sort key. Returns nil when the server order should be kept.
+func (s *SortOptions) Less(lessByKey map[string]func(i, j int) bool) func(i, j int) bool {
+	less, ok := lessByKey[s.By]
+	if !ok {
+		return nil
+	}
+	if s.Order == SortOrderDesc {
+		return func(i, j int) bool { return less(j, i) }
+	}
+	return less
+}
+
+// PageOptions holds the pagination informed on command-line, passed along to the API server when
+// listing resources.
+type PageOptions struct {
+	Limit    int64  // maximum amount of resources listed, zero lists all
+	Continue string // token of the next page, returned by the previous page
+}
+
+// PageFlags register the limit and continue flags.
+func PageFlags(flags *pflag.FlagSet) *PageOptions {
+	p := &PageOptions{}
+	flags.Int64Var(
+		&p.Limit,
+		LimitFlag,
+		0,
+		"Maximum number of resources to list, zero lists all. The label and field selectors are applied "+
+			"first, and the sorting only applies to the resources listed",
+	)
+	flags.StringVar(
+		&p.Continue,
+		ContinueFlag,
+		"",
+		fmt.Sprintf("Token to list the next page of resources, printed when --%s leaves resources behind", LimitFlag),
+	)
+	return p
+}
+
+// Enabled returns true when the list is paginated.
+func (p *PageOptions) Enabled() bool {
+	return p.Limit > 0 || p.Continue != ""
+}
+
+// Validate checks the limit is not negative.
+func (p *PageOptions) Validate() error {
+	if p.Limit < 0 {
+		return fmt.Errorf("--%s must not be negative", LimitFlag)
+	}
+	return nil
+}
+
+// ApplyTo sets the limit and continue token on the list options.
+func (p *PageOptions) ApplyTo(opts *metav1.ListOptions) {
+	opts.Limit = p.Limit
+	opts.Continue = p.Continue
+}
+
+// Error explains the expired continue token error, the other errors are returned as is.
+func (p *PageOptions) Error(err error) error {
+	if p.Continue != "" && (k8serrors.IsResourceExpired(err) || k8serrors.IsGone(err)) {
+		return fmt.Errorf("the --%s token has expired, list the resources again from the first page: %w",
+			ContinueFlag, err)
+	}
+	return err
+}
+
+// NextPage returns the message telling how to list the next page, or empty when the list is
+// complete.
+func (p *PageOptions) NextPage(list metav1.ListInterface) string {
+	token := list.GetContinue()
+	if token == "" {
+		return ""
+	}
+	msg := fmt.Sprintf("More resources are available, use --%s %q to list the next page", ContinueFlag, token)
+	if remaining := list.GetRemainingItemCount(); remaining != nil {
+		msg = fmt.Sprintf("%d more resources are available, use --%s %q to list the next page",
+			*remaining, ContinueFlag, token)
+	}
+	return msg
+}
@@ -0,0 +1,103 @@
+package flags
+
+import (
+	"sort"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortFlags(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cmd := &cobra.Command{}
+	flags := cmd.PersistentFlags()
+	sortOpts := SortFlags(flags, "name", "size")
+	g.Expect(sortOpts.Validate()).To(o.Succeed())
+
+	items := []struct {
+		name string
+		size int
+	}{{"b", 1}, {"a", 2}, {"c", 1}}
+	lessByKey := map[string]func(i, j int) bool{
+		"name": func(i, j int) bool { return items[i].name < items[j].name },
+		"size": func(i, j int) bool { return items[i].size < items[j].size },
+	}
+	names := func() []string {
+		result := []string{}
+		for _, item := range items {
+			result = append(result, item.name)
+		}
+		return result
+	}
+
+	// no sort key keeps the original order
+	g.Expect(sortOpts.Less(lessByKey)).To(o.BeNil())
+
+	g.Expect(flags.Set(SortByFlag, "name")).To(o.Succeed())
+	g.Expect(sortOpts.Validate()).To(o.Succeed())
+	sort.SliceStable(items, sortOpts.Less(lessByKey))
+	g.Expect(names()).To(o.Equal([]string{"a", "b", "c"}))
+
+	// descending order, the sort is stable for equal elements
+	g.Expect(flags.Set(SortByFlag, "size")).To(o.Succeed())
+	g.Expect(flags.Set(SortOrderFlag, SortOrderDesc)).To(o.Succeed())
+	g.Expect(sortOpts.Validate()).To(o.Succeed())
+	sort.SliceStable(items, sortOpts.Less(lessByKey))
+	g.Expect(names()).To(o.Equal([]string{"a", "b", "c"}))
+
+	g.Expect(flags.Set(SortByFlag, "status")).To(o.Succeed())
+	g.Expect(sortOpts.Validate()).NotTo(o.Succeed())
+
+	g.Expect(flags.Set(SortByFlag, "name")).To(o.Succeed())
+	g.Expect(flags.Set(SortOrderFlag, "random")).To(o.Succeed())
+	g.Expect(sortOpts.Validate()).NotTo(o.Succeed())
+}
+
+func TestAllNamespacesFlags(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cmd := &cobra.Command{}
+	flags := cmd.Flags()
+	flags.StringP("namespace", "n", "", "")
+	allNamespaces := false
+	AllNamespacesFlags(flags, &allNamespaces)
+	g.Expect(ValidateAllNamespaces(flags, allNamespaces)).To(o.Succeed())
+
+	g.Expect(flags.Set(AllNamespacesFlag, "true")).To(o.Succeed())
+	g.Expect(allNamespaces).To(o.BeTrue())
+	g.Expect(ValidateAllNamespaces(flags, allNamespaces)).To(o.Succeed())
+
+	g.Expect(flags.Set("namespace", "default")).To(o.Succeed())
+	g.Expect(ValidateAllNamespaces(flags, allNamespaces)).
+		To(o.MatchError("--all-namespaces and --namespace are mutually exclusive"))
+}
+
+func TestPageFlags(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cmd := &cobra.Command{}
+	flags := cmd.PersistentFlags()
+	page := PageFlags(flags)
+	g.Expect(page.Enabled()).To(o.BeFalse())
+	g.Expect(page.Validate()).To(o.Succeed())
+
+	g.Expect(flags.Set(LimitFlag, "-1")).To(o.Succeed())
+	g.Expect(page.Validate()).To(o.MatchError("--limit must not be negative"))
+
+	g.Expect(flags.Set(LimitFlag, "10")).To(o.Succeed())
+	g.Expect(flags.Set(ContinueFlag, "token")).To(o.Succeed())
+	g.Expect(page.Enabled()).To(o.BeTrue())
+	g.Expect(page.Validate()).To(o.Succeed())
+	opts := metav1.ListOptions{LabelSelector: "app=a"}
+	page.ApplyTo(&opts)
+	g.Expect(opts).To(o.Equal(metav1.ListOptions{LabelSelector: "app=a", Limit: 10, Continue: "token"}))
+
+	list := &metav1.List{}
+	g.Expect(page.NextPage(list)).To(o.BeEmpty())
+	list.Continue = "next"
+	g.Expect(page.NextPage(list)).To(o.Equal(`More resources are available, use --continue "next" to list the next page`))
+}
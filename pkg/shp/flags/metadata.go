@@ -0,0 +1,120 @@
+package flags
+
+import (
+	"errors"
+	"os"
+	"os/user"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// LabelFlag command-line flag.
+	LabelFlag = "label"
+	// AnnotationFlag command-line flag.
+	AnnotationFlag = "annotation"
+	// CreatedByFlag command-line flag.
+	CreatedByFlag = "created-by"
+
+	// CreatedByLabel label carrying the operating system user who created the resource.
+	CreatedByLabel = "shp.shipwright.io/created-by"
+)
+
+// invalidLabelValueChars matches the characters not allowed in a label value.
+var invalidLabelValueChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// Metadata holds the metadata informed on command-line, meant to be set on the resources created by
+// the command.
+type Metadata struct {
+	Labels      map[string]string // labels to set on the resource
+	Annotations map[string]string // annotations to set on the resource
+	CreatedBy   bool              // flag to add the created-by label
+}
+
+// MetadataFromFlags creates a Metadata instance based on command-line flags.
+func MetadataFromFlags(flags *pflag.FlagSet) *Metadata {
+	metadata := &Metadata{
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+	}
+
+	flags.Var(
+		NewLabelValue(metadata.Labels),
+		LabelFlag,
+		"specify a key-value pair for a label to set on the created resource",
+	)
+	flags.Var(
+		NewAnnotationValue(metadata.Annotations),
+		AnnotationFlag,
+		"specify a key-value pair for an annotation to set on the created resource",
+	)
+	flags.BoolVar(
+		&metadata.CreatedBy,
+		CreatedByFlag,
+		false,
+		"label the created resource with the current operating system user",
+	)
+
+	return metadata
+}
+
+// ApplyTo merges the metadata on the informed object, keeping the labels and annotations already
+// present unless they are informed again.
+func (m *Metadata) ApplyTo(objectMeta *metav1.ObjectMeta) error {
+	if m == nil {
+		return nil
+	}
+	labels := map[string]string{}
+	for k, v := range m.Labels {
+		labels[k] = v
+	}
+	if m.CreatedBy {
+		username, err := createdBy()
+		if err != nil {
+			return err
+		}
+		labels[CreatedByLabel] = username
+	}
+	objectMeta.Labels = mergeMaps(objectMeta.Labels, labels)
+	objectMeta.Annotations = mergeMaps(objectMeta.Annotations, m.Annotations)
+	return nil
+}
+
+// mergeMaps copies the entries of src into dst, instantiating dst only when there's something to
+// copy.
+func mergeMaps(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = map[string]string{}
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// createdBy returns the current operating system user as a valid label value, characters not
+// allowed are replaced by underscore ("_") and the value is truncated to the maximum length.
+func createdBy() (string, error) {
+	username := os.Getenv("USER")
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		username = u.Username
+	}
+
+	value := invalidLabelValueChars.ReplaceAllString(username, "_")
+	if len(value) > validation.LabelValueMaxLength {
+		value = value[:validation.LabelValueMaxLength]
+	}
+	value = strings.Trim(value, "_.-")
+	if value == "" {
+		return "", errors.New("unable to determine the current user for the created-by label")
+	}
+	return value, nil
+}
@@ -0,0 +1,59 @@
+package flags
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestMetadataFromFlags(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cmd := &cobra.Command{}
+	flags := cmd.PersistentFlags()
+	metadata := MetadataFromFlags(flags)
+
+	g.Expect(flags.Set(LabelFlag, "team=build")).To(o.Succeed())
+	g.Expect(flags.Set(LabelFlag, "app=my-app")).To(o.Succeed())
+	g.Expect(flags.Set(LabelFlag, "app=invalid value")).NotTo(o.Succeed())
+	g.Expect(flags.Set(AnnotationFlag, "example.com/build-url=https://ci.example.com/42")).To(o.Succeed())
+
+	t.Run("merge labels", func(_ *testing.T) {
+		objectMeta := metav1.ObjectMeta{Labels: map[string]string{"app": "other", "existing": "true"}}
+		g.Expect(metadata.ApplyTo(&objectMeta)).To(o.Succeed())
+		g.Expect(objectMeta.Labels).To(o.Equal(map[string]string{
+			"app":      "my-app",
+			"existing": "true",
+			"team":     "build",
+		}))
+	})
+
+	t.Run("merge annotations", func(_ *testing.T) {
+		objectMeta := metav1.ObjectMeta{Annotations: map[string]string{"existing": "true"}}
+		g.Expect(metadata.ApplyTo(&objectMeta)).To(o.Succeed())
+		g.Expect(objectMeta.Annotations).To(o.Equal(map[string]string{
+			"example.com/build-url": "https://ci.example.com/42",
+			"existing":              "true",
+		}))
+	})
+
+	t.Run("created-by label", func(_ *testing.T) {
+		g.Expect(flags.Set(CreatedByFlag, "true")).To(o.Succeed())
+
+		objectMeta := metav1.ObjectMeta{}
+		g.Expect(metadata.ApplyTo(&objectMeta)).To(o.Succeed())
+		g.Expect(objectMeta.Labels).To(o.HaveKey(CreatedByLabel))
+		g.Expect(validation.IsValidLabelValue(objectMeta.Labels[CreatedByLabel])).To(o.BeEmpty())
+	})
+
+	t.Run("nothing informed", func(_ *testing.T) {
+		objectMeta := metav1.ObjectMeta{}
+		g.Expect(MetadataFromFlags(cmd.Flags()).ApplyTo(&objectMeta)).To(o.Succeed())
+		g.Expect(objectMeta.Labels).To(o.BeNil())
+		g.Expect(objectMeta.Annotations).To(o.BeNil())
+	})
+}
@@ -0,0 +1,199 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/kubectl/pkg/cmd/get"
+
+	"github.com/shipwright-io/build/pkg/client/clientset/versioned/scheme"
+)
+
+const (
+	// OutputFlag command-line flag.
+	OutputFlag = "output"
+	// ShowManagedFieldsFlag command-line flag.
+	ShowManagedFieldsFlag = "show-managed-fields"
+	// TemplateFlag command-line flag.
+	TemplateFlag = "template"
+	// AllowMissingTemplateKeysFlag command-line flag.
+	AllowMissingTemplateKeysFlag = "allow-missing-template-keys"
+	// EnvDefaultAnnotation annotates the flags taking their default from the environment, telling the
+	// --output flag registered by OutputFlags apart from the other flags named "output".
+	EnvDefaultAnnotation = "shipwright.io/env-default"
+)
+
+// OutputOptions holds the output format of the commands printing Shipwright objects, by default
+// a table is printed, otherwise the objects are rendered as JSON, YAML, resource names, custom
+// columns, or using a go-template or jsonpath expression. It's the single place the commands obtain
+// their object printer from.
+type OutputOptions struct {
+	Format    string // output format, empty for the default table
+	NoHeaders bool   // omits the custom-columns header
+
+	jsonYaml *genericclioptions.JSONYamlPrintFlags
+	name     *genericclioptions.NamePrintFlags
+	template *genericclioptions.KubeTemplatePrintFlags
+	columns  *get.CustomColumnsPrintFlags
+}
+
+// OutputFlags register the output, show-managed-fields, template and allow-missing-template-keys
+// flags. The managedFields are omitted from the JSON and YAML output unless show-managed-fields is
+// informed. The template is either informed inline, i.e. "-o jsonpath={.metadata.name}", or using
+// the template flag.
+func OutputFlags(flags *pflag.FlagSet) *OutputOptions {
+	o := &OutputOptions{
+		jsonYaml: genericclioptions.NewJSONYamlPrintFlags(),
+		name:     genericclioptions.NewNamePrintFlags(""),
+		template: genericclioptions.NewKubeTemplatePrintFlags(),
+		columns:  get.NewCustomColumnsPrintFlags(),
+	}
+	flags.StringVarP(
+		&o.Format,
+		OutputFlag,
+		"o",
+		"",
+		fmt.Sprintf("Output format, either empty for the default table or one of: %s",
+			strings.Join(o.AllowedFormats(), ", ")),
+	)
+	flags.BoolVar(
+		&o.jsonYaml.ShowManagedFields,
+		ShowManagedFieldsFlag,
+		false,
+		"Keep the managedFields when printing objects in JSON or YAML format",
+	)
+	flags.StringVar(
+		o.template.TemplateArgument,
+		TemplateFlag,
+		"",
+		"Template string, or path to the template file, used by the go-template, go-template-file and jsonpath output formats",
+	)
+	flags.BoolVar(
+		o.template.AllowMissingKeys,
+		AllowMissingTemplateKeysFlag,
+		true,
+		"Ignore the fields and map keys missing in the objects when printing with a template",
+	)
+	if err := flags.SetAnnotation(OutputFlag, EnvDefaultAnnotation, []string{"SHP_OUTPUT"}); err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// Enabled returns true when the objects should be printed in the informed format, instead of the
+// default table.
+func (o *OutputOptions) Enabled() bool {
+	return o.Format != ""
+}
+
+// Validate checks the output format is supported, and that the template or custom columns, when
+// informed, can be parsed.
+func (o *OutputOptions) Validate() error {
+	if !o.Enabled() {
+		return nil
+	}
+	_, err := o.printer()
+	return err
+}
+
+// PrintObject renders the object, or list of objects, in the informed format. The kind and
+// apiVersion of the objects are set out of the Shipwright scheme, as the clients leave it empty.
+func (o *OutputOptions) PrintObject(w io.Writer, obj runtime.Object) error {
+	p, err := o.printer()
+	if err != nil {
+		return err
+	}
+
+	obj = obj.DeepCopyObject()
+	if meta.IsListType(obj) {
+		if err = meta.EachListItem(obj, setGroupVersionKind); err != nil {
+			return err
+		}
+	}
+	if err = setGroupVersionKind(obj); err != nil {
+		return err
+	}
+	// the name printer only takes the list items one by one
+	if _, ok := p.(*printers.NamePrinter); ok && meta.IsListType(obj) {
+		return meta.EachListItem(obj, func(item runtime.Object) error {
+			return p.PrintObj(item, w)
+		})
+	}
+	return p.PrintObj(obj, w)
+}
+
+// AllowedFormats returns the output formats supported by the JSON, YAML, name, template and custom
+// columns printers.
+func (o *OutputOptions) AllowedFormats() []string {
+	formats := append(o.jsonYaml.AllowedFormats(), o.name.AllowedFormats()...)
+	formats = append(formats, o.template.AllowedFormats()...)
+	return append(formats, o.columns.AllowedFormats()...)
+}
+
+// printer returns the JSON, YAML, name, template or custom columns printer for the informed format.
+// The template and columns may be informed after the format, i.e. "go-template={{.metadata.name}}"
+// or "custom-columns=NAME:.metadata.name".
+func (o *OutputOptions) printer() (printers.ResourcePrinter, error) {
+	p, err := o.jsonYaml.ToPrinter(o.Format)
+	if !genericclioptions.IsNoCompatiblePrinterError(err) {
+		return p, err
+	}
+
+	p, err = o.name.ToPrinter(o.Format)
+	if !genericclioptions.IsNoCompatiblePrinterError(err) {
+		return p, err
+	}
+
+	if strings.HasPrefix(o.Format, "custom-columns") {
+		return o.columnsPrinter()
+	}
+
+	p, err = o.template.ToPrinter(o.Format)
+	if genericclioptions.IsNoCompatiblePrinterError(err) {
+		return nil, fmt.Errorf("invalid --%s %q, must be one of: %s",
+			OutputFlag, o.Format, strings.Join(o.AllowedFormats(), ", "))
+	}
+	return p, err
+}
+
+// columnsPrinter returns the custom columns printer, i.e. "custom-columns=NAME:.metadata.name", the
+// columns JSONPath expressions are parsed upfront so a malformed spec fails before listing objects.
+func (o *OutputOptions) columnsPrinter() (printers.ResourcePrinter, error) {
+	o.columns.NoHeaders = o.NoHeaders
+	p, err := o.columns.ToPrinter(o.Format)
+	if genericclioptions.IsNoCompatiblePrinterError(err) {
+		return nil, fmt.Errorf("invalid --%s %q, must be one of: %s",
+			OutputFlag, o.Format, strings.Join(o.AllowedFormats(), ", "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s %q: %w", OutputFlag, o.Format, err)
+	}
+	for _, column := range p.(*get.CustomColumnsPrinter).Columns {
+		if err = jsonpath.New(column.Header).Parse(column.FieldSpec); err != nil {
+			return nil, fmt.Errorf("invalid --%s %q, column %q: %w", OutputFlag, o.Format, column.Header, err)
+		}
+	}
+	return p, nil
+}
+
+// setGroupVersionKind sets the object kind and apiVersion, when empty, based on the Shipwright
+// scheme.
+func setGroupVersionKind(obj runtime.Object) error {
+	if !obj.GetObjectKind().GroupVersionKind().Empty() {
+		return nil
+	}
+	gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+	return nil
+}
@@ -0,0 +1,123 @@
+package flags
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+func TestOutputFlags(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cmd := &cobra.Command{}
+	flags := cmd.PersistentFlags()
+	output := OutputFlags(flags)
+	g.Expect(output.Enabled()).To(o.BeFalse())
+	g.Expect(output.Validate()).To(o.Succeed())
+
+	g.Expect(flags.Set(OutputFlag, "wide")).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.MatchError(`invalid --output "wide", must be one of: json, yaml, name, ` +
+		`go-template, go-template-file, template, templatefile, jsonpath, jsonpath-as-json, jsonpath-file, ` +
+		`custom-columns, custom-columns-file`))
+
+	list := &buildv1alpha1.BuildList{Items: []buildv1alpha1.Build{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "build",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "shp"}},
+		},
+	}}}
+
+	g.Expect(flags.Set(OutputFlag, "yaml")).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.Succeed())
+	out := &bytes.Buffer{}
+	g.Expect(output.PrintObject(out, list)).To(o.Succeed())
+	g.Expect(out.String()).To(o.ContainSubstring("kind: BuildList"))
+	g.Expect(out.String()).To(o.ContainSubstring("kind: Build\n"))
+	g.Expect(out.String()).To(o.ContainSubstring("apiVersion: shipwright.io/v1alpha1"))
+	g.Expect(out.String()).ToNot(o.ContainSubstring("managedFields"))
+	// the informed object is kept untouched
+	g.Expect(list.Kind).To(o.BeEmpty())
+	g.Expect(list.Items[0].ManagedFields).To(o.HaveLen(1))
+
+	g.Expect(flags.Set(OutputFlag, "json")).To(o.Succeed())
+	g.Expect(flags.Set(ShowManagedFieldsFlag, "true")).To(o.Succeed())
+	out.Reset()
+	g.Expect(output.PrintObject(out, &list.Items[0])).To(o.Succeed())
+	g.Expect(out.String()).To(o.ContainSubstring(`"kind": "Build"`))
+	g.Expect(out.String()).To(o.ContainSubstring(`"managedFields"`))
+
+	g.Expect(flags.Set(OutputFlag, "name")).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.Succeed())
+	out.Reset()
+	g.Expect(output.PrintObject(out, list)).To(o.Succeed())
+	g.Expect(out.String()).To(o.Equal("build.shipwright.io/build\n"))
+
+	g.Expect(flags.Set(OutputFlag, "jsonpath={.items[*].metadata.name}")).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.Succeed())
+	out.Reset()
+	g.Expect(output.PrintObject(out, list)).To(o.Succeed())
+	g.Expect(out.String()).To(o.Equal("build"))
+
+	g.Expect(flags.Set(OutputFlag, "go-template={{range .items}}{{.metadata.name}}{{end}}")).To(o.Succeed())
+	out.Reset()
+	g.Expect(output.PrintObject(out, list)).To(o.Succeed())
+	g.Expect(out.String()).To(o.Equal("build"))
+
+	// invalid templates are reported during validation
+	g.Expect(flags.Set(OutputFlag, "go-template={{.metadata.name")).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.MatchError(o.ContainSubstring("error parsing template")))
+	g.Expect(flags.Set(OutputFlag, "jsonpath={.metadata.name")).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.MatchError(o.ContainSubstring("error parsing jsonpath")))
+
+	file := filepath.Join(t.TempDir(), "template")
+	g.Expect(os.WriteFile(file, []byte("{{.kind}}/{{.metadata.name}}"), 0o600)).To(o.Succeed())
+	g.Expect(flags.Set(OutputFlag, "go-template-file")).To(o.Succeed())
+	g.Expect(flags.Set(TemplateFlag, file)).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.Succeed())
+	out.Reset()
+	g.Expect(output.PrintObject(out, &list.Items[0])).To(o.Succeed())
+	g.Expect(out.String()).To(o.Equal("Build/build"))
+}
+
+func TestOutputFlagsCustomColumns(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cmd := &cobra.Command{}
+	flags := cmd.PersistentFlags()
+	output := OutputFlags(flags)
+
+	list := &buildv1alpha1.BuildList{Items: []buildv1alpha1.Build{{
+		ObjectMeta: metav1.ObjectMeta{Name: "build"},
+		Spec: buildv1alpha1.BuildSpec{
+			Output: buildv1alpha1.Image{Image: "registry/app"},
+		},
+	}}}
+
+	g.Expect(flags.Set(OutputFlag, "custom-columns=NAME:.metadata.name,IMAGE:.spec.output.image")).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.Succeed())
+	out := &bytes.Buffer{}
+	g.Expect(output.PrintObject(out, list)).To(o.Succeed())
+	g.Expect(out.String()).To(o.MatchRegexp(`^NAME\s+IMAGE\nbuild\s+registry/app\n$`))
+
+	output.NoHeaders = true
+	g.Expect(output.Validate()).To(o.Succeed())
+	out.Reset()
+	g.Expect(output.PrintObject(out, list)).To(o.Succeed())
+	g.Expect(out.String()).To(o.MatchRegexp(`^build\s+registry/app\n$`))
+
+	// malformed column specs are reported during validation
+	g.Expect(flags.Set(OutputFlag, "custom-columns")).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.MatchError(o.ContainSubstring("no custom columns given")))
+	g.Expect(flags.Set(OutputFlag, "custom-columns=NAME")).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.MatchError(o.ContainSubstring("expected <header>:<json-path-expr>")))
+	g.Expect(flags.Set(OutputFlag, "custom-columns=NAME:.metadata.name,IMAGE:.spec.output[0")).To(o.Succeed())
+	g.Expect(output.Validate()).To(o.MatchError(o.ContainSubstring(`column "IMAGE"`)))
+}
@@ -0,0 +1,100 @@
+package flags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ReadParamFile reads the parameter values from the file informed on --param-file, either YAML or
+// JSON, or from stdin when the file is "-". Each entry maps the parameter name to a single value or
+// to an array of values, numbers and booleans are taken as written. The parameters are sorted by
+// name.
+func ReadParamFile(file string, stdin io.Reader) ([]buildv1alpha1.ParamValue, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		// #nosec G304 the file is informed by the user on purpose
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read --%s: %w", ParamFileFlag, err)
+	}
+
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse --%s: %w", ParamFileFlag, err)
+	}
+	entries := map[string]interface{}{}
+	// the numbers are kept as written, rather than converted to float
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	if err = decoder.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("unable to parse --%s, expecting \"name: value\" entries: %w", ParamFileFlag, err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := []buildv1alpha1.ParamValue{}
+	for _, name := range names {
+		param := buildv1alpha1.ParamValue{Name: name}
+		switch value := entries[name].(type) {
+		case []interface{}:
+			values := []buildv1alpha1.SingleValue{}
+			for _, item := range value {
+				s, ok := scalarString(item)
+				if !ok {
+					return nil, fmt.Errorf("invalid --%s entry %q, array items must be strings, numbers or booleans", ParamFileFlag, name)
+				}
+				values = append(values, buildv1alpha1.SingleValue{Value: &s})
+			}
+			param.Values = values
+		default:
+			s, ok := scalarString(value)
+			if !ok {
+				return nil, fmt.Errorf("invalid --%s entry %q, the value must be a string, number, boolean or an array of them",
+					ParamFileFlag, name)
+			}
+			param.SingleValue = &buildv1alpha1.SingleValue{Value: &s}
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// scalarString returns the string representation of a string, number or boolean value.
+func scalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return fmt.Sprintf("%t", v), true
+	}
+	return "", false
+}
+
+// MergeParamValues appends the parameters which are not set yet, the ones already set, i.e. with
+// --param-value, take precedence.
+func MergeParamValues(params []buildv1alpha1.ParamValue, from []buildv1alpha1.ParamValue) []buildv1alpha1.ParamValue {
+	for _, param := range from {
+		if findParamValue(params, param.Name) == nil {
+			params = append(params, param)
+		}
+	}
+	return params
+}
@@ -0,0 +1,56 @@
+package flags
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"k8s.io/utils/pointer"
+)
+
+func TestReadParamFile(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	single := func(name, value string) buildv1alpha1.ParamValue {
+		return buildv1alpha1.ParamValue{Name: name, SingleValue: &buildv1alpha1.SingleValue{Value: pointer.String(value)}}
+	}
+
+	params, err := ReadParamFile("-", strings.NewReader(`
+dockerfile: Dockerfile
+build-args:
+- VERSION=1.0
+- DEBUG=true
+retries: 3
+verbose: true
+`))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(params).To(gomega.Equal([]buildv1alpha1.ParamValue{
+		{Name: "build-args", Values: []buildv1alpha1.SingleValue{{Value: pointer.String("VERSION=1.0")}, {Value: pointer.String("DEBUG=true")}}},
+		single("dockerfile", "Dockerfile"),
+		single("retries", "3"),
+		single("verbose", "true"),
+	}))
+
+	file := filepath.Join(t.TempDir(), "params.json")
+	g.Expect(os.WriteFile(file, []byte(`{"dockerfile": "Containerfile", "timeout": 1.5}`), 0600)).To(gomega.Succeed())
+	params, err = ReadParamFile(file, nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(params).To(gomega.Equal([]buildv1alpha1.ParamValue{single("dockerfile", "Containerfile"), single("timeout", "1.5")}))
+
+	_, err = ReadParamFile(filepath.Join(t.TempDir(), "missing.yaml"), nil)
+	g.Expect(err).To(gomega.MatchError(gomega.HavePrefix("unable to read --param-file")))
+	_, err = ReadParamFile("-", strings.NewReader("- dockerfile"))
+	g.Expect(err).To(gomega.MatchError(gomega.HavePrefix(`unable to parse --param-file, expecting "name: value" entries`)))
+	_, err = ReadParamFile("-", strings.NewReader("dockerfile:\n  path: Dockerfile\n"))
+	g.Expect(err).To(gomega.MatchError(`invalid --param-file entry "dockerfile", the value must be a string, number, boolean or an array of them`))
+	_, err = ReadParamFile("-", strings.NewReader("build-args: [[VERSION]]\n"))
+	g.Expect(err).To(gomega.MatchError(`invalid --param-file entry "build-args", array items must be strings, numbers or booleans`))
+
+	// the parameters already set take precedence
+	merged := MergeParamValues([]buildv1alpha1.ParamValue{single("dockerfile", "Dockerfile")},
+		[]buildv1alpha1.ParamValue{single("dockerfile", "Containerfile"), single("target", "runtime")})
+	g.Expect(merged).To(gomega.Equal([]buildv1alpha1.ParamValue{single("dockerfile", "Dockerfile"), single("target", "runtime")}))
+}
@@ -0,0 +1,54 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// RevisionValue implements pflag.Value interface, to represent a Git revision (branch, tag or
+// commit SHA) stored on a string pointer, rejecting values that can't possibly be a valid ref.
+type RevisionValue struct {
+	revisionPtr *string
+}
+
+// String shows the current revision, empty when not set.
+func (r *RevisionValue) String() string {
+	if r.revisionPtr == nil {
+		return ""
+	}
+	return *r.revisionPtr
+}
+
+// Set validates the informed revision and stores it on the shared pointer.
+func (r *RevisionValue) Set(value string) error {
+	if err := validateRevision(value); err != nil {
+		return err
+	}
+	*r.revisionPtr = value
+	return nil
+}
+
+// Type analogous to the pflag "string".
+func (r *RevisionValue) Type() string {
+	return "string"
+}
+
+// NewRevisionValue creates a new instance of RevisionValue sharing an existing reference.
+func NewRevisionValue(revisionPtr *string) *RevisionValue {
+	return &RevisionValue{revisionPtr: revisionPtr}
+}
+
+// validateRevision performs a light check on the informed Git revision, looking for whitespace,
+// control characters and sequences Git never accepts as part of a ref name.
+func validateRevision(revision string) error {
+	for _, r := range revision {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return fmt.Errorf("'%s' is an invalid Git revision, it must not contain whitespace", revision)
+		}
+	}
+	if strings.ContainsAny(revision, "~^:?*[\\") || strings.Contains(revision, "..") {
+		return fmt.Errorf("'%s' is an invalid Git revision, it contains characters not allowed in a ref", revision)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package flags
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestRevisionValue(t *testing.T) {
+	g := o.NewWithT(t)
+
+	revision := ""
+	v := NewRevisionValue(&revision)
+
+	for _, valid := range []string{"main", "v0.1.0", "feature/branch", "6c6ab4bc1e3b0aa2dd3a1e0e5b0e0b8b0d5a0c1f"} {
+		g.Expect(v.Set(valid)).To(o.Succeed())
+		g.Expect(v.String()).To(o.Equal(valid))
+		g.Expect(revision).To(o.Equal(valid))
+	}
+
+	for _, invalid := range []string{"my branch", "main\t", "main..dev", "HEAD~1", "refs:heads"} {
+		g.Expect(v.Set(invalid)).ToNot(o.Succeed())
+	}
+	g.Expect(revision).To(o.Equal("6c6ab4bc1e3b0aa2dd3a1e0e5b0e0b8b0d5a0c1f"))
+}
@@ -20,12 +20,14 @@ func (s *StrategyKindValue) String() string {
 	return string(*s.kindPtr)
 }
 
-// Set set the informed string as BuildStrategyKind by casting.
+// Set set the informed string as BuildStrategyKind by casting, only the namespaced and cluster
+// scoped kinds are accepted.
 func (s *StrategyKindValue) Set(value string) error {
 	kind := buildv1alpha1.BuildStrategyKind(value)
 	if kind != buildv1alpha1.NamespacedBuildStrategyKind &&
 		kind != buildv1alpha1.ClusterBuildStrategyKind {
-		return fmt.Errorf("'%s' is an invalid BuildStrategyKind", value)
+		return fmt.Errorf("'%s' is an invalid BuildStrategyKind, must be one of: %s, %s",
+			value, buildv1alpha1.ClusterBuildStrategyKind, buildv1alpha1.NamespacedBuildStrategyKind)
 	}
 	*s.kindPtr = kind
 	return nil
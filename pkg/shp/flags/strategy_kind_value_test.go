@@ -21,3 +21,15 @@ func TestStrategyKindValue(t *testing.T) {
 	g.Expect(string(expected)).To(o.Equal(v.String()))
 	g.Expect(expected).To(o.Equal(buildStrategyKind))
 }
+
+func TestStrategyKindValueInvalid(t *testing.T) {
+	g := o.NewWithT(t)
+
+	buildStrategyKind := buildv1alpha1.ClusterBuildStrategyKind
+	v := NewStrategyKindValue(&buildStrategyKind)
+
+	err := v.Set("buildstrategy")
+	g.Expect(err).To(o.HaveOccurred())
+	g.Expect(err.Error()).To(o.ContainSubstring("must be one of: ClusterBuildStrategy, BuildStrategy"))
+	g.Expect(buildStrategyKind).To(o.Equal(buildv1alpha1.ClusterBuildStrategyKind))
+}
@@ -0,0 +1,43 @@
+package flags
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeoutValue implements pflag.Value interface, to represent a build timeout stored on a duration
+// pointer, only positive durations are accepted.
+type TimeoutValue struct {
+	durationPtr *time.Duration
+}
+
+// String shows the current duration, empty when not set.
+func (t *TimeoutValue) String() string {
+	if t.durationPtr == nil || *t.durationPtr == 0 {
+		return ""
+	}
+	return t.durationPtr.String()
+}
+
+// Set parses the informed duration, rejecting zero and negative values.
+func (t *TimeoutValue) Set(value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return fmt.Errorf("timeout must be a positive duration, %q informed", value)
+	}
+	*t.durationPtr = d
+	return nil
+}
+
+// Type analogous to the pflag "duration" type.
+func (t *TimeoutValue) Type() string {
+	return "duration"
+}
+
+// NewTimeoutValue instantiate a TimeoutValue sharing the duration pointer.
+func NewTimeoutValue(durationPtr *time.Duration) *TimeoutValue {
+	return &TimeoutValue{durationPtr: durationPtr}
+}
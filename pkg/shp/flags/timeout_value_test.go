@@ -0,0 +1,26 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestNewTimeoutValue(t *testing.T) {
+	g := o.NewWithT(t)
+
+	var d time.Duration
+	v := NewTimeoutValue(&d)
+	g.Expect(v.String()).To(o.BeEmpty())
+
+	g.Expect(v.Set("10m")).To(o.Succeed())
+	g.Expect(d).To(o.Equal(10 * time.Minute))
+	g.Expect(v.String()).To(o.Equal("10m0s"))
+
+	// invalid, zero and negative durations are rejected, keeping the previous value
+	g.Expect(v.Set("ten minutes")).NotTo(o.Succeed())
+	g.Expect(v.Set("0s")).NotTo(o.Succeed())
+	g.Expect(v.Set("-1h")).NotTo(o.Succeed())
+	g.Expect(d).To(o.Equal(10 * time.Minute))
+}
@@ -0,0 +1,117 @@
+package params
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/spf13/pflag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+)
+
+// crdResource the CustomResourceDefinitions, read to discover the fields the installed Shipwright
+// Build release declares.
+var crdResource = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// DynamicClientSet returns a dynamic kubernetes client.
+func (p *Params) DynamicClientSet() (dynamic.Interface, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.dynamicClientset != nil {
+		return p.dynamicClientset, nil
+	}
+	if p.configFlags == nil {
+		return nil, errors.New("no kubernetes configuration to create the dynamic client")
+	}
+	config, err := p.loadRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	p.dynamicClientset, err = dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return p.dynamicClientset, nil
+}
+
+// WarnUnsupportedFlags warns about the flags informed which set fields the installed Shipwright
+// Build release does not declare, instead of leaving the user with the field silently dropped or
+// an opaque rejection. The discovery reads the CustomResourceDefinitions, it's best effort and
+// never fails the command, when they can't be read, i.e. for lack of permission, nothing is shown.
+func (p *Params) WarnUnsupportedFlags(ctx context.Context, flagSet *pflag.FlagSet, w io.Writer, features []flags.Feature) {
+	schemas := map[string]map[string]interface{}{}
+	for _, feature := range features {
+		if !flagSet.Changed(feature.Flag) {
+			continue
+		}
+		resourceSchema, ok := schemas[feature.Resource]
+		if !ok {
+			resourceSchema = p.resourceSchema(ctx, feature.Resource)
+			schemas[feature.Resource] = resourceSchema
+		}
+		if resourceSchema == nil || schemaDeclares(resourceSchema, feature.Path) {
+			continue
+		}
+		p.Logger(w).Warning(fmt.Sprintf(
+			"--%s sets %q, which the installed Shipwright Build release does not declare on %s, it is likely not supported",
+			feature.Flag, strings.Join(feature.Path, "."), feature.Resource))
+	}
+}
+
+// resourceSchema returns the OpenAPI schema of the Shipwright resource version used by the CLI,
+// or nil when it can't be read.
+func (p *Params) resourceSchema(ctx context.Context, resource string) map[string]interface{} {
+	client, err := p.DynamicClientSet()
+	if err != nil {
+		return nil
+	}
+	name := fmt.Sprintf("%s.%s", resource, buildv1alpha1.SchemeGroupVersion.Group)
+	crd, err := client.Resource(crdResource).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, version := range versions {
+		v, ok := version.(map[string]interface{})
+		if !ok || v["name"] != buildv1alpha1.SchemeGroupVersion.Version {
+			continue
+		}
+		openAPISchema, _, _ := unstructured.NestedMap(v, "schema", "openAPIV3Schema")
+		return openAPISchema
+	}
+	return nil
+}
+
+// schemaDeclares returns whether the OpenAPI schema declares the field path, descending into the
+// array items. A schema preserving unknown fields is assumed to declare any field.
+func schemaDeclares(openAPISchema map[string]interface{}, path []string) bool {
+	for _, field := range path {
+		if items, ok := openAPISchema["items"].(map[string]interface{}); ok {
+			openAPISchema = items
+		}
+		if preserve, _ := openAPISchema["x-kubernetes-preserve-unknown-fields"].(bool); preserve {
+			return true
+		}
+		properties, _ := openAPISchema["properties"].(map[string]interface{})
+		next, ok := properties[field].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		openAPISchema = next
+	}
+	return true
+}
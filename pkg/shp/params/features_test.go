@@ -0,0 +1,108 @@
+package params
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+)
+
+// buildRunCRD returns the BuildRun CRD declaring the informed spec properties on v1alpha1.
+func buildRunCRD(specProperties map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "buildruns.shipwright.io"},
+		"spec": map[string]interface{}{
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1beta1", "schema": map[string]interface{}{
+					"openAPIV3Schema": map[string]interface{}{"x-kubernetes-preserve-unknown-fields": true},
+				}},
+				map[string]interface{}{"name": "v1alpha1", "schema": map[string]interface{}{
+					"openAPIV3Schema": map[string]interface{}{"properties": map[string]interface{}{
+						"spec": map[string]interface{}{"properties": specProperties},
+					}},
+				}},
+			},
+		},
+	}}
+}
+
+func TestWarnUnsupportedFlags(t *testing.T) {
+	object := func(properties map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"type": "object", "properties": properties}
+	}
+	oldRelease := buildRunCRD(map[string]interface{}{
+		"env":       map[string]interface{}{"type": "array", "items": object(map[string]interface{}{"name": object(nil)})},
+		"output":    object(map[string]interface{}{"image": object(nil)}),
+		"buildSpec": map[string]interface{}{"type": "object", "x-kubernetes-preserve-unknown-fields": true},
+	})
+
+	tests := []struct {
+		name     string
+		crd      *unstructured.Unstructured
+		args     []string
+		warnings []string
+	}{
+		{name: "no flags informed", crd: oldRelease},
+		{name: "supported flags", crd: oldRelease, args: []string{"--env=A=B", "--source-url=https://github.com/org/app"}},
+		{
+			name: "unsupported flags",
+			crd:  oldRelease,
+			args: []string{"--retention-ttl-after-failed=1h", "--output-label=revision=abc123"},
+			warnings: []string{
+				`--output-label sets "spec.output.labels", which the installed Shipwright Build release does not declare on buildruns`,
+				`--retention-ttl-after-failed sets "spec.retention.ttlAfterFailed", which the installed Shipwright Build release does not declare on buildruns`,
+			},
+		},
+		{name: "unreadable CRD", args: []string{"--retention-ttl-after-failed=1h"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			objects := []runtime.Object{}
+			if test.crd != nil {
+				objects = append(objects, test.crd)
+			}
+			p := NewParamsForTest(nil, nil, nil, "default", nil, nil)
+			p.dynamicClientset = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{crdResource: "CustomResourceDefinitionList"}, objects...)
+
+			flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.BuildRunSpecFromFlags(flagSet)
+			flags.InlineBuildSpecFromFlags(flagSet)
+			g.Expect(flagSet.Parse(test.args)).To(gomega.Succeed())
+
+			var out bytes.Buffer
+			p.WarnUnsupportedFlags(context.Background(), flagSet, &out, flags.BuildRunFeatures)
+			if len(test.warnings) == 0 {
+				g.Expect(out.String()).To(gomega.BeEmpty())
+				return
+			}
+			for _, warning := range test.warnings {
+				g.Expect(out.String()).To(gomega.ContainSubstring(warning))
+			}
+		})
+	}
+
+	// without any configuration the discovery is skipped
+	g := gomega.NewWithT(t)
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.BuildRunSpecFromFlags(flagSet)
+	g.Expect(flagSet.Parse([]string{"--retention-ttl-after-failed=1h"})).To(gomega.Succeed())
+	var out bytes.Buffer
+	NewParamsForTest(nil, nil, nil, "default", nil, nil).
+		WarnUnsupportedFlags(context.Background(), flagSet, &out, flags.BuildRunFeatures)
+	g.Expect(out.String()).To(gomega.BeEmpty())
+}
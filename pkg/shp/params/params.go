@@ -2,29 +2,39 @@ package params
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"math"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/kubectl/pkg/scheme"
+	"k8s.io/kubectl/pkg/util/term"
 
 	buildclientset "github.com/shipwright-io/build/pkg/client/clientset/versioned"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/follower"
 	"github.com/shipwright-io/cli/pkg/shp/reactor"
+	"github.com/shipwright-io/cli/pkg/shp/util"
 
 	"github.com/spf13/pflag"
 )
 
+// hiddenKubeFlags the kubeconfig flags registered but not shown on the usage, the impersonation
+// flags "--as" and "--as-group" are kept visible, i.e. to verify the RBAC of a service account.
 var hiddenKubeFlags = []string{
-	"as",
 	"as-uid",
-	"as-group",
 	"cache-dir",
 	"certificate-authority",
 	"client-certificate",
@@ -40,23 +50,55 @@ var hiddenKubeFlags = []string{
 }
 
 // Params is a place for Shipwright CLI to store its runtime parameters including configured dynamic
-// client and global flags.
+// client and global flags. The clients are created once and shared by the command, and may be
+// safely requested from concurrent goroutines.
 type Params struct {
+	lock sync.Mutex // guards the lazily initialized clients and configuration
+
+	restConfig     *rest.Config             // rest configuration shared by the api-clients
 	clientset      kubernetes.Interface     // kubernetes api-client, global instance
 	buildClientset buildclientset.Interface // shipwright api-client, global instance
-	pw             *reactor.PodWatcher      // pod-watcher global instance
-	follower       *follower.Follower       // follower global instance
 
-	configFlags *genericclioptions.ConfigFlags
-	namespace   string
+	watchClientset      kubernetes.Interface     // kubernetes api-client without the request timeout
+	watchBuildClientset buildclientset.Interface // shipwright api-client without the request timeout
+	dynamicClientset    dynamic.Interface        // dynamic api-client, i.e. to discover the installed CRDs
+	follower            *follower.Follower       // follower global instance
+
+	configFlags    *genericclioptions.ConfigFlags
+	namespace      string
+	checkNamespace bool   // verifies the namespace exists before changing resources in it
+	noColor        bool   // disables colored output
+	quiet          bool   // prints only the names of the objects created, without status messages
+	logFormat      string // format of the CLI's own diagnostic messages
+
+	contextTimeout time.Duration // maximum duration of the watch operations, zero means no limit
 
 	failPollInterval *time.Duration
 	failPollTimeout  *time.Duration
 }
 
+// checkNamespaceFlag global flag, toggles the namespace existence check.
+const checkNamespaceFlag = "check-namespace"
+
+// isTerminal checks whether the writer is a terminal, as a variable to allow testing.
+var isTerminal = term.IsTerminal
+
 // AddFlags accepts flags and adds program global flags to it
 func (p *Params) AddFlags(flags *pflag.FlagSet) {
 	p.configFlags.AddFlags(flags)
+	flags.BoolVar(&p.noColor, "no-color", false, "Disable colored output, also disabled when the output is not a terminal")
+	flags.BoolVarP(&p.quiet, "quiet", "q", false,
+		"Print only the name of the objects created, and do not print the pod status while following the logs, "+
+			"the warnings and errors are still written to stderr")
+	flags.StringVar(&p.logFormat, "log-format", util.LogFormatText, fmt.Sprintf(
+		"Format of the CLI's own status and warning messages, either %q or %q, JSON lines are written to stderr while build logs stay on stdout",
+		util.LogFormatText, util.LogFormatJSON))
+	flags.BoolVar(&p.checkNamespace, checkNamespaceFlag, true,
+		"Verify the namespace exists before running the commands which create or change resources in it, "+
+			"the commands only reading resources don't check it")
+	flags.DurationVar(&p.contextTimeout, "context-timeout", 0,
+		"Maximum duration of the watch operations, like following the logs, zero means no limit. Unlike "+
+			"--request-timeout, which applies to each single request, it bounds the whole operation")
 
 	for _, flag := range hiddenKubeFlags {
 		if err := flags.MarkHidden(flag); err != nil {
@@ -65,18 +107,41 @@ func (p *Params) AddFlags(flags *pflag.FlagSet) {
 	}
 }
 
-// RESTConfig returns the rest configuration based on local flags.
-func (p *Params) RESTConfig() (*rest.Config, error) {
+// loadRESTConfig loads the rest configuration based on local flags only once, it also discovers the
+// namespace. It must be called holding the lock.
+func (p *Params) loadRESTConfig() (*rest.Config, error) {
+	if p.restConfig != nil {
+		return p.restConfig, nil
+	}
+
 	clientConfig := p.configFlags.ToRawKubeConfigLoader()
 	restConfig, err := clientConfig.ClientConfig()
 	if err != nil {
 		return nil, err
 	}
-	p.namespace, _, err = clientConfig.Namespace()
+	if len(p.namespace) == 0 {
+		p.namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p.restConfig = restConfig
+	return p.restConfig, nil
+}
+
+// RESTConfig returns the rest configuration based on local flags.
+func (p *Params) RESTConfig() (*rest.Config, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	config, err := p.loadRESTConfig()
 	if err != nil {
 		return nil, err
 	}
 
+	// callers get their own copy, so the cached configuration is never modified
+	restConfig := rest.CopyConfig(config)
 	restConfig.APIPath = "/api"
 	restConfig.GroupVersion = &corev1.SchemeGroupVersion
 	restConfig.NegotiatedSerializer = serializer.WithoutConversionCodecFactory{
@@ -87,11 +152,20 @@ func (p *Params) RESTConfig() (*rest.Config, error) {
 
 // ClientSet returns a kubernetes clientset.
 func (p *Params) ClientSet() (kubernetes.Interface, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.loadClientSet()
+}
+
+// loadClientSet instantiates the kubernetes clientset only once. It must be called holding the
+// lock.
+func (p *Params) loadClientSet() (kubernetes.Interface, error) {
 	if p.clientset != nil {
 		return p.clientset, nil
 	}
 
-	restConfig, err := p.RESTConfig()
+	restConfig, err := p.loadRESTConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +177,22 @@ func (p *Params) ClientSet() (kubernetes.Interface, error) {
 	return p.clientset, nil
 }
 
-// RequestTimeout returns the setting from k8s --request-timeout param
+// Color returns whether colored output should be written to the informed writer, which is only the
+// case for terminals, unless disabled via --no-color or the NO_COLOR environment variable.
+func (p *Params) Color(w io.Writer) bool {
+	if p.noColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return isTerminal(w)
+}
+
+// Interactive checks whether the input is a terminal, so the user can be prompted.
+func (p *Params) Interactive(in io.Reader) bool {
+	return isTerminal(in)
+}
+
+// RequestTimeout returns the setting from k8s --request-timeout param, applied on each single
+// request, except for the watch and log streaming requests.
 func (p *Params) RequestTimeout() (time.Duration, error) {
 	if p.configFlags.Timeout == nil {
 		return math.MaxInt64, nil
@@ -116,17 +205,107 @@ func (p *Params) RequestTimeout() (time.Duration, error) {
 	return time.ParseDuration(*p.configFlags.Timeout)
 }
 
+// ContextTimeout returns the maximum duration of the watch operations, like following the logs,
+// informed by --context-timeout, zero or negative means no limit.
+func (p *Params) ContextTimeout() time.Duration {
+	if p.contextTimeout <= 0 {
+		return math.MaxInt64
+	}
+	return p.contextTimeout
+}
+
+// loadWatchRESTConfig returns a copy of the rest configuration without the request timeout, which
+// would otherwise interrupt the long-lived watch and log streaming requests. Returns nil when the
+// request timeout is not set, so the regular clients can be shared. It must be called holding the
+// lock.
+func (p *Params) loadWatchRESTConfig() (*rest.Config, error) {
+	// the clients informed up front, without configuration, are shared as well
+	if p.configFlags == nil || (p.restConfig == nil && p.clientset != nil) {
+		return nil, nil
+	}
+	if to, err := p.RequestTimeout(); err != nil || to == math.MaxInt64 {
+		return nil, err
+	}
+	config, err := p.loadRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	watchConfig := rest.CopyConfig(config)
+	watchConfig.Timeout = 0
+	return watchConfig, nil
+}
+
+// WatchClientSet returns a kubernetes clientset for the watch and log streaming requests, which
+// are not bound to the request timeout.
+func (p *Params) WatchClientSet() (kubernetes.Interface, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.loadWatchClientSet()
+}
+
+// loadWatchClientSet instantiates the kubernetes clientset for the watch requests only once, it
+// is the regular clientset when the request timeout is not set. It must be called holding the
+// lock.
+func (p *Params) loadWatchClientSet() (kubernetes.Interface, error) {
+	if p.watchClientset != nil {
+		return p.watchClientset, nil
+	}
+
+	watchConfig, err := p.loadWatchRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	if watchConfig == nil {
+		p.watchClientset, err = p.loadClientSet()
+	} else {
+		p.watchClientset, err = kubernetes.NewForConfig(watchConfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p.watchClientset, nil
+}
+
+// WatchShipwrightClientSet returns a Shipwright Clientset for the watch requests, which are not
+// bound to the request timeout.
+func (p *Params) WatchShipwrightClientSet() (buildclientset.Interface, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.watchBuildClientset != nil {
+		return p.watchBuildClientset, nil
+	}
+	watchConfig, err := p.loadWatchRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	if watchConfig == nil {
+		p.watchBuildClientset, err = p.loadShipwrightClientSet()
+	} else {
+		p.watchBuildClientset, err = buildclientset.NewForConfig(watchConfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p.watchBuildClientset, nil
+}
+
 // ShipwrightClientSet returns a Shipwright Clientset
 func (p *Params) ShipwrightClientSet() (buildclientset.Interface, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.loadShipwrightClientSet()
+}
+
+// loadShipwrightClientSet instantiates the Shipwright clientset only once. It must be called
+// holding the lock.
+func (p *Params) loadShipwrightClientSet() (buildclientset.Interface, error) {
 	if p.buildClientset != nil {
 		return p.buildClientset, nil
 	}
-	clientConfig := p.configFlags.ToRawKubeConfigLoader()
-	config, err := clientConfig.ClientConfig()
-	if err != nil {
-		return nil, err
-	}
-	p.namespace, _, err = clientConfig.Namespace()
+	config, err := p.loadRESTConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +319,14 @@ func (p *Params) ShipwrightClientSet() (buildclientset.Interface, error) {
 // Namespace returns kubernetes namespace with all the overrides
 // from command line and kubernetes config
 func (p *Params) Namespace() string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.loadNamespace()
+}
+
+// loadNamespace discovers the namespace only once. It must be called holding the lock.
+func (p *Params) loadNamespace() string {
 	if len(p.namespace) == 0 {
 		clientConfig := p.configFlags.ToRawKubeConfigLoader()
 		p.namespace, _, _ = clientConfig.Namespace()
@@ -148,25 +335,76 @@ func (p *Params) Namespace() string {
 	return p.namespace
 }
 
-// NewFollower instantiate a new PodWatcher based on the current instance.
-func (p *Params) NewPodWatcher(ctx context.Context) (*reactor.PodWatcher, error) {
-	if p.pw != nil {
-		return p.pw, nil
+// ValidateNamespace checks the namespace informed on the command-line is a valid namespace name,
+// catching typos before any request is made.
+func (p *Params) ValidateNamespace() error {
+	if p.configFlags == nil || p.configFlags.Namespace == nil || *p.configFlags.Namespace == "" {
+		return nil
+	}
+	namespace := *p.configFlags.Namespace
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid --namespace %q: %s", namespace, strings.Join(errs, "; "))
 	}
+	return nil
+}
 
-	to, err := p.RequestTimeout()
-	if err != nil {
-		return nil, err
+// VerifyNamespace checks the namespace exists, unless disabled with --check-namespace=false. Lacking
+// the permission to read the namespace is not an error, the command itself fails when the namespace
+// is missing.
+func (p *Params) VerifyNamespace(ctx context.Context) error {
+	if !p.checkNamespace {
+		return nil
 	}
 	clientset, err := p.ClientSet()
+	if err != nil {
+		return err
+	}
+	namespace := p.Namespace()
+	_, err = clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	switch {
+	case err == nil, k8serrors.IsForbidden(err):
+		return nil
+	case k8serrors.IsNotFound(err):
+		return fmt.Errorf("namespace %q not found, use --%s=false to skip this check", namespace, checkNamespaceFlag)
+	}
+	return err
+}
+
+// Quiet returns whether only the names of the objects created are printed, and the status messages
+// suppressed while following the logs, informed by --quiet.
+func (p *Params) Quiet() bool {
+	return p.quiet
+}
+
+// LogFormat returns the format of the CLI's own diagnostic messages.
+func (p *Params) LogFormat() string {
+	if p.logFormat == "" {
+		return util.LogFormatText
+	}
+	return p.logFormat
+}
+
+// Logger returns a logger for the CLI's own diagnostic messages, writing on the informed writer,
+// which is expected to be stderr when the messages are formatted as JSON.
+func (p *Params) Logger(w io.Writer) *util.Logger {
+	return util.NewLogger(w, p.LogFormat())
+}
+
+// NewPodWatcher instantiate a new PodWatcher based on the current instance. Every call returns a
+// new instance, a PodWatcher can't be reused once stopped, nor shared by concurrent watches.
+func (p *Params) NewPodWatcher(ctx context.Context) (*reactor.PodWatcher, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	clientset, err := p.loadWatchClientSet()
 	if err != nil {
 		return nil, err
 	}
-	p.pw, err = reactor.NewPodWatcher(ctx, to, clientset, p.Namespace())
-	return p.pw, err
+	return reactor.NewPodWatcher(ctx, p.ContextTimeout(), clientset, p.loadNamespace())
 }
 
-// NewFollower instantiate a new Follower based on the current instance.
+// NewFollower instantiate a new Follower based on the current instance, with a PodWatcher of its
+// own, so a new one is needed for each BuildRun followed.
 func (p *Params) NewFollower(
 	ctx context.Context,
 	br types.NamespacedName,
@@ -176,20 +414,37 @@ func (p *Params) NewFollower(
 	if err != nil {
 		return nil, err
 	}
-	clientset, _ := p.ClientSet()
+	return p.NewFollowerWithPodWatcher(ctx, br, ioStreams, pw)
+}
+
+// NewFollowerWithPodWatcher instantiate a new Follower reacting on the events of the informed
+// PodWatcher, i.e. shared with the command driving the watch.
+func (p *Params) NewFollowerWithPodWatcher(
+	ctx context.Context,
+	br types.NamespacedName,
+	ioStreams *genericclioptions.IOStreams,
+	pw *reactor.PodWatcher,
+) (*follower.Follower, error) {
+	// the follower streams the container logs, not bound to the request timeout
+	clientset, _ := p.WatchClientSet()
 
 	buildClientset, err := p.ShipwrightClientSet()
 	if err != nil {
 		return nil, err
 	}
 
-	p.follower = follower.NewFollower(ctx, br, ioStreams, pw, clientset, buildClientset)
+	f := follower.NewFollower(ctx, br, ioStreams, pw, clientset, buildClientset)
+	f.SetLogFormat(p.LogFormat())
 	if p.failPollTimeout != nil {
-		p.follower.SetFailPollTimeout(*p.failPollTimeout)
+		f.SetFailPollTimeout(*p.failPollTimeout)
 	}
 	if p.failPollInterval != nil {
-		p.follower.SetFailPollInterval(*p.failPollInterval)
+		f.SetFailPollInterval(*p.failPollInterval)
 	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.follower = f
 	return p.follower, nil
 }
 
@@ -1,10 +1,27 @@
 package params
 
 import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	fakekubetesting "k8s.io/client-go/testing"
+	"k8s.io/kubectl/pkg/util/term"
+
+	buildclientset "github.com/shipwright-io/build/pkg/client/clientset/versioned"
 )
 
 func TestParamsCreation(t *testing.T) {
@@ -29,3 +46,199 @@ func TestParamsCreation(t *testing.T) {
 	})
 
 }
+
+func TestParamsClientsAreReused(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	shpParams := NewParams()
+	shpParams.AddFlags(pflag.NewFlagSet("name", 0))
+
+	testNs := "test"
+	shpParams.configFlags.Namespace = &testNs
+
+	var wg sync.WaitGroup
+	clientsets := make([]kubernetes.Interface, 10)
+	buildClientsets := make([]buildclientset.Interface, 10)
+	for i := range clientsets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clientsets[i], _ = shpParams.ClientSet()
+			buildClientsets[i], _ = shpParams.ShipwrightClientSet()
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range clientsets {
+		g.Expect(clientsets[i]).ToNot(gomega.BeNil())
+		g.Expect(clientsets[i]).To(gomega.BeIdenticalTo(clientsets[0]))
+		g.Expect(buildClientsets[i]).ToNot(gomega.BeNil())
+		g.Expect(buildClientsets[i]).To(gomega.BeIdenticalTo(buildClientsets[0]))
+	}
+
+	t.Run("RESTConfig", func(_ *testing.T) {
+		restConfig, err := shpParams.RESTConfig()
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(restConfig.APIPath).To(gomega.Equal("/api"))
+
+		// modifying the returned configuration must not affect the shared one
+		restConfig.Host = "https://changed"
+		other, err := shpParams.RESTConfig()
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(other).ToNot(gomega.BeIdenticalTo(restConfig))
+		g.Expect(other.Host).ToNot(gomega.Equal("https://changed"))
+	})
+}
+
+func TestParamsColor(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	defer func() { isTerminal = term.IsTerminal }()
+	t.Setenv("NO_COLOR", "")
+
+	flagset := pflag.NewFlagSet("name", 0)
+	shpParams := NewParams()
+	shpParams.AddFlags(flagset)
+
+	// a regular writer is not a terminal
+	g.Expect(shpParams.Color(&bytes.Buffer{})).To(gomega.BeFalse())
+
+	isTerminal = func(interface{}) bool { return true }
+	g.Expect(shpParams.Color(&bytes.Buffer{})).To(gomega.BeTrue())
+
+	t.Setenv("NO_COLOR", "1")
+	g.Expect(shpParams.Color(&bytes.Buffer{})).To(gomega.BeFalse())
+
+	t.Setenv("NO_COLOR", "")
+	g.Expect(flagset.Set("no-color", "true")).To(gomega.Succeed())
+	g.Expect(shpParams.Color(&bytes.Buffer{})).To(gomega.BeFalse())
+}
+
+func TestParamsInteractive(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	defer func() { isTerminal = term.IsTerminal }()
+
+	shpParams := NewParams()
+	g.Expect(shpParams.Interactive(&bytes.Buffer{})).To(gomega.BeFalse())
+
+	isTerminal = func(interface{}) bool { return true }
+	g.Expect(shpParams.Interactive(&bytes.Buffer{})).To(gomega.BeTrue())
+}
+
+func TestParamsWatchClients(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	flagset := pflag.NewFlagSet("name", 0)
+	shpParams := NewParams()
+	shpParams.AddFlags(flagset)
+	g.Expect(shpParams.ContextTimeout()).To(gomega.Equal(time.Duration(math.MaxInt64)))
+
+	g.Expect(flagset.Set("request-timeout", "5s")).To(gomega.Succeed())
+	g.Expect(flagset.Set("context-timeout", "1m")).To(gomega.Succeed())
+	g.Expect(shpParams.ContextTimeout()).To(gomega.Equal(time.Minute))
+
+	// the request timeout must not be applied on the watch requests
+	shpParams.lock.Lock()
+	watchConfig, err := shpParams.loadWatchRESTConfig()
+	shpParams.lock.Unlock()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(watchConfig).ToNot(gomega.BeNil())
+	g.Expect(watchConfig.Timeout).To(gomega.BeZero())
+
+	restConfig, err := shpParams.RESTConfig()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(restConfig.Timeout).To(gomega.Equal(5 * time.Second))
+
+	client, err := shpParams.ClientSet()
+	g.Expect(err).To(gomega.BeNil())
+	watchClient, err := shpParams.WatchClientSet()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(watchClient).ToNot(gomega.BeIdenticalTo(client))
+}
+
+func TestParamsImpersonation(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	headers := make(chan http.Header, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	flagset := pflag.NewFlagSet("name", 0)
+	shpParams := NewParams()
+	shpParams.AddFlags(flagset)
+	for _, name := range []string{"as", "as-group"} {
+		flag := flagset.Lookup(name)
+		g.Expect(flag).ToNot(gomega.BeNil(), "flag --%s must be registered", name)
+		g.Expect(flag.Hidden).To(gomega.BeFalse(), "flag --%s must be visible", name)
+	}
+
+	g.Expect(flagset.Set("kubeconfig", "/dev/null")).To(gomega.Succeed())
+	g.Expect(flagset.Set("server", server.URL)).To(gomega.Succeed())
+	g.Expect(flagset.Set("namespace", "test")).To(gomega.Succeed())
+	g.Expect(flagset.Set("request-timeout", "5s")).To(gomega.Succeed())
+	g.Expect(flagset.Set("as", "system:serviceaccount:test:builder")).To(gomega.Succeed())
+	g.Expect(flagset.Set("as-group", "developers")).To(gomega.Succeed())
+	g.Expect(flagset.Set("as-group", "testers")).To(gomega.Succeed())
+
+	restConfig, err := shpParams.RESTConfig()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(restConfig.Impersonate.UserName).To(gomega.Equal("system:serviceaccount:test:builder"))
+	g.Expect(restConfig.Impersonate.Groups).To(gomega.Equal([]string{"developers", "testers"}))
+
+	// both the regular and the watch clients must send the impersonation headers
+	client, err := shpParams.ClientSet()
+	g.Expect(err).To(gomega.BeNil())
+	watchClient, err := shpParams.WatchClientSet()
+	g.Expect(err).To(gomega.BeNil())
+	buildClient, err := shpParams.ShipwrightClientSet()
+	g.Expect(err).To(gomega.BeNil())
+
+	ctx := context.Background()
+	_, _ = client.CoreV1().Pods("test").Get(ctx, "pod", metav1.GetOptions{})
+	_, _ = watchClient.CoreV1().Pods("test").Get(ctx, "pod", metav1.GetOptions{})
+	_, _ = buildClient.ShipwrightV1alpha1().Builds("test").Get(ctx, "build", metav1.GetOptions{})
+	for i := 0; i < 3; i++ {
+		var header http.Header
+		g.Eventually(headers).Should(gomega.Receive(&header))
+		g.Expect(header.Get("Impersonate-User")).To(gomega.Equal("system:serviceaccount:test:builder"))
+		g.Expect(header.Values("Impersonate-Group")).To(gomega.Equal([]string{"developers", "testers"}))
+	}
+}
+
+func TestParamsNamespaceValidation(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	shpParams := NewParams()
+	flagset := pflag.NewFlagSet("name", 0)
+	shpParams.AddFlags(flagset)
+	g.Expect(shpParams.ValidateNamespace()).To(gomega.Succeed())
+
+	g.Expect(flagset.Set("namespace", "My_Namespace")).To(gomega.Succeed())
+	g.Expect(shpParams.ValidateNamespace()).To(gomega.MatchError(gomega.HavePrefix(`invalid --namespace "My_Namespace": `)))
+	g.Expect(flagset.Set("namespace", "my-namespace")).To(gomega.Succeed())
+	g.Expect(shpParams.ValidateNamespace()).To(gomega.Succeed())
+
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "existing"}})
+	shpParams = NewParamsForTest(clientset, nil, nil, "existing", nil, nil)
+	shpParams.checkNamespace = true
+	g.Expect(shpParams.VerifyNamespace(ctx)).To(gomega.Succeed())
+
+	shpParams = NewParamsForTest(clientset, nil, nil, "missing", nil, nil)
+	shpParams.checkNamespace = true
+	g.Expect(shpParams.VerifyNamespace(ctx)).To(gomega.MatchError(
+		`namespace "missing" not found, use --check-namespace=false to skip this check`))
+	shpParams.checkNamespace = false
+	g.Expect(shpParams.VerifyNamespace(ctx)).To(gomega.Succeed())
+
+	// without the permission to read namespaces the check is skipped
+	clientset.PrependReactor("get", "namespaces", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+		return true, nil, k8serrors.NewForbidden(corev1.Resource("namespaces"), "missing", nil)
+	})
+	shpParams.checkNamespace = true
+	g.Expect(shpParams.VerifyNamespace(ctx)).To(gomega.Succeed())
+}
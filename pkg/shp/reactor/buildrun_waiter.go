@@ -0,0 +1,58 @@
+package reactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	buildclientset "github.com/shipwright-io/build/pkg/client/clientset/versioned"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// buildRunPollInterval interval between the BuildRun retrievals while waiting for its completion.
+var buildRunPollInterval = time.Second
+
+// WaitForCompletion blocks until the BuildRun reaches a terminal state, i.e. its Succeeded condition
+// is either true or false, and returns the condition. A BuildRun canceled by the user is terminal
+// once the controller marks it as failed, with the reason "BuildRunCanceled". Errors retrieving the
+// BuildRun are retried, except when it doesn't exist or access is denied. The context bounds the
+// wait, when it expires or is canceled the error wraps the context error.
+func WaitForCompletion(
+	ctx context.Context,
+	clientset buildclientset.Interface,
+	namespace string,
+	name string,
+) (*buildv1alpha1.Condition, error) {
+	brClient := clientset.ShipwrightV1alpha1().BuildRuns(namespace)
+
+	var condition *buildv1alpha1.Condition
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, buildRunPollInterval, true, func(ctx context.Context) (bool, error) {
+		br, err := brClient.Get(ctx, name, metav1.GetOptions{})
+		switch {
+		case k8serrors.IsNotFound(err), k8serrors.IsForbidden(err), k8serrors.IsUnauthorized(err):
+			return false, err
+		case err != nil:
+			lastErr = err
+			return false, nil
+		}
+		lastErr = nil
+		condition = br.Status.GetCondition(buildv1alpha1.Succeeded)
+		return condition != nil && condition.Status != corev1.ConditionUnknown, nil
+	})
+	if err == nil {
+		return condition, nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if lastErr != nil {
+			return nil, fmt.Errorf("waiting for BuildRun %q to complete: %w, last error: %s", name, ctxErr, lastErr)
+		}
+		return nil, fmt.Errorf("waiting for BuildRun %q to complete: %w", name, ctxErr)
+	}
+	return nil, err
+}
@@ -0,0 +1,133 @@
+package reactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	fakekubetesting "k8s.io/client-go/testing"
+)
+
+func Test_WaitForCompletion(t *testing.T) {
+	buildRunPollInterval = time.Millisecond
+	defer func() { buildRunPollInterval = time.Second }()
+
+	name := "br"
+	canceled := buildv1alpha1.BuildRunRequestedState(buildv1alpha1.BuildRunStateCancel)
+
+	tests := []struct {
+		name       string
+		state      *buildv1alpha1.BuildRunRequestedState
+		conditions []*buildv1alpha1.Condition // condition returned on each retrieval, the last one repeats
+		getErr     error                      // error returned by the first retrieval
+		timeout    time.Duration
+		cancel     bool
+		expected   *buildv1alpha1.Condition
+		err        error
+	}{{
+		name: "succeeded",
+		conditions: []*buildv1alpha1.Condition{
+			nil,
+			{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionUnknown, Reason: "Pending"},
+			{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionTrue, Reason: "Succeeded"},
+		},
+		expected: &buildv1alpha1.Condition{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionTrue, Reason: "Succeeded"},
+	}, {
+		name: "failed",
+		conditions: []*buildv1alpha1.Condition{
+			{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionUnknown, Reason: "Running"},
+			{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionFalse, Reason: "Failed", Message: "step failed"},
+		},
+		expected: &buildv1alpha1.Condition{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionFalse, Reason: "Failed", Message: "step failed"},
+	}, {
+		name:  "canceled by the user",
+		state: &canceled,
+		conditions: []*buildv1alpha1.Condition{
+			{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionUnknown, Reason: "Running"},
+			{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionFalse, Reason: buildv1alpha1.BuildRunStateCancel},
+		},
+		expected: &buildv1alpha1.Condition{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionFalse, Reason: buildv1alpha1.BuildRunStateCancel},
+	}, {
+		name: "transient error is retried",
+		conditions: []*buildv1alpha1.Condition{
+			{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionTrue, Reason: "Succeeded"},
+		},
+		getErr:   errors.New("connection reset by peer"),
+		expected: &buildv1alpha1.Condition{Type: buildv1alpha1.Succeeded, Status: corev1.ConditionTrue, Reason: "Succeeded"},
+	}, {
+		name:   "not found",
+		getErr: k8serrors.NewNotFound(buildv1alpha1.Resource("buildruns"), name),
+		err:    k8serrors.NewNotFound(buildv1alpha1.Resource("buildruns"), name),
+	}, {
+		name:       "context canceled",
+		conditions: []*buildv1alpha1.Condition{nil},
+		cancel:     true,
+		err:        context.Canceled,
+	}, {
+		name:       "timeout",
+		conditions: []*buildv1alpha1.Condition{nil},
+		timeout:    20 * time.Millisecond,
+		err:        context.DeadlineExceeded,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			br := &buildv1alpha1.BuildRun{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name},
+				Spec:       buildv1alpha1.BuildRunSpec{State: test.state},
+			}
+			clientset := shpfake.NewSimpleClientset(br)
+			calls := 0
+			clientset.PrependReactor("get", "buildruns", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+				calls++
+				if calls == 1 && test.getErr != nil {
+					return true, nil, test.getErr
+				}
+				result := br.DeepCopy()
+				if len(test.conditions) > 0 {
+					condition := test.conditions[len(test.conditions)-1]
+					if calls <= len(test.conditions) {
+						condition = test.conditions[calls-1]
+					}
+					if condition != nil {
+						result.Status.Conditions = buildv1alpha1.Conditions{*condition}
+					}
+				}
+				return true, result, nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if test.timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+			if test.cancel {
+				time.AfterFunc(20*time.Millisecond, cancel)
+			}
+
+			condition, err := WaitForCompletion(ctx, clientset, metav1.NamespaceDefault, name)
+			if test.err != nil {
+				g.Expect(err).To(o.MatchError(o.ContainSubstring(test.err.Error())))
+				if errors.Is(test.err, context.Canceled) || errors.Is(test.err, context.DeadlineExceeded) {
+					g.Expect(errors.Is(err, test.err)).To(o.BeTrue())
+				}
+				g.Expect(condition).To(o.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(o.HaveOccurred())
+			g.Expect(condition).To(o.Equal(test.expected))
+		})
+	}
+}
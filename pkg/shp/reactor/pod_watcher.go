@@ -2,6 +2,8 @@ package reactor
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -15,8 +17,11 @@ const (
 	// ContextTimeoutMessage is the message for a context timeout
 	ContextTimeoutMessage = "context deadline has been exceeded"
 
-	// RequestTimeoutMessage is the message for a request timeout
-	RequestTimeoutMessage = "request timeout has expired"
+	// ContextCanceledMessage is the message for a canceled context, i.e. interrupted by the user
+	ContextCanceledMessage = "context has been canceled"
+
+	// WatchTimeoutMessage is the message for the watch timeout, bounding the whole event loop
+	WatchTimeoutMessage = "watch timeout has expired"
 )
 
 // PodWatcher a simple function orchestrator based on watching a given pod and reacting upon the
@@ -24,8 +29,8 @@ const (
 // changes.
 type PodWatcher struct {
 	ctx         context.Context
-	to          time.Duration
-	stopCh      chan bool // stops the event loop execution
+	to          time.Duration // watch timeout, bounding the whole event loop
+	stopCh      chan bool     // stops the event loop execution
 	stopLock    sync.Mutex
 	stopped     bool
 	eventTicker *time.Ticker
@@ -34,12 +39,16 @@ type PodWatcher struct {
 	watcher     watch.Interface // client watch instance
 	listOpts    metav1.ListOptions
 
+	schedulerTimeout time.Duration // maximum time for the pod to start running, zero disables it
+
 	noPodEventsYetFn []NoPodEventsYetFn
 	toPodFn          []TimeoutPodFn
 	skipPodFn        []SkipPodFn
 	onPodAddedFn     []OnPodEventFn
 	onPodModifiedFn  []OnPodEventFn
 	onPodDeletedFn   []OnPodEventFn
+
+	schedulerTimeoutFn []SchedulerTimeoutFn
 }
 
 // SkipPodFn a given pod instance is informed and expects a boolean as return. When true is returned
@@ -49,13 +58,17 @@ type SkipPodFn func(pod *corev1.Pod) bool
 // OnPodEventFn when a pod is modified this method handles the event.
 type OnPodEventFn func(pod *corev1.Pod) error
 
-// TimeoutPodFn when either the context or request timeout expires before the Pod finishes
+// TimeoutPodFn when either the context or watch timeout expires before the Pod finishes
 type TimeoutPodFn func(msg string)
 
 // NoPodEventsYetFn when the watch has not received the create event within a reasonable time,
 // where a PodList is also provided in the off chance the Pod completed before the Watch was started.
 type NoPodEventsYetFn func(podList *corev1.PodList)
 
+// SchedulerTimeoutFn when the pod has not started running within the scheduler timeout, the last
+// pod observed is informed, or nil when no pod events have been observed.
+type SchedulerTimeoutFn func(pod *corev1.Pod)
+
 // WithSkipPodFn sets the skip function instance.
 func (p *PodWatcher) WithSkipPodFn(fn SkipPodFn) *PodWatcher {
 	p.skipPodFn = append(p.skipPodFn, fn)
@@ -80,7 +93,7 @@ func (p *PodWatcher) WithOnPodDeletedFn(fn OnPodEventFn) *PodWatcher {
 	return p
 }
 
-// WithTimeoutPodFn sets the function executed when the context or request timeout fires
+// WithTimeoutPodFn sets the function executed when the context or watch timeout fires
 func (p *PodWatcher) WithTimeoutPodFn(fn TimeoutPodFn) *PodWatcher {
 	p.toPodFn = append(p.toPodFn, fn)
 	return p
@@ -92,6 +105,20 @@ func (p *PodWatcher) WithNoPodEventsYetFn(fn NoPodEventsYetFn) *PodWatcher {
 	return p
 }
 
+// WithSchedulerTimeoutFn sets the function executed when the pod has not started running within the
+// scheduler timeout.
+func (p *PodWatcher) WithSchedulerTimeoutFn(fn SchedulerTimeoutFn) *PodWatcher {
+	p.schedulerTimeoutFn = append(p.schedulerTimeoutFn, fn)
+	return p
+}
+
+// WithSchedulerTimeout sets the maximum time for the pod to start running, the event loop is
+// interrupted with an error when it expires. Zero disables the scheduler timeout.
+func (p *PodWatcher) WithSchedulerTimeout(timeout time.Duration) *PodWatcher {
+	p.schedulerTimeout = timeout
+	return p
+}
+
 // handleEvent applies user informed functions against informed pod and event.
 func (p *PodWatcher) handleEvent(pod *corev1.Pod, event watch.Event) error {
 	//p.stopLock.Lock()
@@ -136,6 +163,20 @@ func (p *PodWatcher) Connect(listOpts metav1.ListOptions) error {
 // the loop is interrupted.  Separating out WaitForCompletion from Start helps deal with the fake k8s clients, which are used by the unit tests,
 // and the capabilities of their Watch implementation.
 func (p *PodWatcher) WaitForCompletion() (*corev1.Pod, error) {
+	// the watch timeout is accounted for the whole event loop, not for each event
+	watchTimeout := time.NewTimer(p.to)
+	defer watchTimeout.Stop()
+
+	// the scheduler timeout only accounts for the pre-running phase, the channel is disabled as soon
+	// as the pod starts running, or when the timeout is not set
+	var schedulerTimeout <-chan time.Time
+	if p.schedulerTimeout > 0 {
+		schedulerTimer := time.NewTimer(p.schedulerTimeout)
+		defer schedulerTimer.Stop()
+		schedulerTimeout = schedulerTimer.C
+	}
+	var lastPod *corev1.Pod
+
 	for {
 		select {
 		// handling the regular pod modification events, which should trigger calling event functions
@@ -149,17 +190,12 @@ func (p *PodWatcher) WaitForCompletion() (*corev1.Pod, error) {
 				continue
 			}
 
-			if len(p.skipPodFn) > 0 {
-				skip := false
-				for _, fn := range p.skipPodFn {
-					if fn(pod) {
-						skip = true
-						break
-					}
-				}
-				if skip {
-					continue
-				}
+			if p.skipPod(pod) {
+				continue
+			}
+			lastPod = pod
+			if podStarted(pod) {
+				schedulerTimeout = nil
 			}
 			if err := p.handleEvent(pod, event); err != nil {
 				return pod, err
@@ -168,17 +204,21 @@ func (p *PodWatcher) WaitForCompletion() (*corev1.Pod, error) {
 		// the event loop as well.
 		case <-p.ctx.Done():
 			p.watcher.Stop()
+			msg := ContextTimeoutMessage
+			if errors.Is(p.ctx.Err(), context.Canceled) {
+				msg = ContextCanceledMessage
+			}
 			for _, fn := range p.toPodFn {
-				fn(ContextTimeoutMessage)
+				fn(msg)
 			}
 			return nil, nil
 
-		// handle k8s --request-timeout setting, converted to time.Duration, that is passed down to PodWatcher;
-		// if we have exceeded it, we exit
-		case <-time.After(p.to):
+		// handle the --context-timeout setting, converted to time.Duration, that is passed down to
+		// PodWatcher; if we have exceeded it, we exit
+		case <-watchTimeout.C:
 			p.watcher.Stop()
 			for _, fn := range p.toPodFn {
-				fn(RequestTimeoutMessage)
+				fn(WatchTimeoutMessage)
 			}
 			return nil, nil
 
@@ -192,12 +232,23 @@ func (p *PodWatcher) WaitForCompletion() (*corev1.Pod, error) {
 			// watch can be established, we list the pods and if we find any, call noPodEventsYetFn.
 			// Reminder, if we do get events, this ticker is stopped/cancelled
 			podList, _ := p.clientset.CoreV1().Pods(p.ns).List(p.ctx, p.listOpts)
+			if podList != nil {
+				podList.Items = p.unskippedPods(podList.Items)
+			}
 			// no need to return the error here, calling the no pod events listener is more important and it
 			// more than likely will treat a nil/empty PodList the same regardless
 			for _, fn := range p.noPodEventsYetFn {
 				fn(podList)
 			}
 
+		// the pod has not started running in time, i.e. it can't be scheduled for lack of capacity
+		case <-schedulerTimeout:
+			p.watcher.Stop()
+			for _, fn := range p.schedulerTimeoutFn {
+				fn(lastPod)
+			}
+			return lastPod, fmt.Errorf("pod has not started running within the scheduler timeout of %s", p.schedulerTimeout)
+
 		// watching over stop channel to stop the event loop on demand.
 		case <-p.stopCh:
 			p.watcher.Stop()
@@ -206,6 +257,37 @@ func (p *PodWatcher) WaitForCompletion() (*corev1.Pod, error) {
 	}
 }
 
+// unskippedPods returns the pods not skipped by the skip functions, i.e. when the label selector
+// matches several pods and only one of them is watched.
+func (p *PodWatcher) unskippedPods(pods []corev1.Pod) []corev1.Pod {
+	unskipped := []corev1.Pod{}
+	for i := range pods {
+		if !p.skipPod(&pods[i]) {
+			unskipped = append(unskipped, pods[i])
+		}
+	}
+	return unskipped
+}
+
+// skipPod checks whether any of the skip functions skips the pod.
+func (p *PodWatcher) skipPod(pod *corev1.Pod) bool {
+	for _, fn := range p.skipPodFn {
+		if fn(pod) {
+			return true
+		}
+	}
+	return false
+}
+
+// podStarted checks whether the pod has left the pending phase.
+func podStarted(pod *corev1.Pod) bool {
+	switch pod.Status.Phase {
+	case corev1.PodRunning, corev1.PodSucceeded, corev1.PodFailed:
+		return true
+	}
+	return false
+}
+
 // Start is a convenience method for capturing the use of both Connect and WaitForCompletion
 func (p *PodWatcher) Start(listOpts metav1.ListOptions) (*corev1.Pod, error) {
 	err := p.Connect(listOpts)
@@ -15,7 +15,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 )
 
-func Test_PodWatcher_RequestTimeout(t *testing.T) {
+func Test_PodWatcher_WatchTimeout(t *testing.T) {
 	g := o.NewWithT(t)
 	ctx := context.TODO()
 
@@ -244,3 +244,67 @@ func validateEventChannelData(got, expected, verb string, ok bool, t *testing.T)
 		t.Fatalf("test channel %s value was %s instead of %s", verb, got, expected)
 	}
 }
+
+func Test_PodWatcher_SchedulerTimeout(t *testing.T) {
+	g := o.NewWithT(t)
+	ctx := context.TODO()
+
+	clientset := fake.NewSimpleClientset()
+
+	pw, err := NewPodWatcher(ctx, math.MaxInt64, clientset, metav1.NamespaceDefault)
+	g.Expect(err).To(o.BeNil())
+
+	var timedOut *corev1.Pod
+	called := false
+	pw.WithSchedulerTimeout(100 * time.Millisecond).WithSchedulerTimeoutFn(func(pod *corev1.Pod) {
+		called = true
+		timedOut = pod
+	})
+
+	g.Expect(pw.Connect(metav1.ListOptions{})).To(o.Succeed())
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "pod"}}
+	pod.Status.Phase = corev1.PodPending
+	_, err = clientset.CoreV1().Pods(metav1.NamespaceDefault).Create(ctx, pod, metav1.CreateOptions{})
+	g.Expect(err).To(o.BeNil())
+
+	lastPod, err := pw.WaitForCompletion()
+	g.Expect(err).To(o.MatchError("pod has not started running within the scheduler timeout of 100ms"))
+	g.Expect(called).To(o.BeTrue())
+	g.Expect(timedOut).ToNot(o.BeNil())
+	g.Expect(lastPod.GetName()).To(o.Equal("pod"))
+
+	// once the pod is running the scheduler timeout no longer applies
+	pw, err = NewPodWatcher(ctx, 300*time.Millisecond, clientset, metav1.NamespaceDefault)
+	g.Expect(err).To(o.BeNil())
+	called = false
+	pw.WithSchedulerTimeout(100 * time.Millisecond).WithSchedulerTimeoutFn(func(_ *corev1.Pod) {
+		called = true
+	})
+
+	g.Expect(pw.Connect(metav1.ListOptions{})).To(o.Succeed())
+	pod.Status.Phase = corev1.PodRunning
+	_, err = clientset.CoreV1().Pods(metav1.NamespaceDefault).UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	g.Expect(err).To(o.BeNil())
+
+	_, err = pw.WaitForCompletion()
+	g.Expect(err).To(o.BeNil())
+	g.Expect(called).To(o.BeFalse())
+}
+
+func Test_PodWatcher_SkipPodsListed(t *testing.T) {
+	g := o.NewWithT(t)
+
+	pw, err := NewPodWatcher(context.TODO(), math.MaxInt64, fake.NewSimpleClientset(), metav1.NamespaceDefault)
+	g.Expect(err).To(o.BeNil())
+	pw.WithSkipPodFn(func(pod *corev1.Pod) bool {
+		return pod.Name != "pod-b"
+	})
+
+	// the pods listed when no events are observed yet honor the skip functions as well
+	pods := pw.unskippedPods([]corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-b"}},
+	})
+	g.Expect(pods).To(o.HaveLen(1))
+	g.Expect(pods[0].Name).To(o.Equal("pod-b"))
+}
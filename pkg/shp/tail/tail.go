@@ -8,11 +8,26 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/shipwright-io/cli/pkg/shp/util"
+)
+
+const (
+	// DefaultReconnectTail default amount of lines repeated when reconnecting to a log stream, so
+	// there are no gaps in the output.
+	DefaultReconnectTail = 5
+	// maxReconnects amount of times a broken log stream is reconnected before giving up.
+	maxReconnects = 5
 )
 
+// openStreamFn opens the log stream of the pod.
+type openStreamFn func(ctx context.Context, ns, podName string, opts *corev1.PodLogOptions) (io.ReadCloser, error)
+
 // Tail represents a "tail" command streaming log outputs to stdout interface, and errors are written
 // to stderr interface directly.
 type Tail struct {
@@ -21,6 +36,14 @@ type Tail struct {
 	stopCh    chan bool            // stop channel
 	stopLock  sync.Mutex
 	stopped   bool
+	streams   sync.WaitGroup // tracks the active log streams
+
+	openStream        openStreamFn    // opens the log streams
+	reconnectTail     int64           // lines repeated when reconnecting a broken log stream
+	reconnectInterval time.Duration   // initial interval between reconnection attempts
+	raw               bool            // write the log stream as is, without the container prefix
+	watchOnly         bool            // skip the lines logged before the stream starts
+	stepColors        util.StepColors // colors of the container prefixes, none when empty
 
 	stdout io.Writer
 	stderr io.Writer
@@ -36,35 +59,82 @@ func (t *Tail) SetStderr(w io.Writer) {
 	t.stderr = w
 }
 
-// Start start streaming logs for informed target.
+// SetReconnectTail set the amount of lines repeated when reconnecting a broken log stream.
+func (t *Tail) SetReconnectTail(lines int64) {
+	t.reconnectTail = lines
+}
+
+// SetRaw writes the log streams byte by byte as received, without prefixing the lines with the
+// container name. A broken stream is not reconnected, the lines repeated would end up in the output.
+func (t *Tail) SetRaw(raw bool) {
+	t.raw = raw
+}
+
+// SetWatchOnly streams only the lines logged from now on, skipping the lines logged before.
+func (t *Tail) SetWatchOnly(watchOnly bool) {
+	t.watchOnly = watchOnly
+}
+
+// SetStepColors colors the container name prefix of the lines with the color assigned to the
+// container, it must be set before starting the log streams.
+func (t *Tail) SetStepColors(colors util.StepColors) {
+	t.stepColors = colors
+}
+
+// Start start streaming logs for informed target. When the log stream breaks while the container is
+// still running, the stream is reconnected repeating the last lines, until the reconnection
+// attempts are exhausted, except for raw output, which is never repeated. A container still waiting
+// to start, i.e. a sidecar starting late, is waited for until the tail is stopped, or the pod is done.
 func (t *Tail) Start(ns, podName, container string) {
+	t.streams.Add(1)
 	go func() {
-		podClient := t.clientset.CoreV1().Pods(ns)
-		stream, err := podClient.GetLogs(podName, &corev1.PodLogOptions{
+		defer t.streams.Done()
+		opts := &corev1.PodLogOptions{
 			Follow:    true,
 			Container: container,
-		}).Stream(t.ctx)
-		if err != nil {
-			fmt.Fprintln(t.stderr, err)
-			return
 		}
-		defer func() {
-			if err := stream.Close(); err != nil {
-				fmt.Fprintf(t.stderr, "Failed to close stream: %v", err)
+		if t.watchOnly {
+			noLines := int64(0)
+			opts.TailLines = &noLines
+		}
+		interval := t.reconnectInterval
+		for attempt := 0; ; {
+			err := t.stream(ns, podName, container, opts)
+			if err == nil || t.isStopped() {
+				return
 			}
-		}()
-
-		go func() {
-			<-t.stopCh
-			if err := stream.Close(); err != nil {
-				fmt.Fprintf(t.stderr, "Failed to close stream: %v", err)
+			phase, state := t.containerState(ns, podName, container)
+			if state.Waiting != nil {
+				// the container never starts once the pod is done, i.e. after an init-container failure
+				if phase == corev1.PodSucceeded || phase == corev1.PodFailed {
+					return
+				}
+				select {
+				case <-t.stopCh:
+					return
+				case <-time.After(t.reconnectInterval):
+				}
+				continue
+			}
+			if attempt == maxReconnects || state.Running == nil {
+				fmt.Fprintln(t.stderr, err)
+				return
+			}
+			// the lines repeated on reconnection would break the byte by byte output
+			if t.raw {
+				fmt.Fprintf(t.stderr, "Log stream of container %q broken (%v), not reconnecting with raw output\n", container, err)
+				return
 			}
-		}()
 
-		containerName := strings.TrimPrefix(container, "step-")
-		sc := bufio.NewScanner(stream)
-		for sc.Scan() {
-			fmt.Fprintf(t.stdout, "[%s] %s\n", containerName, sc.Text())
+			fmt.Fprintf(t.stderr, "Log stream of container %q broken (%v), reconnecting...\n", container, err)
+			select {
+			case <-t.stopCh:
+				return
+			case <-time.After(interval):
+			}
+			attempt++
+			interval *= 2
+			opts.TailLines = &t.reconnectTail
 		}
 	}()
 	go func() {
@@ -73,6 +143,77 @@ func (t *Tail) Start(ns, podName, container string) {
 	}()
 }
 
+// stream writes the log lines until the stream reaches the end, or is closed by stopping the tail,
+// returning the error which broke the stream otherwise.
+func (t *Tail) stream(ns, podName, container string, opts *corev1.PodLogOptions) error {
+	stream, err := t.openStream(t.ctx, ns, podName, opts)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-t.stopCh:
+		case <-done:
+		}
+		if err := stream.Close(); err != nil {
+			fmt.Fprintf(t.stderr, "Failed to close stream: %v", err)
+		}
+	}()
+
+	if t.raw {
+		_, err = io.Copy(t.stdout, stream)
+		return err
+	}
+
+	prefix := t.stepColors.Color(container, fmt.Sprintf("[%s]", strings.TrimPrefix(container, "step-")))
+	sc := bufio.NewScanner(stream)
+	for sc.Scan() {
+		fmt.Fprintf(t.stdout, "%s %s\n", prefix, sc.Text())
+	}
+	return sc.Err()
+}
+
+// isStopped checks whether the tail is stopped.
+func (t *Tail) isStopped() bool {
+	t.stopLock.Lock()
+	defer t.stopLock.Unlock()
+	return t.stopped
+}
+
+// containerState returns the pod phase and the container state, to decide whether a broken stream
+// should be reconnected, or the container is yet to start. Both are empty when the pod is not found
+// or is being deleted.
+func (t *Tail) containerState(ns, podName, container string) (corev1.PodPhase, corev1.ContainerState) {
+	pod, err := t.clientset.CoreV1().Pods(ns).Get(t.ctx, podName, metav1.GetOptions{})
+	if err != nil || pod.DeletionTimestamp != nil {
+		return "", corev1.ContainerState{}
+	}
+	statuses := append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.Name == container {
+			return pod.Status.Phase, status.State
+		}
+	}
+	return pod.Status.Phase, corev1.ContainerState{}
+}
+
+// Wait blocks until all log streams reach the end, which happens when the containers terminate, or
+// until the timeout expires.
+func (t *Tail) Wait(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		t.streams.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
+
 // Stop closes stop channel to stop log streaming.
 func (t *Tail) Stop() {
 	// employ sync because of observed 'panic: close of closed channel' when running build run log following
@@ -92,7 +233,12 @@ func NewTail(ctx context.Context, clientset kubernetes.Interface) *Tail {
 		clientset: clientset,
 		stopCh:    make(chan bool, 1),
 		stopLock:  sync.Mutex{},
-		stdout:    os.Stdout,
-		stderr:    os.Stderr,
+		openStream: func(ctx context.Context, ns, podName string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+			return clientset.CoreV1().Pods(ns).GetLogs(podName, opts).Stream(ctx)
+		},
+		reconnectTail:     DefaultReconnectTail,
+		reconnectInterval: time.Second,
+		stdout:            os.Stdout,
+		stderr:            os.Stderr,
 	}
 }
@@ -3,7 +3,9 @@ package tail
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +13,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/shipwright-io/cli/pkg/shp/util"
 )
 
 func Test_Tail(t *testing.T) {
@@ -59,3 +63,239 @@ func Test_Tail(t *testing.T) {
 	g.Expect(err).To(o.BeNil())
 	g.Expect(stderrNumBytes).To(o.Equal(int64(0)))
 }
+
+// brokenReader returns the informed data, and then the error.
+type brokenReader struct {
+	data io.Reader
+	err  error
+}
+
+func (b *brokenReader) Read(p []byte) (int, error) {
+	n, err := b.data.Read(p)
+	if err == io.EOF {
+		return n, b.err
+	}
+	return n, err
+}
+
+func Test_TailReconnect(t *testing.T) {
+	tests := []struct {
+		name       string
+		running    bool
+		breaks     int
+		expected   string
+		reconnects int
+	}{
+		{name: "reconnects while the container runs", running: true, breaks: 2, expected: "[c] one\n[c] two\n[c] two\n[c] three\n", reconnects: 2},
+		{name: "gives up when the container terminated", running: false, breaks: 1, expected: "[c] one\n[c] two\n", reconnects: 0},
+		{name: "gives up when the reconnections are exhausted", running: true, breaks: maxReconnects + 1, expected: "[c] one\n[c] two\n", reconnects: maxReconnects},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "pod"}}
+			state := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+			if test.running {
+				state = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+			}
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "step-c", State: state}}
+
+			logTail := NewTail(context.TODO(), fake.NewSimpleClientset(pod))
+			logTail.reconnectInterval = time.Millisecond
+			logTail.SetReconnectTail(1)
+
+			tailLines := []*int64{}
+			logTail.openStream = func(_ context.Context, _, _ string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+				tailLines = append(tailLines, opts.TailLines)
+				switch attempt := len(tailLines); {
+				case attempt > test.breaks:
+					return io.NopCloser(strings.NewReader("two\nthree\n")), nil
+				case attempt == 1:
+					return io.NopCloser(&brokenReader{data: strings.NewReader("one\ntwo\n"), err: io.ErrUnexpectedEOF}), nil
+				default:
+					return nil, errors.New("connection refused")
+				}
+			}
+
+			var stdout, stderr bytes.Buffer
+			logTail.SetStdout(&stdout)
+			logTail.SetStderr(&stderr)
+			logTail.Start(metav1.NamespaceDefault, "pod", "step-c")
+			logTail.Wait(5 * time.Second)
+			logTail.Stop()
+
+			g.Expect(stdout.String()).To(o.Equal(test.expected))
+			g.Expect(strings.Count(stderr.String(), "reconnecting...")).To(o.Equal(test.reconnects))
+			g.Expect(tailLines[0]).To(o.BeNil())
+			for _, lines := range tailLines[1:] {
+				g.Expect(*lines).To(o.Equal(int64(1)))
+			}
+		})
+	}
+}
+
+func Test_TailWaitsForContainerToStart(t *testing.T) {
+	g := o.NewWithT(t)
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "pod"}}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "sidecar",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}},
+	}}
+	waitingErr := errors.New(`container "sidecar" in pod "pod" is waiting to start: PodInitializing`)
+
+	// the container starts on the third attempt, more than the reconnections allowed are not counted
+	logTail := NewTail(context.TODO(), fake.NewSimpleClientset(pod))
+	logTail.reconnectInterval = time.Millisecond
+	attempts := 0
+	logTail.openStream = func(_ context.Context, _, _ string, _ *corev1.PodLogOptions) (io.ReadCloser, error) {
+		if attempts++; attempts <= maxReconnects+2 {
+			return nil, waitingErr
+		}
+		return io.NopCloser(strings.NewReader("late\n")), nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	logTail.SetStdout(&stdout)
+	logTail.SetStderr(&stderr)
+	logTail.Start(metav1.NamespaceDefault, "pod", "sidecar")
+	logTail.Wait(5 * time.Second)
+	logTail.Stop()
+
+	g.Expect(stdout.String()).To(o.Equal("[sidecar] late\n"))
+	g.Expect(stderr.String()).To(o.BeEmpty())
+
+	// a container which never starts is waited for until the tail stops
+	logTail = NewTail(context.TODO(), fake.NewSimpleClientset(pod))
+	logTail.reconnectInterval = time.Millisecond
+	logTail.openStream = func(_ context.Context, _, _ string, _ *corev1.PodLogOptions) (io.ReadCloser, error) {
+		return nil, waitingErr
+	}
+	stdout.Reset()
+	logTail.SetStdout(&stdout)
+	logTail.SetStderr(&stderr)
+	logTail.Start(metav1.NamespaceDefault, "pod", "sidecar")
+	logTail.Wait(20 * time.Millisecond)
+	logTail.Stop()
+	logTail.Wait(5 * time.Second)
+
+	g.Expect(stdout.String()).To(o.BeEmpty())
+	g.Expect(stderr.String()).To(o.BeEmpty())
+
+	// a container which never started is not waited for once the pod has failed, i.e. when an
+	// init-container failed
+	pod.Status.Phase = corev1.PodFailed
+	logTail = NewTail(context.TODO(), fake.NewSimpleClientset(pod))
+	logTail.reconnectInterval = time.Millisecond
+	attempts = 0
+	logTail.openStream = func(_ context.Context, _, _ string, _ *corev1.PodLogOptions) (io.ReadCloser, error) {
+		attempts++
+		return nil, waitingErr
+	}
+	logTail.SetStdout(&stdout)
+	logTail.SetStderr(&stderr)
+	logTail.Start(metav1.NamespaceDefault, "pod", "sidecar")
+	start := time.Now()
+	logTail.Wait(5 * time.Second)
+	g.Expect(time.Since(start)).To(o.BeNumerically("<", time.Second))
+	logTail.Stop()
+
+	g.Expect(attempts).To(o.Equal(1))
+	g.Expect(stdout.String()).To(o.BeEmpty())
+	g.Expect(stderr.String()).To(o.BeEmpty())
+}
+
+func Test_TailRaw(t *testing.T) {
+	g := o.NewWithT(t)
+
+	logTail := NewTail(context.TODO(), fake.NewSimpleClientset())
+	logTail.SetRaw(true)
+	logTail.openStream = func(_ context.Context, _, _ string, _ *corev1.PodLogOptions) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("one\r\n\x1b[1mtwo\x1b[0m\nthree")), nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	logTail.SetStdout(&stdout)
+	logTail.SetStderr(&stderr)
+	logTail.Start(metav1.NamespaceDefault, "pod", "step-c")
+	logTail.Wait(5 * time.Second)
+	logTail.Stop()
+
+	g.Expect(stdout.String()).To(o.Equal("one\r\n\x1b[1mtwo\x1b[0m\nthree"))
+	g.Expect(stderr.String()).To(o.BeEmpty())
+}
+
+func Test_TailRawBrokenStream(t *testing.T) {
+	g := o.NewWithT(t)
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "pod"}}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "step-c",
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}}
+
+	// the stream breaks while the container runs, it's not reconnected repeating the last lines
+	logTail := NewTail(context.TODO(), fake.NewSimpleClientset(pod))
+	logTail.reconnectInterval = time.Millisecond
+	logTail.SetRaw(true)
+	attempts := 0
+	logTail.openStream = func(_ context.Context, _, _ string, _ *corev1.PodLogOptions) (io.ReadCloser, error) {
+		attempts++
+		return io.NopCloser(&brokenReader{data: strings.NewReader("one\ntwo\n"), err: io.ErrUnexpectedEOF}), nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	logTail.SetStdout(&stdout)
+	logTail.SetStderr(&stderr)
+	logTail.Start(metav1.NamespaceDefault, "pod", "step-c")
+	logTail.Wait(5 * time.Second)
+	logTail.Stop()
+
+	g.Expect(attempts).To(o.Equal(1))
+	g.Expect(stdout.String()).To(o.Equal("one\ntwo\n"))
+	g.Expect(stderr.String()).To(o.Equal(
+		"Log stream of container \"step-c\" broken (unexpected EOF), not reconnecting with raw output\n"))
+}
+
+func Test_TailStepColors(t *testing.T) {
+	g := o.NewWithT(t)
+
+	logTail := NewTail(context.TODO(), fake.NewSimpleClientset())
+	logTail.SetStepColors(util.NewStepColors([]string{"step-c"}))
+	logTail.openStream = func(_ context.Context, _, _ string, _ *corev1.PodLogOptions) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("one\n")), nil
+	}
+
+	var stdout bytes.Buffer
+	logTail.SetStdout(&stdout)
+	logTail.Start(metav1.NamespaceDefault, "pod", "step-c")
+	logTail.Wait(5 * time.Second)
+	logTail.Stop()
+
+	g.Expect(stdout.String()).To(o.Equal("\x1b[36m[c]\x1b[0m one\n"))
+}
+
+func Test_TailWatchOnly(t *testing.T) {
+	g := o.NewWithT(t)
+
+	logTail := NewTail(context.TODO(), fake.NewSimpleClientset())
+	logTail.SetWatchOnly(true)
+	var opened *corev1.PodLogOptions
+	logTail.openStream = func(_ context.Context, _, _ string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+		opened = opts
+		return io.NopCloser(strings.NewReader("new\n")), nil
+	}
+
+	var stdout bytes.Buffer
+	logTail.SetStdout(&stdout)
+	logTail.Start(metav1.NamespaceDefault, "pod", "step-c")
+	logTail.Wait(5 * time.Second)
+	logTail.Stop()
+
+	g.Expect(stdout.String()).To(o.Equal("[c] new\n"))
+	g.Expect(opened.Follow).To(o.BeTrue())
+	g.Expect(opened.TailLines).ToNot(o.BeNil())
+	g.Expect(*opened.TailLines).To(o.Equal(int64(0)))
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	buildclientset "github.com/shipwright-io/build/pkg/client/clientset/versioned"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CancelBuildRun requests the BuildRun cancellation, by setting its state to canceled, the build
+// controller then stops the BuildRun pod.
+func CancelBuildRun(ctx context.Context, client buildclientset.Interface, namespace, name string) error {
+	type patchStringValue struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value string `json:"value"`
+	}
+	payload := []patchStringValue{{
+		Op:    "replace",
+		Path:  "/spec/state",
+		Value: buildv1alpha1.BuildRunStateCancel,
+	}}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = client.ShipwrightV1alpha1().BuildRuns(namespace).Patch(ctx, name, types.JSONPatchType, data, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ANSI escape sequences, all colors share the same length.
+const (
+	colorReset   = "\x1b[0m"
+	colorDefault = "\x1b[39m"
+	colorGreen   = "\x1b[32m"
+	colorRed     = "\x1b[31m"
+)
+
+// stepPalette colors assigned to the steps, leaving out green and red which stand for the status.
+var stepPalette = []string{
+	"\x1b[36m", // cyan
+	"\x1b[35m", // magenta
+	"\x1b[33m", // yellow
+	"\x1b[34m", // blue
+	"\x1b[96m", // bright cyan
+	"\x1b[95m", // bright magenta
+	"\x1b[93m", // bright yellow
+	"\x1b[94m", // bright blue
+}
+
+// ColorStatus wraps the text with the color matching the condition status, green for true and red
+// for false, when enabled. Other statuses are wrapped with the default color, so all texts carry
+// escape sequences of the same length and tabular output stays aligned.
+func ColorStatus(text string, status corev1.ConditionStatus, enabled bool) string {
+	if !enabled {
+		return text
+	}
+	color := colorDefault
+	switch status {
+	case corev1.ConditionTrue:
+		color = colorGreen
+	case corev1.ConditionFalse:
+		color = colorRed
+	}
+	return color + text + colorReset
+}
+
+// StepColors colors assigned to the steps, by container name.
+type StepColors map[string]string
+
+// NewStepColors assigns a color to each step following the order of the sorted names, so the same
+// set of steps gets the same colors on every run. The colors repeat when there are more steps than
+// colors.
+func NewStepColors(names []string) StepColors {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	colors := StepColors{}
+	for i, name := range sorted {
+		colors[name] = stepPalette[i%len(stepPalette)]
+	}
+	return colors
+}
+
+// Color wraps the text with the color assigned to the step, the text is returned as is for unknown
+// steps, or when no colors are assigned at all.
+func (s StepColors) Color(step, text string) string {
+	color, ok := s[step]
+	if !ok {
+		return text
+	}
+	return color + text + colorReset
+}
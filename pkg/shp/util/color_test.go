@@ -0,0 +1,38 @@
+package util
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestColorStatus(t *testing.T) {
+	g := o.NewWithT(t)
+
+	g.Expect(ColorStatus("Succeeded", corev1.ConditionTrue, false)).To(o.Equal("Succeeded"))
+	g.Expect(ColorStatus("Succeeded", corev1.ConditionTrue, true)).To(o.Equal("\x1b[32mSucceeded\x1b[0m"))
+	g.Expect(ColorStatus("Failed", corev1.ConditionFalse, true)).To(o.Equal("\x1b[31mFailed\x1b[0m"))
+
+	// every status carries the same amount of escape characters
+	running := ColorStatus("Running", corev1.ConditionUnknown, true)
+	g.Expect(running).To(o.Equal("\x1b[39mRunning\x1b[0m"))
+	g.Expect(len(running) - len("Running")).To(o.Equal(len(ColorStatus("", corev1.ConditionTrue, true))))
+}
+
+func TestStepColors(t *testing.T) {
+	g := o.NewWithT(t)
+
+	colors := NewStepColors([]string{"step-build", "step-source-default", "step-image-digest-exporter"})
+	g.Expect(colors.Color("step-build", "[build]")).To(o.Equal("\x1b[36m[build]\x1b[0m"))
+	g.Expect(colors.Color("step-image-digest-exporter", "[image-digest-exporter]")).To(o.Equal("\x1b[35m[image-digest-exporter]\x1b[0m"))
+	g.Expect(colors.Color("step-source-default", "[source-default]")).To(o.Equal("\x1b[33m[source-default]\x1b[0m"))
+	g.Expect(colors.Color("step-unknown", "[unknown]")).To(o.Equal("[unknown]"))
+
+	// the same steps get the same colors regardless of their order
+	g.Expect(NewStepColors([]string{"step-source-default", "step-image-digest-exporter", "step-build"})).To(o.Equal(colors))
+
+	var disabled StepColors
+	g.Expect(disabled.Color("step-build", "[build]")).To(o.Equal("[build]"))
+}
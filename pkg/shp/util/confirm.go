@@ -0,0 +1,25 @@
+package util
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Confirm writes the question followed by the "[y/N]" choices, and reads the answer. Only "y" and
+// "yes" confirm, case insensitive, any other answer or the end of the input deny. The prompt is
+// expected to be written on stderr, as kubectl does, keeping stdout for the command output.
+func Confirm(in io.Reader, errOut io.Writer, question string) (bool, error) {
+	fmt.Fprintf(errOut, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
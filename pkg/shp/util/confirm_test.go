@@ -0,0 +1,34 @@
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestConfirm(t *testing.T) {
+	g := o.NewWithT(t)
+
+	tests := []struct {
+		answer    string
+		confirmed bool
+	}{
+		{answer: "y\n", confirmed: true},
+		{answer: " YES \n", confirmed: true},
+		{answer: "yes", confirmed: true},
+		{answer: "\n"},
+		{answer: "no\n"},
+		{answer: "yy\n"},
+		{answer: ""},
+	}
+
+	for _, test := range tests {
+		errOut := &bytes.Buffer{}
+		confirmed, err := Confirm(strings.NewReader(test.answer), errOut, "Delete 2 Builds?")
+		g.Expect(err).ToNot(o.HaveOccurred())
+		g.Expect(confirmed).To(o.Equal(test.confirmed), "answer %q", test.answer)
+		g.Expect(errOut.String()).To(o.Equal("Delete 2 Builds? [y/N]: "))
+	}
+}
@@ -0,0 +1,146 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// WarningEventsLimit maximum number of warning events shown when a BuildRun fails.
+const WarningEventsLimit = 10
+
+// BuildRunWarningEvents returns the most recent warning events, up to the limit, of the BuildRun,
+// its TaskRun and pods, which tell i.e. why the pod could not be scheduled or the image pulled.
+func BuildRunWarningEvents(
+	ctx context.Context,
+	client kubernetes.Interface,
+	br *buildv1alpha1.BuildRun,
+	limit int,
+) ([]corev1.Event, error) {
+	return buildRunEvents(ctx, client, br, corev1.EventTypeWarning, limit)
+}
+
+// BuildRunEvents returns the most recent events of any type, up to the limit, of the BuildRun, its
+// TaskRun and pods.
+func BuildRunEvents(
+	ctx context.Context,
+	client kubernetes.Interface,
+	br *buildv1alpha1.BuildRun,
+	limit int,
+) ([]corev1.Event, error) {
+	return buildRunEvents(ctx, client, br, "", limit)
+}
+
+// buildRunEvents returns the most recent events of the BuildRun, its TaskRun and pods, only the
+// events of the informed type unless empty.
+func buildRunEvents(
+	ctx context.Context,
+	client kubernetes.Interface,
+	br *buildv1alpha1.BuildRun,
+	eventType string,
+	limit int,
+) ([]corev1.Event, error) {
+	names := []string{br.Name}
+	if br.Status.LatestTaskRunRef != nil {
+		names = append(names, *br.Status.LatestTaskRunRef)
+	}
+	if details := br.Status.FailureDetails; details != nil && details.Location != nil {
+		names = append(names, details.Location.Pod)
+	}
+	pods, err := client.CoreV1().Pods(br.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", buildv1alpha1.LabelBuildRun, br.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		names = append(names, pod.Name)
+	}
+	return recentEvents(ctx, client, br.Namespace, names, eventType, limit)
+}
+
+// RecentWarningEvents returns the most recent warning events involving the objects informed by
+// name, up to the limit, ordered from the oldest to the newest, like "kubectl describe" shows.
+func RecentWarningEvents(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace string,
+	names []string,
+	limit int,
+) ([]corev1.Event, error) {
+	return recentEvents(ctx, client, namespace, names, corev1.EventTypeWarning, limit)
+}
+
+// recentEvents returns the most recent events involving the objects informed by name, up to the
+// limit, only the events of the informed type unless empty.
+func recentEvents(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace string,
+	names []string,
+	eventType string,
+	limit int,
+) ([]corev1.Event, error) {
+	queried := map[string]bool{}
+	seen := map[string]bool{}
+	recent := []corev1.Event{}
+	for _, name := range names {
+		if name == "" || queried[name] {
+			continue
+		}
+		queried[name] = true
+		events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("involvedObject.name=%s", name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events.Items {
+			if (eventType != "" && event.Type != eventType) || event.InvolvedObject.Name != name || seen[event.Name] {
+				continue
+			}
+			seen[event.Name] = true
+			recent = append(recent, event)
+		}
+	}
+
+	sort.SliceStable(recent, func(i, j int) bool {
+		return EventTime(&recent[i]).Before(EventTime(&recent[j]))
+	})
+	if limit > 0 && len(recent) > limit {
+		recent = recent[len(recent)-limit:]
+	}
+	return recent, nil
+}
+
+// FormatWarningEvents renders the events as the "kubectl describe" events section, one per line,
+// with the involved object, the reason and the message.
+func FormatWarningEvents(events []corev1.Event) string {
+	var b strings.Builder
+	b.WriteString("Recent warning events:\n")
+	for _, event := range events {
+		fmt.Fprintf(&b, "  %s/%s %s: %s\n", strings.ToLower(event.InvolvedObject.Kind),
+			event.InvolvedObject.Name, event.Reason, strings.TrimSpace(event.Message))
+	}
+	return b.String()
+}
+
+// EventTime returns the last time the event was observed.
+func EventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,79 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func TestBuildRunWarningEvents(t *testing.T) {
+	g := o.NewWithT(t)
+
+	now := time.Now()
+	event := func(kind, name, eventType, reason string, age time.Duration) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      fmt.Sprintf("%s.%s", name, reason),
+			},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name},
+			Type:           eventType,
+			Reason:         reason,
+			Message:        reason + " message",
+			LastTimestamp:  metav1.NewTime(now.Add(-age)),
+		}
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: metav1.NamespaceDefault,
+		Name:      "br-pod",
+		Labels:    map[string]string{buildv1alpha1.LabelBuildRun: "br"},
+	}}
+	objects := []runtime.Object{
+		pod,
+		event("Pod", "br-pod", corev1.EventTypeWarning, "FailedMount", time.Minute),
+		event("Pod", "br-pod", corev1.EventTypeNormal, "Scheduled", 3*time.Minute),
+		event("Pod", "br-pod", corev1.EventTypeWarning, "FailedScheduling", 2*time.Minute),
+		event("TaskRun", "br-taskrun", corev1.EventTypeWarning, "Failed", 0),
+		event("Pod", "other-pod", corev1.EventTypeWarning, "BackOff", 0),
+	}
+	br := &buildv1alpha1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br"}}
+	br.Status.LatestTaskRunRef = pointer.String("br-taskrun")
+
+	client := fake.NewSimpleClientset(objects...)
+	events, err := BuildRunWarningEvents(context.Background(), client, br, 10)
+	g.Expect(err).ToNot(o.HaveOccurred())
+
+	reasons := []string{}
+	for _, e := range events {
+		reasons = append(reasons, e.Reason)
+	}
+	g.Expect(reasons).To(o.Equal([]string{"FailedScheduling", "FailedMount", "Failed"}))
+	g.Expect(FormatWarningEvents(events)).To(o.Equal("Recent warning events:\n" +
+		"  pod/br-pod FailedScheduling: FailedScheduling message\n" +
+		"  pod/br-pod FailedMount: FailedMount message\n" +
+		"  taskrun/br-taskrun Failed: Failed message\n"))
+
+	// only the most recent events are kept
+	events, err = BuildRunWarningEvents(context.Background(), client, br, 2)
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(events).To(o.HaveLen(2))
+	g.Expect(events[0].Reason).To(o.Equal("FailedMount"))
+	g.Expect(events[1].Reason).To(o.Equal("Failed"))
+
+	// all event types
+	events, err = BuildRunEvents(context.Background(), client, br, 10)
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(events).To(o.HaveLen(4))
+	g.Expect(events[0].Reason).To(o.Equal("Scheduled"))
+}
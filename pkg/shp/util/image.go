@@ -0,0 +1,34 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+// OutputImage returns the output image of the BuildRun, either overwritten on the BuildRun or as
+// recorded on the Build spec used, empty when unknown.
+func OutputImage(br *buildv1alpha1.BuildRun) string {
+	if br.Spec.Output != nil && br.Spec.Output.Image != "" {
+		return br.Spec.Output.Image
+	}
+	if br.Status.BuildSpec != nil {
+		return br.Status.BuildSpec.Output.Image
+	}
+	return ""
+}
+
+// ImageDigestReference pins the image to the digest, replacing the tag or digest informed, i.e.
+// "registry/app:latest" becomes "registry/app@sha256:...". Only the digest is returned when the
+// image is unknown.
+func ImageDigestReference(image, digest string) string {
+	if image == "" {
+		return digest
+	}
+	image = strings.SplitN(image, "@", 2)[0]
+	if i := strings.LastIndex(image, ":"); i > 0 && !strings.Contains(image[i:], "/") {
+		image = image[:i]
+	}
+	return fmt.Sprintf("%s@%s", image, digest)
+}
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+func TestOutputImage(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	br := &buildv1alpha1.BuildRun{}
+	g.Expect(OutputImage(br)).To(gomega.BeEmpty())
+
+	br.Status.BuildSpec = &buildv1alpha1.BuildSpec{Output: buildv1alpha1.Image{Image: "registry/app"}}
+	g.Expect(OutputImage(br)).To(gomega.Equal("registry/app"))
+
+	// the BuildRun output takes precedence over the Build's
+	br.Spec.Output = &buildv1alpha1.Image{Image: "registry/fork"}
+	g.Expect(OutputImage(br)).To(gomega.Equal("registry/fork"))
+}
+
+func TestImageDigestReference(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	for image, expected := range map[string]string{
+		"":                                "sha256:abc",
+		"registry/app":                    "registry/app@sha256:abc",
+		"registry/app:latest":             "registry/app@sha256:abc",
+		"registry:5000/app":               "registry:5000/app@sha256:abc",
+		"registry:5000/app:v1":            "registry:5000/app@sha256:abc",
+		"registry/app@sha256:def":         "registry/app@sha256:abc",
+		"registry:5000/app:v1@sha256:def": "registry:5000/app@sha256:abc",
+	} {
+		g.Expect(ImageDigestReference(image, "sha256:abc")).To(gomega.Equal(expected), image)
+	}
+}
@@ -0,0 +1,86 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Formats of the CLI's own diagnostic messages.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// Levels of the diagnostic messages, as written on the JSON lines.
+const (
+	LogLevelInfo    = "info"
+	LogLevelWarning = "warning"
+)
+
+// Logger writes the CLI's own informational and warning messages, as opposed to the build logs,
+// either as plain text or as JSON lines carrying the level, message and time.
+type Logger struct {
+	w      io.Writer
+	format string
+	now    func() time.Time
+}
+
+// logLine JSON representation of a diagnostic message.
+type logLine struct {
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+	Time  string `json:"time"`
+}
+
+// ValidateLogFormat checks the log format is supported.
+func ValidateLogFormat(format string) error {
+	if format != LogFormatText && format != LogFormatJSON {
+		return fmt.Errorf("invalid --log-format %q, must be either %q or %q", format, LogFormatText, LogFormatJSON)
+	}
+	return nil
+}
+
+// NewLogger returns a Logger writing on the informed writer, an unknown format is treated as text.
+func NewLogger(w io.Writer, format string) *Logger {
+	return &Logger{w: w, format: format, now: time.Now}
+}
+
+// Info writes an informational message.
+func (l *Logger) Info(msg string) {
+	l.write(LogLevelInfo, msg)
+}
+
+// Warning writes a warning message.
+func (l *Logger) Warning(msg string) {
+	l.write(LogLevelWarning, msg)
+}
+
+// write renders the message on the logger format. Plain text messages are written as is, only
+// ensuring the line break, while the JSON lines carry the message without the surrounding blanks.
+// Empty messages are skipped.
+func (l *Logger) write(level, msg string) {
+	if msg == "" {
+		return
+	}
+	if l.format != LogFormatJSON {
+		if !strings.HasSuffix(msg, "\n") {
+			msg += "\n"
+		}
+		fmt.Fprint(l.w, msg)
+		return
+	}
+
+	data, err := json.Marshal(logLine{
+		Level: level,
+		Msg:   strings.TrimSpace(msg),
+		Time:  l.now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		fmt.Fprint(l.w, msg)
+		return
+	}
+	fmt.Fprintln(l.w, string(data))
+}
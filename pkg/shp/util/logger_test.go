@@ -0,0 +1,32 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
+)
+
+func TestLogger(t *testing.T) {
+	g := o.NewWithT(t)
+
+	g.Expect(ValidateLogFormat(LogFormatText)).To(o.Succeed())
+	g.Expect(ValidateLogFormat(LogFormatJSON)).To(o.Succeed())
+	g.Expect(ValidateLogFormat("yaml")).To(o.MatchError(`invalid --log-format "yaml", must be either "text" or "json"`))
+
+	out := &bytes.Buffer{}
+	logger := NewLogger(out, LogFormatText)
+	logger.Info("Pod \"pod\" is in state \"Pending\"...\n")
+	logger.Warning("no tag informed")
+	g.Expect(out.String()).To(o.Equal("Pod \"pod\" is in state \"Pending\"...\nno tag informed\n"))
+
+	out.Reset()
+	logger = NewLogger(out, LogFormatJSON)
+	logger.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	logger.Info("Pod \"pod\" is in state \"Pending\"...\n")
+	logger.Warning("no tag informed")
+	g.Expect(out.String()).To(o.Equal(
+		`{"level":"info","msg":"Pod \"pod\" is in state \"Pending\"...","time":"2024-01-02T03:04:05Z"}` + "\n" +
+			`{"level":"warning","msg":"no tag informed","time":"2024-01-02T03:04:05Z"}` + "\n"))
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// PrefixWriter prefixes each line written, i.e. with the name of the build it belongs to, so the
+// output of concurrent commands sharing the same writer stays readable. Only complete lines are
+// written, holding the lock shared among the writers, the last incomplete line is kept until
+// Flush is called.
+type PrefixWriter struct {
+	w      io.Writer
+	prefix []byte
+	lock   *sync.Mutex // shared among the writers on the same underlying writer
+	buf    []byte      // incomplete line
+}
+
+// NewPrefixWriter instantiates a PrefixWriter on the underlying writer, using the lock shared among
+// the writers on the same underlying writer.
+func NewPrefixWriter(w io.Writer, prefix string, lock *sync.Mutex) *PrefixWriter {
+	return &PrefixWriter{w: w, prefix: []byte(prefix), lock: lock}
+}
+
+// Write writes the complete lines prefixed, keeping the last incomplete line.
+func (p *PrefixWriter) Write(data []byte) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.buf = append(p.buf, data...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := p.writeLine(p.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		p.buf = p.buf[i+1:]
+	}
+	return len(data), nil
+}
+
+// Flush writes the last incomplete line prefixed, terminated by a new line.
+func (p *PrefixWriter) Flush() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(p.buf) == 0 {
+		return nil
+	}
+	line := append(p.buf, '\n')
+	p.buf = nil
+	return p.writeLine(line)
+}
+
+// writeLine writes the prefix followed by the line, it must be called holding the lock.
+func (p *PrefixWriter) writeLine(line []byte) error {
+	_, err := p.w.Write(append(append([]byte{}, p.prefix...), line...))
+	return err
+}
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	out := &bytes.Buffer{}
+	lock := &sync.Mutex{}
+	a := NewPrefixWriter(out, "a | ", lock)
+	b := NewPrefixWriter(out, "b | ", lock)
+
+	fmt.Fprint(a, "first line of a\nsecond ")
+	fmt.Fprint(b, "first line of b\n")
+	fmt.Fprint(a, "line of a\nincomplete")
+	g.Expect(out.String()).To(gomega.Equal("a | first line of a\nb | first line of b\na | second line of a\n"))
+
+	g.Expect(a.Flush()).To(gomega.Succeed())
+	g.Expect(b.Flush()).To(gomega.Succeed())
+	g.Expect(out.String()).To(gomega.HaveSuffix("a | second line of a\na | incomplete\n"))
+
+	// concurrent writers never mix up their lines
+	out.Reset()
+	var wg sync.WaitGroup
+	for _, w := range []*PrefixWriter{a, b} {
+		wg.Add(1)
+		go func(w *PrefixWriter) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				fmt.Fprintf(w, "line %d", i)
+				fmt.Fprintln(w)
+			}
+		}(w)
+	}
+	wg.Wait()
+	lines := bytes.Split(bytes.TrimSuffix(out.Bytes(), []byte("\n")), []byte("\n"))
+	g.Expect(lines).To(gomega.HaveLen(200))
+	for _, line := range lines {
+		g.Expect(string(line)).To(gomega.MatchRegexp(`^[ab] \| line \d+$`))
+	}
+}
@@ -0,0 +1,24 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// FilterableFields are the fields the API server is able to filter custom resources by.
+var FilterableFields = []string{"metadata.name", "metadata.namespace"}
+
+// FieldSelectorError translates the API server error about an unsupported field selector into a
+// friendly message, listing the fields that can be used. Other errors are returned unchanged.
+func FieldSelectorError(err error, fieldSelector string) error {
+	if err == nil || fieldSelector == "" {
+		return err
+	}
+	if k8serrors.IsBadRequest(err) && strings.Contains(err.Error(), "field label not supported") {
+		return fmt.Errorf("field selector %q is not supported, the fields available to filter on are: %s",
+			fieldSelector, strings.Join(FilterableFields, ", "))
+	}
+	return err
+}